@@ -0,0 +1,13 @@
+package api
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// JobRun is a single execution of a CronJob: the Job it created and the pods
+// that Job produced.
+type JobRun struct {
+	Job  batchv1.Job
+	Pods []corev1.Pod
+}
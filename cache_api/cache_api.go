@@ -0,0 +1,279 @@
+package cacheapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/deployment_api"
+	"github.com/kaudit/api/internal/throttle"
+	"github.com/kaudit/api/namespace_api"
+	"github.com/kaudit/api/pod_api"
+	"github.com/kaudit/api/service_api"
+)
+
+// Option configures a Cache.
+type Option func(*config)
+
+type config struct {
+	namespace    string
+	resyncPeriod time.Duration
+	logger       *slog.Logger
+	limiter      *api.RateLimiter
+}
+
+// WithNamespace restricts the namespaced caches (pods, services and deployments) to
+// a single namespace. Requests for any other namespace are rejected with an error.
+//
+// Namespaces themselves are cluster-scoped and are always cached in full.
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithResyncPeriod sets how often the informers replay their cached objects.
+// A zero period, the default, disables periodic resyncs.
+func WithResyncPeriod(period time.Duration) Option {
+	return func(c *config) {
+		c.resyncPeriod = period
+	}
+}
+
+// WithLogger logs every read served from the cache to logger, at debug level when it
+// succeeds and at warn level when it fails, like podapi.WithLogger logs requests. The
+// records carry cached=true. Watches and the requests they send are logged through the
+// live resource APIs. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithRateLimiter sends every request of the Cache through limiter, so that it counts
+// against a limit shared with the other APIs using it: the listings and watches of the
+// informers, including the re-lists they fall back to, and the watches delegated to the
+// live resource APIs. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(c *config) {
+		c.limiter = limiter
+	}
+}
+
+// Cache owns a set of shared informers and exposes lister-backed implementations
+// of the resource interfaces on top of them.
+//
+// The informers are registered when the Cache is created but do not contact the
+// apiserver until Start is called. Reads only see objects once the initial listing
+// has completed, so callers should wait for WaitForCacheSync before serving queries.
+//
+// A Cache is safe for concurrent use. Once stopped it cannot be restarted.
+type Cache struct {
+	factory   informers.SharedInformerFactory
+	namespace string
+
+	pods        *PodAPI
+	services    *ServiceAPI
+	deployments *DeploymentAPI
+	namespaces  *NamespaceAPI
+
+	mu      sync.Mutex
+	stopCh  chan struct{}
+	started bool
+	stopped bool
+}
+
+// NewCache creates a Cache for the provided client and registers the pod, service,
+// deployment and namespace informers.
+//
+// Watch calls are not served from the cache; they are delegated to the live
+// resource APIs built on the same client.
+func NewCache(client kubernetes.Interface, opts ...Option) *Cache {
+	cfg := config{namespace: metav1.NamespaceAll}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		client,
+		cfg.resyncPeriod,
+		informers.WithNamespace(cfg.namespace),
+	)
+
+	c := &Cache{
+		factory:   factory,
+		namespace: cfg.namespace,
+		stopCh:    make(chan struct{}),
+	}
+
+	// The informers list and watch until the Cache is stopped
+	ctx := wait.ContextForChannel(c.stopCh)
+	pods := factory.InformerFor(&corev1.Pod{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		pods := client.CoreV1().Pods(cfg.namespace)
+		return newInformer(ctx, cfg.limiter, &corev1.Pod{}, resync, pods.List, pods.Watch)
+	})
+	services := factory.InformerFor(&corev1.Service{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		services := client.CoreV1().Services(cfg.namespace)
+		return newInformer(ctx, cfg.limiter, &corev1.Service{}, resync, services.List, services.Watch)
+	})
+	deployments := factory.InformerFor(&appsv1.Deployment{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		deployments := client.AppsV1().Deployments(cfg.namespace)
+		return newInformer(ctx, cfg.limiter, &appsv1.Deployment{}, resync, deployments.List, deployments.Watch)
+	})
+	namespaces := factory.InformerFor(&corev1.Namespace{}, func(client kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+		namespaces := client.CoreV1().Namespaces()
+		return newInformer(ctx, cfg.limiter, &corev1.Namespace{}, resync, namespaces.List, namespaces.Watch)
+	})
+
+	c.pods = &PodAPI{
+		cache:  c,
+		lister: corelisters.NewPodLister(pods.GetIndexer()),
+		live:   podapi.NewPodAPI(client, podapi.WithLogger(cfg.logger), podapi.WithRateLimiter(cfg.limiter)),
+		logger: cfg.logger,
+	}
+	c.services = &ServiceAPI{
+		cache:  c,
+		lister: corelisters.NewServiceLister(services.GetIndexer()),
+		live:   serviceapi.NewServiceAPI(client, serviceapi.WithLogger(cfg.logger), serviceapi.WithRateLimiter(cfg.limiter)),
+		logger: cfg.logger,
+	}
+	c.deployments = &DeploymentAPI{
+		cache:  c,
+		lister: appslisters.NewDeploymentLister(deployments.GetIndexer()),
+		live:   deploymentapi.NewDeploymentAPI(client, deploymentapi.WithLogger(cfg.logger), deploymentapi.WithRateLimiter(cfg.limiter)),
+		logger: cfg.logger,
+	}
+	c.namespaces = &NamespaceAPI{
+		lister: corelisters.NewNamespaceLister(namespaces.GetIndexer()),
+		live:   namespaceapi.NewNamespaceAPI(client, namespaceapi.WithLogger(cfg.logger), namespaceapi.WithRateLimiter(cfg.limiter)),
+		logger: cfg.logger,
+	}
+
+	return c
+}
+
+// Start launches the registered informers in the background.
+//
+// Calling Start more than once has no additional effect, and calling it after Stop
+// is a no-op.
+func (c *Cache) Start() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopped {
+		return
+	}
+	c.factory.Start(c.stopCh)
+	c.started = true
+}
+
+// WaitForCacheSync blocks until every informer has completed its initial listing.
+//
+// Returns an error if the Cache has not been started, or if ctx is done or the
+// Cache is stopped before all informers have synced.
+func (c *Cache) WaitForCacheSync(ctx context.Context) error {
+	c.mu.Lock()
+	started := c.started
+	c.mu.Unlock()
+	if !started {
+		return errors.New("failed to sync informer caches: cache has not been started")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for informerType, synced := range c.factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync %v informer cache", informerType)
+		}
+	}
+
+	return nil
+}
+
+// Stop shuts the informers down and waits for their goroutines to exit.
+//
+// Calling Stop more than once has no additional effect.
+func (c *Cache) Stop() {
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return
+	}
+	c.stopped = true
+	close(c.stopCh)
+	c.mu.Unlock()
+
+	c.factory.Shutdown()
+}
+
+// PodAPI returns the cache-backed api.PodAPI implementation.
+func (c *Cache) PodAPI() *PodAPI {
+	return c.pods
+}
+
+// ServiceAPI returns the cache-backed api.ServiceAPI implementation.
+func (c *Cache) ServiceAPI() *ServiceAPI {
+	return c.services
+}
+
+// DeploymentAPI returns the cache-backed api.DeploymentAPI implementation.
+func (c *Cache) DeploymentAPI() *DeploymentAPI {
+	return c.deployments
+}
+
+// NamespaceAPI returns the cache-backed api.NamespaceAPI implementation.
+func (c *Cache) NamespaceAPI() *NamespaceAPI {
+	return c.namespaces
+}
+
+// newInformer returns an informer of objects like obj, listing and watching them with
+// list and open, each request admitted by limiter. The requests are bound to ctx.
+func newInformer[L runtime.Object](ctx context.Context, limiter *api.RateLimiter, obj runtime.Object, resync time.Duration,
+	list func(context.Context, metav1.ListOptions) (L, error), open func(context.Context, metav1.ListOptions) (watch.Interface, error),
+) cache.SharedIndexInformer {
+	watchLimited := throttle.Watch(limiter, open)
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return throttle.Do(ctx, limiter, func() (runtime.Object, error) {
+				return list(ctx, opts)
+			})
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return watchLimited(ctx, opts)
+		},
+	}
+	return cache.NewSharedIndexInformer(lw, obj, resync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// checkScope returns an error if namespace lies outside the namespace the Cache
+// was restricted to with WithNamespace.
+func (c *Cache) checkScope(namespace string) error {
+	if c.namespace != metav1.NamespaceAll && namespace != c.namespace {
+		return fmt.Errorf("namespace %q is outside the cache scope %q", namespace, c.namespace)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cacheapi
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api"
+)
+
+// startedCache returns a synced Cache over a fake clientset seeded with objects.
+// The Cache is stopped when the test finishes.
+func startedCache(t *testing.T, objects []runtime.Object, opts ...Option) *Cache {
+	t.Helper()
+
+	cache := NewCache(fake.NewClientset(objects...), opts...)
+	cache.Start()
+	t.Cleanup(cache.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, cache.WaitForCacheSync(ctx))
+
+	return cache
+}
+
+func TestNewCache(t *testing.T) {
+	cache := NewCache(fake.NewClientset(), WithNamespace("team-a"), WithResyncPeriod(time.Minute))
+
+	assert.NotNil(t, cache)
+	assert.Equal(t, "team-a", cache.namespace)
+	assert.Implements(t, (*api.PodAPI)(nil), cache.PodAPI())
+	assert.Implements(t, (*api.ServiceAPI)(nil), cache.ServiceAPI())
+	assert.Implements(t, (*api.DeploymentAPI)(nil), cache.DeploymentAPI())
+	assert.Implements(t, (*api.NamespaceAPI)(nil), cache.NamespaceAPI())
+}
+
+func TestCache_Lifecycle(t *testing.T) {
+	t.Run("WaitForCacheSync before Start", func(t *testing.T) {
+		cache := NewCache(fake.NewClientset())
+
+		err := cache.WaitForCacheSync(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cache has not been started")
+	})
+
+	t.Run("Start, sync and stop", func(t *testing.T) {
+		cache := NewCache(fake.NewClientset(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		}))
+
+		cache.Start()
+		cache.Start()
+		require.NoError(t, cache.WaitForCacheSync(context.Background()))
+
+		ns, err := cache.NamespaceAPI().GetNamespaceByName(context.Background(), "default")
+		require.NoError(t, err)
+		assert.Equal(t, "default", ns.Name)
+
+		cache.Stop()
+		cache.Stop()
+		cache.Start()
+	})
+}
+
+func TestCache_CheckScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		namespace string
+		wantErr   bool
+	}{
+		{
+			name:      "Unscoped cache",
+			namespace: "team-b",
+		},
+		{
+			name:      "Namespace in scope",
+			opts:      []Option{WithNamespace("team-a")},
+			namespace: "team-a",
+		},
+		{
+			name:      "Namespace outside scope",
+			opts:      []Option{WithNamespace("team-a")},
+			namespace: "team-b",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := NewCache(fake.NewClientset(), tt.opts...)
+
+			err := cache.checkScope(tt.namespace)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "outside the cache scope")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCache_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	cache := startedCache(t, []runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	}, WithLogger(logger))
+	ctx := context.Background()
+
+	pods, err := cache.PodAPI().ListPodsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=list resource=pods namespace=default labelSelector="app=web" cached=true`)
+	assert.Contains(t, buf.String(), "items=1")
+
+	_, err = cache.NamespaceAPI().GetNamespaceByName(ctx, "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	assert.Contains(t, buf.String(), `level=WARN msg="kubernetes request failed" verb=get resource=namespaces name=missing cached=true`)
+}
+
+func TestCache_WithRateLimiter(t *testing.T) {
+	limiter := api.NewRateLimiter(api.RateLimit{MaxInFlight: 1})
+	cache := NewCache(fake.NewClientset(), WithRateLimiter(limiter))
+
+	// Hold the only in-flight slot: the informers cannot list until it is freed
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	cache.Start()
+	t.Cleanup(cache.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.Error(t, cache.WaitForCacheSync(ctx))
+
+	release()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, cache.WaitForCacheSync(ctx))
+
+	// Watches delegated to the live APIs go through the limiter as well
+	release, err = limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+	watchCtx, cancelWatch := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelWatch()
+	_, err = cache.PodAPI().WatchPodsByLabel(watchCtx, "default", "app=web")
+	require.Error(t, err)
+}
@@ -0,0 +1,312 @@
+package cacheapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/deployment_api"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+)
+
+// DeploymentAPI serves deployment reads from the shared informer cache of its Cache.
+//
+// Returned objects are deep copies and may be modified freely by the caller.
+type DeploymentAPI struct {
+	cache  *Cache
+	lister appsv1listers.DeploymentLister
+	live   *deploymentapi.DeploymentAPI
+	logger *slog.Logger
+}
+
+// GetDeploymentByName retrieves a specific Deployment by namespace and name from the cache.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - namespace: Namespace of the deployment (must be non-empty and within the cache scope).
+//   - name: Name of the deployment (must be non-empty).
+//
+// Returns the matched *appsv1.Deployment or an error if not found or invalid.
+func (d *DeploymentAPI) GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, name, "name", "invalid deployment name", err)
+	}
+	if err := d.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Deployment", namespace, name, fmt.Sprintf("failed to get deployment %q", name), err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "deployments", Namespace: namespace, Name: name, Cached: true}
+	start := time.Now()
+	deployment, err := d.lister.Deployments(namespace).Get(name)
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, name, fmt.Sprintf("failed to get deployment %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 1)
+
+	return deployment.DeepCopy(), nil
+}
+
+// ListDeploymentsByLabel lists cached deployments by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching deployments or an error.
+func (d *DeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+	if err := d.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Deployment", namespace, "", "failed to list deployments by label", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	deployments, err := d.lister.Deployments(namespace).List(selector)
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(deployments, nil)
+	reqlog.Succeeded(ctx, d.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListDeploymentsByField lists cached deployments by namespace and field selector.
+//
+// The selector is evaluated client-side against the fields the apiserver supports
+// for deployments, metadata.name and metadata.namespace. A selector on any other field
+// fails with an error matching api.ErrValidation, as it does on the apiserver.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching deployments or an error.
+func (d *DeploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := d.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Deployment", namespace, "", "failed to list deployments by field", err)
+	}
+
+	selector, err := parseFields(fieldSelector, deploymentFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	deployments, err := d.lister.Deployments(namespace).List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(deployments, func(deployment *appsv1.Deployment) bool {
+		return selector.Matches(deploymentFields(deployment))
+	})
+	reqlog.Succeeded(ctx, d.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListDeploymentsByLabelAllNamespaces lists cached deployments by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching deployments, or an error if the cache is restricted to a namespace.
+func (d *DeploymentAPI) ListDeploymentsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "labelSelector", "invalid label selector", err)
+	}
+	if err := d.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Deployment", "", "", "failed to list deployments by label in all namespaces", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	deployments, err := d.lister.List(selector)
+	if err != nil {
+		err = api.NewResourceError("Deployment", "", "", "failed to list deployments by label in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(deployments, func(deployment *appsv1.Deployment) bool {
+		return namespaces.Match(deployment.Namespace)
+	})
+	reqlog.Succeeded(ctx, d.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListDeploymentsByFieldAllNamespaces lists cached deployments by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching deployments, or an error if the cache is restricted to a namespace.
+func (d *DeploymentAPI) ListDeploymentsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := d.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Deployment", "", "", "failed to list deployments by field in all namespaces", err)
+	}
+
+	selector, err := parseFields(fieldSelector, deploymentFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	deployments, err := d.lister.List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Deployment", "", "", "failed to list deployments by field in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(deployments, func(deployment *appsv1.Deployment) bool {
+		return namespaces.Match(deployment.Namespace) && selector.Matches(deploymentFields(deployment))
+	})
+	reqlog.Succeeded(ctx, d.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListDeploymentsByQuery lists cached deployments by namespace and query.
+//
+// Both selectors are evaluated client-side, the field selector as in ListDeploymentsByField.
+// The cache holds the latest version of every deployment it has seen, so the resource
+// version of query is only validated. When query.Limit is set, the first deployments in
+// namespace and name order are returned.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.DeploymentAPI.
+//   - namespace: Namespace scope, empty for every namespace (must be within the cache scope).
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching deployments, at most query.Limit when set, or an error.
+func (d *DeploymentAPI) ListDeploymentsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.Deployment, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "query", "invalid list query", err)
+	}
+	if err := d.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Deployment", namespace, "", "failed to list deployments by query", err)
+	}
+
+	labelSelector, fieldSelector, err := parseQuery(query, deploymentFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "query", "invalid list query", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector, Cached: true}
+	start := time.Now()
+	deployments, err := d.lister.Deployments(namespace).List(labelSelector)
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	items := firstN(copyMatching(deployments, func(deployment *appsv1.Deployment) bool {
+		return fieldSelector.Matches(deploymentFields(deployment))
+	}), query.Limit)
+	reqlog.Succeeded(ctx, d.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListDeploymentsByLabelPaged lists cached deployments by namespace and label selector. An
+// empty namespace lists the deployments of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.DeploymentAPI.
+//
+// Returns an iterator over all matching deployments; errors are yielded as the second value.
+func (d *DeploymentAPI) ListDeploymentsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(d.ListDeploymentsByLabelAllNamespaces(ctx, labelSelector, nil))
+	}
+	return values(d.ListDeploymentsByLabel(ctx, namespace, labelSelector))
+}
+
+// ListDeploymentsByFieldPaged lists cached deployments by namespace and field selector. An
+// empty namespace lists the deployments of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.DeploymentAPI.
+//
+// Returns an iterator over all matching deployments; errors are yielded as the second value.
+func (d *DeploymentAPI) ListDeploymentsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(d.ListDeploymentsByFieldAllNamespaces(ctx, fieldSelector, nil))
+	}
+	return values(d.ListDeploymentsByField(ctx, namespace, fieldSelector))
+}
+
+// WatchDeploymentsByLabel watches deployments by namespace and label selector.
+//
+// Watches are served directly by the apiserver; see deploymentapi.DeploymentAPI.WatchDeploymentsByLabel.
+func (d *DeploymentAPI) WatchDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	return d.live.WatchDeploymentsByLabel(ctx, namespace, labelSelector)
+}
+
+// WatchDeploymentsByField watches deployments by namespace and field selector.
+//
+// Watches are served directly by the apiserver; see deploymentapi.DeploymentAPI.WatchDeploymentsByField.
+func (d *DeploymentAPI) WatchDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	return d.live.WatchDeploymentsByField(ctx, namespace, fieldSelector)
+}
@@ -0,0 +1,115 @@
+package cacheapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kaudit/api"
+)
+
+func testDeployments() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "web"},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "worker"},
+			},
+		},
+	}
+}
+
+func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
+	deploymentAPI := startedCache(t, testDeployments()).DeploymentAPI()
+
+	deploy, err := deploymentAPI.GetDeploymentByName(context.Background(), "test-namespace", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", deploy.Name)
+
+	deploy, err = deploymentAPI.GetDeploymentByName(context.Background(), "test-namespace", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get deployment")
+	assert.Nil(t, deploy)
+}
+
+func TestDeploymentAPI_ListDeploymentsByLabel(t *testing.T) {
+	deploymentAPI := startedCache(t, testDeployments()).DeploymentAPI()
+
+	deployments, err := deploymentAPI.ListDeploymentsByLabel(context.Background(), "test-namespace", "app=worker")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "worker", deployments[0].Name)
+
+	_, err = deploymentAPI.ListDeploymentsByLabel(context.Background(), "", "app=worker")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid namespace")
+}
+
+func TestDeploymentAPI_ListDeploymentsByField(t *testing.T) {
+	deploymentAPI := startedCache(t, testDeployments()).DeploymentAPI()
+
+	deployments, err := deploymentAPI.ListDeploymentsByField(context.Background(), "test-namespace", "metadata.name!=web")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "worker", deployments[0].Name)
+}
+
+func TestDeploymentAPI_ListDeploymentsAllNamespaces(t *testing.T) {
+	objects := append(testDeployments(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "kube-system",
+			Labels:    map[string]string{"app": "web"},
+		},
+	})
+	deploymentAPI := startedCache(t, objects).DeploymentAPI()
+	onlySystem, err := api.NewNamespaceFilter([]string{"kube-*"}, nil)
+	require.NoError(t, err)
+
+	deployments, err := deploymentAPI.ListDeploymentsByLabelAllNamespaces(context.Background(), "app=web", onlySystem)
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "kube-system", deployments[0].Namespace)
+
+	deployments, err = deploymentAPI.ListDeploymentsByFieldAllNamespaces(context.Background(), "metadata.name=web", nil)
+	require.NoError(t, err)
+	assert.Len(t, deployments, 2)
+
+	_, err = deploymentAPI.ListDeploymentsByLabelAllNamespaces(context.Background(), "", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid label selector")
+}
+
+func TestDeploymentAPI_ListDeploymentsByQuery(t *testing.T) {
+	deploymentAPI := startedCache(t, testDeployments()).DeploymentAPI()
+
+	deployments, err := deploymentAPI.ListDeploymentsByQuery(context.Background(), "test-namespace", api.ListQuery{
+		LabelSelector: "app",
+		FieldSelector: "metadata.name=worker",
+	})
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "worker", deployments[0].Name)
+
+	deployments, err = deploymentAPI.ListDeploymentsByQuery(context.Background(), "test-namespace", api.ListQuery{})
+	require.NoError(t, err)
+	assert.Len(t, deployments, 2)
+
+	deployments, err = deploymentAPI.ListDeploymentsByQuery(context.Background(), "", api.ListQuery{LabelSelector: "app=web"})
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "web", deployments[0].Name)
+}
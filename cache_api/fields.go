@@ -0,0 +1,167 @@
+package cacheapi
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/pager"
+)
+
+// objectMetaFields returns the metadata fields every object can be selected by.
+func objectMetaFields(meta metav1.Object) fields.Set {
+	return fields.Set{
+		"metadata.name":      meta.GetName(),
+		"metadata.namespace": meta.GetNamespace(),
+	}
+}
+
+// Supported fields of each kind, the ones its field set has. A field selector on any
+// other field is rejected, as it is by the apiserver.
+var (
+	podFieldNames        = podFields(&corev1.Pod{})
+	serviceFieldNames    = serviceFields(&corev1.Service{})
+	deploymentFieldNames = deploymentFields(&appsv1.Deployment{})
+	namespaceFieldNames  = namespaceFields(&corev1.Namespace{})
+)
+
+// podFields returns the field set a pod is matched against, mirroring the pod
+// fields supported by apiserver field selectors. status.podIPs holds every IP of the
+// pod separated by commas, as in the downward API.
+func podFields(pod *corev1.Pod) fields.Set {
+	set := objectMetaFields(pod)
+	set["spec.nodeName"] = pod.Spec.NodeName
+	set["spec.restartPolicy"] = string(pod.Spec.RestartPolicy)
+	set["spec.schedulerName"] = pod.Spec.SchedulerName
+	set["spec.serviceAccountName"] = pod.Spec.ServiceAccountName
+	set["spec.hostNetwork"] = strconv.FormatBool(pod.Spec.HostNetwork)
+	set["status.phase"] = string(pod.Status.Phase)
+	set["status.podIP"] = pod.Status.PodIP
+	set["status.podIPs"] = podIPs(pod)
+	set["status.hostIP"] = pod.Status.HostIP
+	set["status.nominatedNodeName"] = pod.Status.NominatedNodeName
+	return set
+}
+
+// podIPs returns the IPs of pod separated by commas.
+func podIPs(pod *corev1.Pod) string {
+	ips := make([]string, 0, len(pod.Status.PodIPs))
+	for _, ip := range pod.Status.PodIPs {
+		ips = append(ips, ip.IP)
+	}
+	return strings.Join(ips, ",")
+}
+
+// serviceFields returns the field set a service is matched against.
+func serviceFields(svc *corev1.Service) fields.Set {
+	set := objectMetaFields(svc)
+	set["spec.clusterIP"] = svc.Spec.ClusterIP
+	set["spec.type"] = string(svc.Spec.Type)
+	return set
+}
+
+// deploymentFields returns the field set a deployment is matched against.
+func deploymentFields(deploy *appsv1.Deployment) fields.Set {
+	return objectMetaFields(deploy)
+}
+
+// namespaceFields returns the field set a namespace is matched against.
+func namespaceFields(ns *corev1.Namespace) fields.Set {
+	set := objectMetaFields(ns)
+	set["status.phase"] = string(ns.Status.Phase)
+	return set
+}
+
+// copyMatching returns deep copies of the cached objects accepted by match.
+// A nil match accepts every object.
+//
+// Listers hand out the objects stored in the informer cache, so they are copied
+// before being returned to callers that may modify them.
+func copyMatching[T any, P interface {
+	*T
+	DeepCopy() *T
+}](objs []P, match func(P) bool) []T {
+	items := make([]T, 0, len(objs))
+	for _, obj := range objs {
+		if match == nil || match(obj) {
+			items = append(items, *obj.DeepCopy())
+		}
+	}
+	return items
+}
+
+// parseFields parses fieldSelector and checks that it only selects fields of supported,
+// the field names of the kind it applies to.
+func parseFields(fieldSelector string, supported fields.Set) (fields.Selector, error) {
+	selector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range selector.Requirements() {
+		if !supported.Has(req.Field) {
+			return nil, fmt.Errorf("field label not supported: %s", req.Field)
+		}
+	}
+	return selector, nil
+}
+
+// parseQuery parses the selectors of query, either of which may be empty to select
+// every object. The field selector may only select fields of supported.
+func parseQuery(query api.ListQuery, supported fields.Set) (labels.Selector, fields.Selector, error) {
+	labelSelector, err := labels.Parse(query.LabelSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	fieldSelector, err := parseFields(query.FieldSelector, supported)
+	if err != nil {
+		return nil, nil, err
+	}
+	return labelSelector, fieldSelector, nil
+}
+
+// firstN returns the first n items in namespace and name order, the order the
+// apiserver lists objects in, or every item, in any order, when n is zero or the items
+// are fewer.
+func firstN[T any, P interface {
+	*T
+	GetNamespace() string
+	GetName() string
+}](items []T, n int64) []T {
+	if n == 0 || int64(len(items)) <= n {
+		return items
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		return cmp.Or(
+			strings.Compare(P(&a).GetNamespace(), P(&b).GetNamespace()),
+			strings.Compare(P(&a).GetName(), P(&b).GetName()),
+		)
+	})
+	return items[:n]
+}
+
+// values adapts the result of a cached list call to the iterator returned by the
+// Paged methods.
+func values[T any](items []T, err error) iter.Seq2[T, error] {
+	if err != nil {
+		return pager.Err[T](err)
+	}
+
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
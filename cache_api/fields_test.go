@@ -0,0 +1,70 @@
+package cacheapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+func TestPodFields(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			PodIP:  "10.0.0.1",
+			PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}},
+		},
+	}
+
+	set := podFields(pod)
+	assert.Equal(t, "10.0.0.1", set["status.podIP"])
+	assert.Equal(t, "10.0.0.1,fd00::1", set["status.podIPs"])
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		supported fields.Set
+		errMsg    string
+	}{
+		{
+			name:      "Supported fields",
+			selector:  "spec.nodeName=node-1,status.phase!=Running",
+			supported: podFieldNames,
+		},
+		{
+			name:      "Field of another kind",
+			selector:  "spec.nodeName=node-1",
+			supported: serviceFieldNames,
+			errMsg:    "field label not supported: spec.nodeName",
+		},
+		{
+			name:      "Namespace phase on deployments",
+			selector:  "metadata.name=web,status.phase=Active",
+			supported: deploymentFieldNames,
+			errMsg:    "field label not supported: status.phase",
+		},
+		{
+			name:      "Malformed selector",
+			selector:  "spec.nodeName",
+			supported: podFieldNames,
+			errMsg:    "invalid selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := parseFields(tt.selector, tt.supported)
+
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, selector)
+		})
+	}
+}
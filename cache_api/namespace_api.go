@@ -0,0 +1,210 @@
+package cacheapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/namespace_api"
+)
+
+// NamespaceAPI serves namespace reads from the shared informer cache of its Cache.
+//
+// Namespaces are cluster-scoped, so the namespace restriction of the Cache does not
+// apply. Returned objects are deep copies and may be modified freely by the caller.
+type NamespaceAPI struct {
+	lister corev1listers.NamespaceLister
+	live   *namespaceapi.NamespaceAPI
+	logger *slog.Logger
+}
+
+// GetNamespaceByName retrieves a single Namespace object by its name from the cache.
+//
+// The name parameter is validated to ensure it is not empty.
+//
+//   - ctx: Context passed to the logger; kept for compatibility with api.NamespaceAPI.
+//   - name: The name of the Kubernetes namespace to retrieve.
+//
+// Returns a pointer to a corev1.Namespace object or an error if the namespace
+// is not cached.
+func (n *NamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (*corev1.Namespace, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", name, "name", "failed to validate namespace name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "namespaces", Name: name, Cached: true}
+	start := time.Now()
+	ns, err := n.lister.Get(name)
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", name, fmt.Sprintf("failed to get namespace %q", name), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
+
+	return ns.DeepCopy(), nil
+}
+
+// ListNamespacesByLabel retrieves cached Namespace objects filtered by a label selector.
+//
+//   - ctx: Context passed to the logger; kept for compatibility with api.NamespaceAPI.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of corev1.Namespace objects matching the label selector, or
+// an error if the validation fails.
+func (n *NamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector string) ([]corev1.Namespace, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	list, err := n.lister.List(selector)
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to list namespaces by label %q", labelSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(list, nil)
+	reqlog.Succeeded(ctx, n.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListNamespacesByField retrieves cached Namespace objects filtered by a field selector.
+//
+// The selector is evaluated client-side against metadata.name and status.phase. A
+// selector on any other field fails with an error matching api.ErrValidation, as it
+// does on the apiserver.
+//
+//   - ctx: Context passed to the logger; kept for compatibility with api.NamespaceAPI.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of corev1.Namespace objects matching the field selector, or
+// an error if the validation fails.
+func (n *NamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector string) ([]corev1.Namespace, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	selector, err := parseFields(fieldSelector, namespaceFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	list, err := n.lister.List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to list namespaces by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(list, func(ns *corev1.Namespace) bool {
+		return selector.Matches(namespaceFields(ns))
+	})
+	reqlog.Succeeded(ctx, n.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListNamespacesByQuery retrieves cached Namespace objects filtered by a query.
+//
+// Both selectors are evaluated client-side, the field selector against metadata.name and
+// status.phase. The cache holds the latest version of every namespace it has seen, so
+// the resource version of query is only validated. When query.Limit is set, the first
+// namespaces in name order are returned.
+//
+//   - ctx: Context passed to the logger; kept for compatibility with api.NamespaceAPI.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of corev1.Namespace objects matching the query, at most query.Limit
+// when set, or an error if the validation fails.
+func (n *NamespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.Namespace, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "query", "failed to validate list query", err)
+	}
+
+	labelSelector, fieldSelector, err := parseQuery(query, namespaceFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "query", "failed to validate list query", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector, Cached: true}
+	start := time.Now()
+	list, err := n.lister.List(labelSelector)
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", "failed to list namespaces by query", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	items := firstN(copyMatching(list, func(ns *corev1.Namespace) bool {
+		return fieldSelector.Matches(namespaceFields(ns))
+	}), query.Limit)
+	reqlog.Succeeded(ctx, n.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListNamespacesByLabelPaged retrieves cached Namespace objects filtered by a label selector.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.NamespaceAPI.
+//
+// Returns an iterator over the matching namespaces; errors are yielded as the second value.
+func (n *NamespaceAPI) ListNamespacesByLabelPaged(ctx context.Context, labelSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	err := val.ValidateWithTag(pageSize, "gt=0")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "pageSize", "failed to validate page size", err))
+	}
+
+	return values(n.ListNamespacesByLabel(ctx, labelSelector))
+}
+
+// ListNamespacesByFieldPaged retrieves cached Namespace objects filtered by a field selector.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.NamespaceAPI.
+//
+// Returns an iterator over the matching namespaces; errors are yielded as the second value.
+func (n *NamespaceAPI) ListNamespacesByFieldPaged(ctx context.Context, fieldSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	err := val.ValidateWithTag(pageSize, "gt=0")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "pageSize", "failed to validate page size", err))
+	}
+
+	return values(n.ListNamespacesByField(ctx, fieldSelector))
+}
+
+// WatchNamespacesByLabel watches Namespace objects filtered by a label selector.
+//
+// Watches are served directly by the apiserver; see namespaceapi.NamespaceAPI.WatchNamespacesByLabel.
+func (n *NamespaceAPI) WatchNamespacesByLabel(ctx context.Context, labelSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	return n.live.WatchNamespacesByLabel(ctx, labelSelector)
+}
+
+// WatchNamespacesByField watches Namespace objects filtered by a field selector.
+//
+// Watches are served directly by the apiserver; see namespaceapi.NamespaceAPI.WatchNamespacesByField.
+func (n *NamespaceAPI) WatchNamespacesByField(ctx context.Context, fieldSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	return n.live.WatchNamespacesByField(ctx, fieldSelector)
+}
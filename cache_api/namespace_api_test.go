@@ -0,0 +1,90 @@
+package cacheapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kaudit/api"
+)
+
+func testNamespaces() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "prod",
+				Labels: map[string]string{"environment": "production"},
+			},
+			Status: corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "old",
+				Labels: map[string]string{"environment": "staging"},
+			},
+			Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+		},
+	}
+}
+
+func TestNamespaceAPI_GetNamespaceByName(t *testing.T) {
+	// Namespaces are cached cluster-wide even when the cache is scoped
+	namespaceAPI := startedCache(t, testNamespaces(), WithNamespace("prod")).NamespaceAPI()
+
+	ns, err := namespaceAPI.GetNamespaceByName(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, "old", ns.Name)
+
+	_, err = namespaceAPI.GetNamespaceByName(context.Background(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to validate namespace name")
+}
+
+func TestNamespaceAPI_ListNamespacesByLabel(t *testing.T) {
+	namespaceAPI := startedCache(t, testNamespaces()).NamespaceAPI()
+
+	namespaces, err := namespaceAPI.ListNamespacesByLabel(context.Background(), "environment=production")
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "prod", namespaces[0].Name)
+}
+
+func TestNamespaceAPI_ListNamespacesByField(t *testing.T) {
+	namespaceAPI := startedCache(t, testNamespaces()).NamespaceAPI()
+
+	namespaces, err := namespaceAPI.ListNamespacesByField(context.Background(), "status.phase=Terminating")
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "old", namespaces[0].Name)
+
+	_, err = namespaceAPI.ListNamespacesByField(context.Background(), "invalid@field")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to validate field selector")
+}
+
+func TestNamespaceAPI_ListNamespacesByQuery(t *testing.T) {
+	namespaceAPI := startedCache(t, testNamespaces()).NamespaceAPI()
+
+	namespaces, err := namespaceAPI.ListNamespacesByQuery(context.Background(), api.ListQuery{
+		LabelSelector: "environment",
+		FieldSelector: "status.phase=Active",
+	})
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "prod", namespaces[0].Name)
+
+	// The first namespaces in name order are kept
+	namespaces, err = namespaceAPI.ListNamespacesByQuery(context.Background(), api.ListQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, "old", namespaces[0].Name)
+
+	_, err = namespaceAPI.ListNamespacesByQuery(context.Background(), api.ListQuery{ResourceVersionMatch: "Exact"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to validate list query")
+}
@@ -0,0 +1,310 @@
+package cacheapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/pod_api"
+)
+
+// PodAPI serves pod reads from the shared informer cache of its Cache.
+//
+// Returned objects are deep copies and may be modified freely by the caller.
+type PodAPI struct {
+	cache  *Cache
+	lister corev1listers.PodLister
+	live   *podapi.PodAPI
+	logger *slog.Logger
+}
+
+// GetPodByName retrieves a specific Pod by namespace and name from the cache.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - namespace: Namespace of the pod (must be non-empty and within the cache scope).
+//   - name: Name of the pod (must be non-empty).
+//
+// Returns the matched *corev1.Pod or an error if not found or invalid.
+func (p *PodAPI) GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, name, "name", "invalid pod name", err)
+	}
+	if err := p.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Pod", namespace, name, fmt.Sprintf("failed to get pod %q", name), err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "pods", Namespace: namespace, Name: name, Cached: true}
+	start := time.Now()
+	pod, err := p.lister.Pods(namespace).Get(name)
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, name, fmt.Sprintf("failed to get pod %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, 1)
+
+	return pod.DeepCopy(), nil
+}
+
+// ListPodsByLabel lists cached pods by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching pods or an error.
+func (p *PodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+	if err := p.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Pod", namespace, "", "failed to list pods by label", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	pods, err := p.lister.Pods(namespace).List(selector)
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(pods, nil)
+	reqlog.Succeeded(ctx, p.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListPodsByField lists cached pods by namespace and field selector.
+//
+// The selector is evaluated client-side against the fields the apiserver supports
+// for pods, such as spec.nodeName and status.phase. A selector on any other field fails
+// with an error matching api.ErrValidation, as it does on the apiserver.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching pods or an error.
+func (p *PodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := p.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Pod", namespace, "", "failed to list pods by field", err)
+	}
+
+	selector, err := parseFields(fieldSelector, podFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	pods, err := p.lister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(pods, func(pod *corev1.Pod) bool {
+		return selector.Matches(podFields(pod))
+	})
+	reqlog.Succeeded(ctx, p.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListPodsByLabelAllNamespaces lists cached pods by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching pods, or an error if the cache is restricted to a namespace.
+func (p *PodAPI) ListPodsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "labelSelector", "invalid label selector", err)
+	}
+	if err := p.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Pod", "", "", "failed to list pods by label in all namespaces", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	pods, err := p.lister.List(selector)
+	if err != nil {
+		err = api.NewResourceError("Pod", "", "", "failed to list pods by label in all namespaces", err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(pods, func(pod *corev1.Pod) bool {
+		return namespaces.Match(pod.Namespace)
+	})
+	reqlog.Succeeded(ctx, p.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListPodsByFieldAllNamespaces lists cached pods by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching pods, or an error if the cache is restricted to a namespace.
+func (p *PodAPI) ListPodsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := p.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Pod", "", "", "failed to list pods by field in all namespaces", err)
+	}
+
+	selector, err := parseFields(fieldSelector, podFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	pods, err := p.lister.List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Pod", "", "", "failed to list pods by field in all namespaces", err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(pods, func(pod *corev1.Pod) bool {
+		return namespaces.Match(pod.Namespace) && selector.Matches(podFields(pod))
+	})
+	reqlog.Succeeded(ctx, p.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListPodsByQuery lists cached pods by namespace and query.
+//
+// Both selectors are evaluated client-side, the field selector as in ListPodsByField.
+// The cache holds the latest version of every pod it has seen, so the resource
+// version of query is only validated. When query.Limit is set, the first pods in
+// namespace and name order are returned.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.PodAPI.
+//   - namespace: Namespace scope, empty for every namespace (must be within the cache scope).
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching pods, at most query.Limit when set, or an error.
+func (p *PodAPI) ListPodsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Pod, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "query", "invalid list query", err)
+	}
+	if err := p.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Pod", namespace, "", "failed to list pods by query", err)
+	}
+
+	labelSelector, fieldSelector, err := parseQuery(query, podFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "query", "invalid list query", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector, Cached: true}
+	start := time.Now()
+	pods, err := p.lister.Pods(namespace).List(labelSelector)
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	items := firstN(copyMatching(pods, func(pod *corev1.Pod) bool {
+		return fieldSelector.Matches(podFields(pod))
+	}), query.Limit)
+	reqlog.Succeeded(ctx, p.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListPodsByLabelPaged lists cached pods by namespace and label selector. An
+// empty namespace lists the pods of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.PodAPI.
+//
+// Returns an iterator over all matching pods; errors are yielded as the second value.
+func (p *PodAPI) ListPodsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(p.ListPodsByLabelAllNamespaces(ctx, labelSelector, nil))
+	}
+	return values(p.ListPodsByLabel(ctx, namespace, labelSelector))
+}
+
+// ListPodsByFieldPaged lists cached pods by namespace and field selector. An
+// empty namespace lists the pods of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.PodAPI.
+//
+// Returns an iterator over all matching pods; errors are yielded as the second value.
+func (p *PodAPI) ListPodsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(p.ListPodsByFieldAllNamespaces(ctx, fieldSelector, nil))
+	}
+	return values(p.ListPodsByField(ctx, namespace, fieldSelector))
+}
+
+// WatchPodsByLabel watches pods by namespace and label selector.
+//
+// Watches are served directly by the apiserver; see podapi.PodAPI.WatchPodsByLabel.
+func (p *PodAPI) WatchPodsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	return p.live.WatchPodsByLabel(ctx, namespace, labelSelector)
+}
+
+// WatchPodsByField watches pods by namespace and field selector.
+//
+// Watches are served directly by the apiserver; see podapi.PodAPI.WatchPodsByField.
+func (p *PodAPI) WatchPodsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	return p.live.WatchPodsByField(ctx, namespace, fieldSelector)
+}
@@ -0,0 +1,482 @@
+package cacheapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kaudit/api"
+)
+
+func testPods() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-1",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "test-app"},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-2",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"app": "test-app"},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node-2"},
+			Status: corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod-3",
+				Namespace: "other-namespace",
+				Labels:    map[string]string{"app": "test-app"},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		},
+	}
+}
+
+func TestPodAPI_GetPodByName(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		namespace string
+		podName   string
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "Pod exists",
+			namespace: "test-namespace",
+			podName:   "pod-1",
+		},
+		{
+			name:      "Pod not found",
+			namespace: "test-namespace",
+			podName:   "nonexistent-pod",
+			wantErr:   true,
+			errMsg:    "not found",
+		},
+		{
+			name:      "Namespace outside cache scope",
+			opts:      []Option{WithNamespace("other-namespace")},
+			namespace: "test-namespace",
+			podName:   "pod-1",
+			wantErr:   true,
+			errMsg:    "outside the cache scope",
+		},
+		{
+			name:      "Empty namespace",
+			namespace: "",
+			podName:   "pod-1",
+			wantErr:   true,
+			errMsg:    "invalid namespace",
+		},
+		{
+			name:      "Empty pod name",
+			namespace: "test-namespace",
+			podName:   "",
+			wantErr:   true,
+			errMsg:    "invalid pod name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAPI := startedCache(t, testPods(), tt.opts...).PodAPI()
+
+			pod, err := podAPI.GetPodByName(context.Background(), tt.namespace, tt.podName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, pod)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.podName, pod.Name)
+
+			// The returned pod is a copy of the cached object
+			pod.Labels["app"] = "modified"
+			again, err := podAPI.GetPodByName(context.Background(), tt.namespace, tt.podName)
+			require.NoError(t, err)
+			assert.Equal(t, "test-app", again.Labels["app"])
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByLabel(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		wantCount     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Matching pods",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			wantCount:     2,
+		},
+		{
+			name:          "No matching pods",
+			namespace:     "test-namespace",
+			labelSelector: "app=non-existent",
+			wantCount:     0,
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAPI := startedCache(t, testPods()).PodAPI()
+
+			pods, err := podAPI.ListPodsByLabel(context.Background(), tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, pods)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, pods, tt.wantCount)
+			for _, pod := range pods {
+				assert.Equal(t, tt.namespace, pod.Namespace)
+			}
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByField(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		wantNames     []string
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Select by node name",
+			namespace:     "test-namespace",
+			fieldSelector: "spec.nodeName=node-1",
+			wantNames:     []string{"pod-1"},
+		},
+		{
+			name:          "Select by phase",
+			namespace:     "test-namespace",
+			fieldSelector: "status.phase!=Running",
+			wantNames:     []string{"pod-2"},
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Unsupported field",
+			namespace:     "test-namespace",
+			fieldSelector: "spec.unschedulable=true",
+			wantErr:       true,
+			errMsg:        "field label not supported: spec.unschedulable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAPI := startedCache(t, testPods()).PodAPI()
+
+			pods, err := podAPI.ListPodsByField(context.Background(), tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, api.ErrValidation)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, pods)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestPodAPI_ListPodsAllNamespaces(t *testing.T) {
+	excludeOther, err := api.ExcludeNamespaces("other-*")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		list      func(podAPI *PodAPI) ([]corev1.Pod, error)
+		wantNames []string
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(podAPI *PodAPI) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(context.Background(), "app=test-app", nil)
+			},
+			wantNames: []string{"pod-1", "pod-2", "pod-3"},
+		},
+		{
+			name: "By label excluding namespaces",
+			list: func(podAPI *PodAPI) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(context.Background(), "app=test-app", excludeOther)
+			},
+			wantNames: []string{"pod-1", "pod-2"},
+		},
+		{
+			name: "By field excluding namespaces",
+			list: func(podAPI *PodAPI) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByFieldAllNamespaces(context.Background(), "spec.nodeName=node-1", excludeOther)
+			},
+			wantNames: []string{"pod-1"},
+		},
+		{
+			name: "Cache restricted to a namespace",
+			opts: []Option{WithNamespace("test-namespace")},
+			list: func(podAPI *PodAPI) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(context.Background(), "app=test-app", nil)
+			},
+			wantErr: true,
+			errMsg:  "outside the cache scope",
+		},
+		{
+			name: "Invalid field selector format",
+			list: func(podAPI *PodAPI) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByFieldAllNamespaces(context.Background(), "invalid@field", nil)
+			},
+			wantErr: true,
+			errMsg:  "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAPI := startedCache(t, testPods(), tt.opts...).PodAPI()
+
+			pods, err := tt.list(podAPI)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, pods)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.ElementsMatch(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		namespace string
+		query     api.ListQuery
+		wantNames []string
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "Label and field selectors",
+			namespace: "test-namespace",
+			query:     api.ListQuery{LabelSelector: "app=test-app", FieldSelector: "status.phase=Running"},
+			wantNames: []string{"pod-1"},
+		},
+		{
+			name:      "Empty query",
+			namespace: "test-namespace",
+			query:     api.ListQuery{},
+			wantNames: []string{"pod-1", "pod-2"},
+		},
+		{
+			// The first pods in name order are kept
+			name:      "Limit",
+			namespace: "test-namespace",
+			query:     api.ListQuery{LabelSelector: "app=test-app", Limit: 1},
+			wantNames: []string{"pod-1"},
+		},
+		{
+			name:      "Resource version is ignored",
+			namespace: "test-namespace",
+			query:     api.ListQuery{FieldSelector: "spec.nodeName=node-2", ResourceVersion: "1", ResourceVersionMatch: "Exact"},
+			wantNames: []string{"pod-2"},
+		},
+		{
+			name:      "All namespaces",
+			namespace: "",
+			query:     api.ListQuery{FieldSelector: "spec.nodeName=node-1"},
+			wantNames: []string{"pod-1", "pod-3"},
+		},
+		{
+			name:      "Namespace outside the cache scope",
+			opts:      []Option{WithNamespace("test-namespace")},
+			namespace: "other-namespace",
+			query:     api.ListQuery{LabelSelector: "app=test-app"},
+			wantErr:   true,
+			errMsg:    "outside the cache scope",
+		},
+		{
+			name:      "All namespaces outside the cache scope",
+			opts:      []Option{WithNamespace("test-namespace")},
+			namespace: "",
+			query:     api.ListQuery{},
+			wantErr:   true,
+			errMsg:    "outside the cache scope",
+		},
+		{
+			name:      "Negative limit",
+			namespace: "test-namespace",
+			query:     api.ListQuery{Limit: -1},
+			wantErr:   true,
+			errMsg:    "invalid list query",
+		},
+		{
+			name:      "Unsupported field",
+			namespace: "test-namespace",
+			query:     api.ListQuery{FieldSelector: "spec.unschedulable=true"},
+			wantErr:   true,
+			errMsg:    "field label not supported: spec.unschedulable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podAPI := startedCache(t, testPods(), tt.opts...).PodAPI()
+
+			pods, err := podAPI.ListPodsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, pods)
+				return
+			}
+			require.NoError(t, err)
+			var names []string
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.ElementsMatch(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByLabelPaged(t *testing.T) {
+	podAPI := startedCache(t, testPods()).PodAPI()
+
+	t.Run("Yields cached pods", func(t *testing.T) {
+		var count int
+		for _, err := range podAPI.ListPodsByLabelPaged(context.Background(), "test-namespace", "app=test-app", 1) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("All namespaces", func(t *testing.T) {
+		var namespaces []string
+		for pod, err := range podAPI.ListPodsByLabelPaged(context.Background(), metav1.NamespaceAll, "app=test-app", 1) {
+			require.NoError(t, err)
+			namespaces = append(namespaces, pod.Namespace)
+		}
+		assert.ElementsMatch(t, []string{"test-namespace", "test-namespace", "other-namespace"}, namespaces)
+	})
+
+	t.Run("Invalid page size", func(t *testing.T) {
+		for _, err := range podAPI.ListPodsByLabelPaged(context.Background(), "test-namespace", "app=test-app", 0) {
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid page size")
+		}
+	})
+}
+
+func TestPodAPI_ListPodsByFieldPaged(t *testing.T) {
+	podAPI := startedCache(t, testPods()).PodAPI()
+
+	t.Run("Yields cached pods", func(t *testing.T) {
+		var count int
+		for _, err := range podAPI.ListPodsByFieldPaged(context.Background(), "test-namespace", "spec.nodeName=node-2", 10) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Invalid field selector", func(t *testing.T) {
+		for _, err := range podAPI.ListPodsByFieldPaged(context.Background(), "test-namespace", "invalid@field", 10) {
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid field selector")
+		}
+	})
+}
+
+func TestPodAPI_WatchPodsByLabel(t *testing.T) {
+	podAPI := startedCache(t, testPods()).PodAPI()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := podAPI.WatchPodsByLabel(ctx, "test-namespace", "app=test-app")
+	require.NoError(t, err)
+	assert.NotNil(t, events)
+
+	_, err = podAPI.WatchPodsByField(ctx, "", "spec.nodeName=node-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid namespace")
+}
+
+func TestPodAPI_ErrorCategories(t *testing.T) {
+	podAPI := startedCache(t, testPods()).PodAPI()
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := podAPI.GetPodByName(context.Background(), "test-namespace", "nonexistent-pod")
+		require.ErrorIs(t, err, api.ErrNotFound)
+
+		var resourceErr *api.ResourceError
+		require.ErrorAs(t, err, &resourceErr)
+		assert.Equal(t, "Pod", resourceErr.Kind)
+		assert.Equal(t, "test-namespace", resourceErr.Namespace)
+		assert.Equal(t, "nonexistent-pod", resourceErr.Name)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := podAPI.ListPodsByLabel(context.Background(), "test-namespace", "app=test-app,=invalid")
+		require.ErrorIs(t, err, api.ErrValidation)
+
+		var validationErr *api.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "Pod", validationErr.Kind)
+		assert.Equal(t, "labelSelector", validationErr.Field)
+	})
+}
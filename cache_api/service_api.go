@@ -0,0 +1,312 @@
+package cacheapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/service_api"
+)
+
+// ServiceAPI serves service reads from the shared informer cache of its Cache.
+//
+// Returned objects are deep copies and may be modified freely by the caller.
+type ServiceAPI struct {
+	cache  *Cache
+	lister corev1listers.ServiceLister
+	live   *serviceapi.ServiceAPI
+	logger *slog.Logger
+}
+
+// GetServiceByName retrieves a specific Service by namespace and name from the cache.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - namespace: Namespace of the service (must be non-empty and within the cache scope).
+//   - name: Name of the service (must be non-empty).
+//
+// Returns the matched *corev1.Service or an error if not found or invalid.
+func (s *ServiceAPI) GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, name, "name", "invalid service name", err)
+	}
+	if err := s.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Service", namespace, name, fmt.Sprintf("failed to get service %q", name), err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "services", Namespace: namespace, Name: name, Cached: true}
+	start := time.Now()
+	service, err := s.lister.Services(namespace).Get(name)
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, name, fmt.Sprintf("failed to get service %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+
+	return service.DeepCopy(), nil
+}
+
+// ListServicesByLabel lists cached services by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching services or an error.
+func (s *ServiceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+	if err := s.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Service", namespace, "", "failed to list services by label", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	services, err := s.lister.Services(namespace).List(selector)
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(services, nil)
+	reqlog.Succeeded(ctx, s.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListServicesByField lists cached services by namespace and field selector.
+//
+// The selector is evaluated client-side against the fields the apiserver supports
+// for services, such as spec.type and spec.clusterIP. A selector on any other field
+// fails with an error matching api.ErrValidation, as it does on the apiserver.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - namespace: Namespace scope (must be within the cache scope).
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching services or an error.
+func (s *ServiceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := s.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Service", namespace, "", "failed to list services by field", err)
+	}
+
+	selector, err := parseFields(fieldSelector, serviceFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	services, err := s.lister.Services(namespace).List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(services, func(service *corev1.Service) bool {
+		return selector.Matches(serviceFields(service))
+	})
+	reqlog.Succeeded(ctx, s.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListServicesByLabelAllNamespaces lists cached services by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching services, or an error if the cache is restricted to a namespace.
+func (s *ServiceAPI) ListServicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Service", "", "", "labelSelector", "invalid label selector", err)
+	}
+	if err := s.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Service", "", "", "failed to list services by label in all namespaces", err)
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, api.NewValidationError("Service", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", LabelSelector: labelSelector, Cached: true}
+	start := time.Now()
+	services, err := s.lister.List(selector)
+	if err != nil {
+		err = api.NewResourceError("Service", "", "", "failed to list services by label in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(services, func(service *corev1.Service) bool {
+		return namespaces.Match(service.Namespace)
+	})
+	reqlog.Succeeded(ctx, s.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListServicesByFieldAllNamespaces lists cached services by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching services, or an error if the cache is restricted to a namespace.
+func (s *ServiceAPI) ListServicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Service", "", "", "fieldSelector", "invalid field selector", err)
+	}
+	if err := s.cache.checkScope(metav1.NamespaceAll); err != nil {
+		return nil, api.NewResourceError("Service", "", "", "failed to list services by field in all namespaces", err)
+	}
+
+	selector, err := parseFields(fieldSelector, serviceFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Service", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", FieldSelector: fieldSelector, Cached: true}
+	start := time.Now()
+	services, err := s.lister.List(labels.Everything())
+	if err != nil {
+		err = api.NewResourceError("Service", "", "", "failed to list services by field in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	items := copyMatching(services, func(service *corev1.Service) bool {
+		return namespaces.Match(service.Namespace) && selector.Matches(serviceFields(service))
+	})
+	reqlog.Succeeded(ctx, s.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListServicesByQuery lists cached services by namespace and query.
+//
+// Both selectors are evaluated client-side, the field selector as in ListServicesByField.
+// The cache holds the latest version of every service it has seen, so the resource
+// version of query is only validated. When query.Limit is set, the first services in
+// namespace and name order are returned.
+//
+// Parameters:
+//   - ctx: Context passed to the logger; kept for compatibility with api.ServiceAPI.
+//   - namespace: Namespace scope, empty for every namespace (must be within the cache scope).
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching services, at most query.Limit when set, or an error.
+func (s *ServiceAPI) ListServicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Service, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "query", "invalid list query", err)
+	}
+	if err := s.cache.checkScope(namespace); err != nil {
+		return nil, api.NewResourceError("Service", namespace, "", "failed to list services by query", err)
+	}
+
+	labelSelector, fieldSelector, err := parseQuery(query, serviceFieldNames)
+	if err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "query", "invalid list query", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector, Cached: true}
+	start := time.Now()
+	services, err := s.lister.Services(namespace).List(labelSelector)
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	items := firstN(copyMatching(services, func(service *corev1.Service) bool {
+		return fieldSelector.Matches(serviceFields(service))
+	}), query.Limit)
+	reqlog.Succeeded(ctx, s.logger, req, start, len(items))
+
+	return items, nil
+}
+
+// ListServicesByLabelPaged lists cached services by namespace and label selector. An
+// empty namespace lists the services of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.ServiceAPI.
+//
+// Returns an iterator over all matching services; errors are yielded as the second value.
+func (s *ServiceAPI) ListServicesByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(s.ListServicesByLabelAllNamespaces(ctx, labelSelector, nil))
+	}
+	return values(s.ListServicesByLabel(ctx, namespace, labelSelector))
+}
+
+// ListServicesByFieldPaged lists cached services by namespace and field selector. An
+// empty namespace lists the services of every namespace.
+//
+// The whole result is already held in memory by the cache, so pageSize is only
+// validated for compatibility with api.ServiceAPI.
+//
+// Returns an iterator over all matching services; errors are yielded as the second value.
+func (s *ServiceAPI) ListServicesByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	if namespace == metav1.NamespaceAll {
+		return values(s.ListServicesByFieldAllNamespaces(ctx, fieldSelector, nil))
+	}
+	return values(s.ListServicesByField(ctx, namespace, fieldSelector))
+}
+
+// WatchServicesByLabel watches services by namespace and label selector.
+//
+// Watches are served directly by the apiserver; see serviceapi.ServiceAPI.WatchServicesByLabel.
+func (s *ServiceAPI) WatchServicesByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	return s.live.WatchServicesByLabel(ctx, namespace, labelSelector)
+}
+
+// WatchServicesByField watches services by namespace and field selector.
+//
+// Watches are served directly by the apiserver; see serviceapi.ServiceAPI.WatchServicesByField.
+func (s *ServiceAPI) WatchServicesByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	return s.live.WatchServicesByField(ctx, namespace, fieldSelector)
+}
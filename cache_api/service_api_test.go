@@ -0,0 +1,125 @@
+package cacheapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kaudit/api"
+)
+
+func testServices() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "frontend",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"tier": "web"},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backend",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"tier": "api"},
+			},
+		},
+	}
+}
+
+func TestServiceAPI_GetServiceByName(t *testing.T) {
+	serviceAPI := startedCache(t, testServices()).ServiceAPI()
+
+	svc, err := serviceAPI.GetServiceByName(context.Background(), "test-namespace", "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", svc.Name)
+
+	svc, err = serviceAPI.GetServiceByName(context.Background(), "test-namespace", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get service")
+	assert.Nil(t, svc)
+
+	_, err = serviceAPI.GetServiceByName(context.Background(), "test-namespace", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid service name")
+}
+
+func TestServiceAPI_ListServicesByLabel(t *testing.T) {
+	serviceAPI := startedCache(t, testServices()).ServiceAPI()
+
+	services, err := serviceAPI.ListServicesByLabel(context.Background(), "test-namespace", "tier in (web,api)")
+	require.NoError(t, err)
+	assert.Len(t, services, 2)
+
+	services, err = serviceAPI.ListServicesByLabel(context.Background(), "test-namespace", "tier=web")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "frontend", services[0].Name)
+}
+
+func TestServiceAPI_ListServicesByField(t *testing.T) {
+	serviceAPI := startedCache(t, testServices(), WithNamespace("test-namespace")).ServiceAPI()
+
+	services, err := serviceAPI.ListServicesByField(context.Background(), "test-namespace", "metadata.name=backend")
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "backend", services[0].Name)
+
+	_, err = serviceAPI.ListServicesByField(context.Background(), "other-namespace", "metadata.name=backend")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the cache scope")
+}
+
+func TestServiceAPI_ListServicesAllNamespaces(t *testing.T) {
+	objects := append(testServices(), &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "frontend",
+			Namespace: "kube-system",
+			Labels:    map[string]string{"tier": "web"},
+		},
+	})
+	serviceAPI := startedCache(t, objects).ServiceAPI()
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+
+	services, err := serviceAPI.ListServicesByLabelAllNamespaces(context.Background(), "tier=web", nil)
+	require.NoError(t, err)
+	assert.Len(t, services, 2)
+
+	services, err = serviceAPI.ListServicesByLabelAllNamespaces(context.Background(), "tier=web", excludeSystem)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "test-namespace", services[0].Namespace)
+
+	services, err = serviceAPI.ListServicesByFieldAllNamespaces(context.Background(), "metadata.name=frontend", excludeSystem)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "test-namespace", services[0].Namespace)
+}
+
+func TestServiceAPI_ListServicesByQuery(t *testing.T) {
+	serviceAPI := startedCache(t, testServices()).ServiceAPI()
+
+	services, err := serviceAPI.ListServicesByQuery(context.Background(), "test-namespace", api.ListQuery{
+		LabelSelector: "tier in (web,api)",
+		FieldSelector: "metadata.name!=frontend",
+	})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "backend", services[0].Name)
+
+	// The first services in name order are kept
+	services, err = serviceAPI.ListServicesByQuery(context.Background(), "test-namespace", api.ListQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "backend", services[0].Name)
+
+	_, err = serviceAPI.ListServicesByQuery(context.Background(), "test-namespace", api.ListQuery{LabelSelector: "invalid@label"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid list query")
+}
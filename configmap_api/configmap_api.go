@@ -0,0 +1,255 @@
+package configmapapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// ConfigMapAPI provides high-level methods for retrieving Kubernetes configmaps.
+type ConfigMapAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a ConfigMapAPI.
+type Option func(*ConfigMapAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(c *ConfigMapAPI) {
+		c.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *ConfigMapAPI) {
+		c.logger = logger
+	}
+}
+
+// NewConfigMapAPI creates a new ConfigMapAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewConfigMapAPI(client kubernetes.Interface, opts ...Option) *ConfigMapAPI {
+	c := &ConfigMapAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetConfigMapByName retrieves a specific ConfigMap by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the configmap (must be non-empty).
+//   - name: Name of the configmap (must be non-empty).
+//
+// Returns the matched *corev1.ConfigMap or an error if not found or invalid.
+func (c *ConfigMapAPI) GetConfigMapByName(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, name, "name", "invalid configmap name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "configmaps", Namespace: namespace, Name: name}
+	start := time.Now()
+	cm, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMap, error) {
+		return c.client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", namespace, name, fmt.Sprintf("failed to get configmap %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, 1)
+
+	return cm, nil
+}
+
+// ListConfigMapsByLabel lists configmaps by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching configmaps or an error.
+func (c *ConfigMapAPI) ListConfigMapsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ConfigMap, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "configmaps", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMapList, error) {
+		return c.client.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", namespace, "", fmt.Sprintf("failed to list configmaps by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListConfigMapsByField lists configmaps by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching configmaps or an error.
+func (c *ConfigMapAPI) ListConfigMapsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ConfigMap, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "configmaps", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMapList, error) {
+		return c.client.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", namespace, "", fmt.Sprintf("failed to list configmaps by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListConfigMapsByLabelAllNamespaces lists configmaps by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching configmaps or an error.
+func (c *ConfigMapAPI) ListConfigMapsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.ConfigMap, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "configmaps", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMapList, error) {
+		return c.client.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", "", "", "failed to list configmaps by label in all namespaces", err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListConfigMapsByFieldAllNamespaces lists configmaps by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching configmaps or an error.
+func (c *ConfigMapAPI) ListConfigMapsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.ConfigMap, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ConfigMap", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "configmaps", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMapList, error) {
+		return c.client.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", "", "", "failed to list configmaps by field in all namespaces", err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListConfigMapsByQuery lists configmaps by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching configmaps, at most query.Limit when set, or an error.
+func (c *ConfigMapAPI) ListConfigMapsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.ConfigMap, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("ConfigMap", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "configmaps", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*corev1.ConfigMapList, error) {
+		return c.client.CoreV1().ConfigMaps(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ConfigMap", namespace, "", fmt.Sprintf("failed to list configmaps by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,553 @@
+package configmapapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestNewConfigMapAPI(t *testing.T) {
+	client := fake.NewClientset()
+	api := NewConfigMapAPI(client)
+	assert.NotNil(t, api)
+	assert.Equal(t, client, api.client)
+}
+
+func TestConfigMapAPI_GetConfigMapByName(t *testing.T) {
+	// Setup a configmap in the test namespace
+	testConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-configmap",
+			Namespace: "test-namespace",
+		},
+		Data: map[string]string{
+			"key": "value",
+		},
+	}
+
+	// Create fake clientset with test configmap
+	fakeClient := fake.NewClientset(testConfigMap)
+
+	// Initialize configmap API
+	cmAPI := NewConfigMapAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		cmName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Successfully get configmap",
+			namespace: "test-namespace",
+			cmName:    "test-configmap",
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			cmName:        "test-configmap",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty configmap name",
+			namespace:     "test-namespace",
+			cmName:        "",
+			wantErr:       true,
+			errorContains: "invalid configmap name",
+		},
+		{
+			name:          "ConfigMap not found",
+			namespace:     "test-namespace",
+			cmName:        "nonexistent-configmap",
+			wantErr:       true,
+			errorContains: "failed to get configmap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cm, err := cmAPI.GetConfigMapByName(ctx, tt.namespace, tt.cmName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cm)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, cm)
+				assert.Equal(t, tt.cmName, cm.Name)
+				assert.Equal(t, tt.namespace, cm.Namespace)
+			}
+		})
+	}
+}
+
+func TestConfigMapAPI_ListConfigMapsByLabel(t *testing.T) {
+	// Setup test configmaps
+	testConfigMaps := []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-configmap",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-configmap",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testConfigMaps {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize configmap API
+	cmAPI := NewConfigMapAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List configmaps by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-configmap-1", "test-configmap-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List configmaps with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-configmap-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			configMaps, err := cmAPI.ListConfigMapsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, configMaps)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, configMaps, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(configMaps))
+				for _, item := range configMaps {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestConfigMapAPI_ListConfigMapsByField(t *testing.T) {
+	// Setup test configmaps
+	testConfigMaps := []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-configmap",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-configmap",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testConfigMaps {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize configmap API
+	cmAPI := NewConfigMapAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every configmap
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List configmaps by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-configmap-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-configmap-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			configMaps, err := cmAPI.ListConfigMapsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, configMaps)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, configMaps, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestConfigMapAPI_ListConfigMapsAllNamespaces(t *testing.T) {
+	// Setup configmaps with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	cmAPI := NewConfigMapAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every configmap of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]corev1.ConfigMap, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]corev1.ConfigMap, error) {
+				return cmAPI.ListConfigMapsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]corev1.ConfigMap, error) {
+				return cmAPI.ListConfigMapsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]corev1.ConfigMap, error) {
+				return cmAPI.ListConfigMapsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]corev1.ConfigMap, error) {
+				return cmAPI.ListConfigMapsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]corev1.ConfigMap, error) {
+				return cmAPI.ListConfigMapsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestConfigMapAPI_ListConfigMapsByQuery(t *testing.T) {
+	// Setup configmaps with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	cmAPI := NewConfigMapAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := cmAPI.ListConfigMapsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
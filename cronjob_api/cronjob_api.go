@@ -0,0 +1,330 @@
+package cronjobapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/kaudit/val"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// CronJobAPI provides high-level methods for retrieving Kubernetes cronjobs.
+type CronJobAPI struct {
+	client  kubernetes.Interface
+	jobs    api.JobAPI
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a CronJobAPI.
+type Option func(*CronJobAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(c *CronJobAPI) {
+		c.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *CronJobAPI) {
+		c.logger = logger
+	}
+}
+
+// NewCronJobAPI creates a new CronJobAPI instance using the provided client.
+//
+// The jobs API is used to resolve the pods of each run, see ListRunsForCronJob.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewCronJobAPI(client kubernetes.Interface, jobs api.JobAPI, opts ...Option) *CronJobAPI {
+	c := &CronJobAPI{
+		client: client,
+		jobs:   jobs,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetCronJobByName retrieves a specific CronJob by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the cronjob (must be non-empty).
+//   - name: Name of the cronjob (must be non-empty).
+//
+// Returns the matched *batchv1.CronJob or an error if not found or invalid.
+func (c *CronJobAPI) GetCronJobByName(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, name, "name", "invalid cronjob name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "cronjobs", Namespace: namespace, Name: name}
+	start := time.Now()
+	cj, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJob, error) {
+		return c.client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", namespace, name, fmt.Sprintf("failed to get cronjob %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, 1)
+
+	return cj, nil
+}
+
+// ListCronJobsByLabel lists cronjobs by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching cronjobs or an error.
+func (c *CronJobAPI) ListCronJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.CronJob, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "cronjobs", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJobList, error) {
+		return c.client.BatchV1().CronJobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", namespace, "", fmt.Sprintf("failed to list cronjobs by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListCronJobsByField lists cronjobs by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching cronjobs or an error.
+func (c *CronJobAPI) ListCronJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.CronJob, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "cronjobs", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJobList, error) {
+		return c.client.BatchV1().CronJobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", namespace, "", fmt.Sprintf("failed to list cronjobs by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListCronJobsByLabelAllNamespaces lists cronjobs by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching cronjobs or an error.
+func (c *CronJobAPI) ListCronJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]batchv1.CronJob, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("CronJob", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "cronjobs", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJobList, error) {
+		return c.client.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", "", "", "failed to list cronjobs by label in all namespaces", err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListCronJobsByFieldAllNamespaces lists cronjobs by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching cronjobs or an error.
+func (c *CronJobAPI) ListCronJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]batchv1.CronJob, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("CronJob", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "cronjobs", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJobList, error) {
+		return c.client.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", "", "", "failed to list cronjobs by field in all namespaces", err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListCronJobsByQuery lists cronjobs by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching cronjobs, at most query.Limit when set, or an error.
+func (c *CronJobAPI) ListCronJobsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]batchv1.CronJob, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("CronJob", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "cronjobs", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.CronJobList, error) {
+		return c.client.BatchV1().CronJobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", namespace, "", fmt.Sprintf("failed to list cronjobs by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListJobsForCronJob lists the Jobs owned by a CronJob.
+//
+// Jobs are matched on their controller owner reference rather than on labels, so Jobs
+// left behind by an earlier CronJob of the same name are not included. The result is
+// ordered from the oldest to the most recent run.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the cronjob (must be non-empty).
+//   - name: Name of the cronjob (must be non-empty).
+//
+// Returns the jobs owned by the cronjob or an error.
+func (c *CronJobAPI) ListJobsForCronJob(ctx context.Context, namespace, name string) ([]batchv1.Job, error) {
+	cj, err := c.GetCronJobByName(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", Namespace: namespace}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*batchv1.JobList, error) {
+		return c.client.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("CronJob", namespace, name, fmt.Sprintf("failed to list jobs for cronjob %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	var jobs []batchv1.Job
+	for _, job := range list.Items {
+		owner := metav1.GetControllerOf(&job)
+		if owner != nil && owner.Kind == "CronJob" && owner.UID == cj.UID {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, k int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[k].CreationTimestamp)
+	})
+
+	return jobs, nil
+}
+
+// ListRunsForCronJob returns the run history of a CronJob: every Job it owns together
+// with the pods that Job produced, ordered from the oldest to the most recent run.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the cronjob (must be non-empty).
+//   - name: Name of the cronjob (must be non-empty).
+//
+// Returns one api.JobRun per owned job or an error.
+func (c *CronJobAPI) ListRunsForCronJob(ctx context.Context, namespace, name string) ([]api.JobRun, error) {
+	jobs, err := c.ListJobsForCronJob(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]api.JobRun, 0, len(jobs))
+	for _, job := range jobs {
+		pods, err := c.jobs.ListPodsForJob(ctx, namespace, job.Name)
+		if err != nil {
+			return nil, api.NewResourceError("CronJob", namespace, name, fmt.Sprintf("failed to list runs for cronjob %q in namespace %q", name, namespace), err)
+		}
+		runs = append(runs, api.JobRun{Job: job, Pods: pods})
+	}
+
+	return runs, nil
+}
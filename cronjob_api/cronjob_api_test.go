@@ -0,0 +1,695 @@
+package cronjobapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/job_api"
+	"github.com/kaudit/api/pod_api"
+)
+
+func TestNewCronJobAPI(t *testing.T) {
+	client := fake.NewClientset()
+	api := NewCronJobAPI(client, jobapi.NewJobAPI(client, podapi.NewPodAPI(client)))
+	assert.NotNil(t, api)
+	assert.Equal(t, client, api.client)
+}
+
+func TestCronJobAPI_GetCronJobByName(t *testing.T) {
+	// Setup a cronjob in the test namespace
+	testCronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			Namespace: "test-namespace",
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+		},
+	}
+
+	// Create fake clientset with test cronjob
+	fakeClient := fake.NewClientset(testCronJob)
+
+	// Initialize cronjob API
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		cjName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Successfully get cronjob",
+			namespace: "test-namespace",
+			cjName:    "test-cronjob",
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			cjName:        "test-cronjob",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty cronjob name",
+			namespace:     "test-namespace",
+			cjName:        "",
+			wantErr:       true,
+			errorContains: "invalid cronjob name",
+		},
+		{
+			name:          "CronJob not found",
+			namespace:     "test-namespace",
+			cjName:        "nonexistent-cronjob",
+			wantErr:       true,
+			errorContains: "failed to get cronjob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cj, err := cjAPI.GetCronJobByName(ctx, tt.namespace, tt.cjName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cj)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, cj)
+				assert.Equal(t, tt.cjName, cj.Name)
+				assert.Equal(t, tt.namespace, cj.Namespace)
+			}
+		})
+	}
+}
+
+func TestCronJobAPI_ListCronJobsByLabel(t *testing.T) {
+	// Setup test cronjobs
+	testCronJobs := []*batchv1.CronJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cronjob-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cronjob-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-cronjob",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-cronjob",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testCronJobs {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize cronjob API
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List cronjobs by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-cronjob-1", "test-cronjob-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List cronjobs with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-cronjob-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cronJobs, err := cjAPI.ListCronJobsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cronJobs)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, cronJobs, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(cronJobs))
+				for _, item := range cronJobs {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestCronJobAPI_ListCronJobsByField(t *testing.T) {
+	// Setup test cronjobs
+	testCronJobs := []*batchv1.CronJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cronjob-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cronjob-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-cronjob",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-cronjob",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "*/5 * * * *",
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testCronJobs {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize cronjob API
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	// The fake clientset does not evaluate field selectors, so every cronjob
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List cronjobs by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-cronjob-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-cronjob-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cronJobs, err := cjAPI.ListCronJobsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cronJobs)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, cronJobs, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestCronJobAPI_ListCronJobsAllNamespaces(t *testing.T) {
+	// Setup cronjobs with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every cronjob of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]batchv1.CronJob, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]batchv1.CronJob, error) {
+				return cjAPI.ListCronJobsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]batchv1.CronJob, error) {
+				return cjAPI.ListCronJobsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]batchv1.CronJob, error) {
+				return cjAPI.ListCronJobsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]batchv1.CronJob, error) {
+				return cjAPI.ListCronJobsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]batchv1.CronJob, error) {
+				return cjAPI.ListCronJobsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestCronJobAPI_ListCronJobsByQuery(t *testing.T) {
+	// Setup cronjobs with different labels
+	fakeClient := fake.NewClientset(
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := cjAPI.ListCronJobsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
+
+// cronJobHistory returns a cronjob together with the jobs and pods of its runs, a job
+// owned by a previous cronjob of the same name and an unrelated job.
+func cronJobHistory() []runtime.Object {
+	controller := true
+	owner := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: "batch/v1",
+			Kind:       "CronJob",
+			Name:       "nightly",
+			UID:        uid,
+			Controller: &controller,
+		}}
+	}
+	job := func(name string, created time.Time, owners []metav1.OwnerReference) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "test-namespace",
+				CreationTimestamp: metav1.NewTime(created),
+				OwnerReferences:   owners,
+			},
+		}
+	}
+	pod := func(name, jobName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    map[string]string{"job-name": jobName},
+			},
+		}
+	}
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return []runtime.Object{
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nightly",
+				Namespace: "test-namespace",
+				UID:       "cronjob-uid",
+			},
+			Spec: batchv1.CronJobSpec{
+				Schedule: "0 0 * * *",
+			},
+		},
+		job("nightly-2", start.Add(24*time.Hour), owner("cronjob-uid")),
+		job("nightly-1", start, owner("cronjob-uid")),
+		job("nightly-0", start.Add(-24*time.Hour), owner("previous-uid")),
+		job("manual", start, nil),
+		pod("nightly-1-abcde", "nightly-1"),
+		pod("nightly-1-fghij", "nightly-1"),
+		pod("nightly-2-klmno", "nightly-2"),
+		pod("manual-pqrst", "manual"),
+	}
+}
+
+func TestCronJobAPI_ListJobsForCronJob(t *testing.T) {
+	fakeClient := fake.NewClientset(cronJobHistory()...)
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		cronJobName   string
+		expectedJobs  []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:         "Jobs owned by cronjob, oldest first",
+			namespace:    "test-namespace",
+			cronJobName:  "nightly",
+			expectedJobs: []string{"nightly-1", "nightly-2"},
+			wantErr:      false,
+		},
+		{
+			name:          "CronJob not found",
+			namespace:     "test-namespace",
+			cronJobName:   "nonexistent",
+			wantErr:       true,
+			errorContains: "failed to get cronjob",
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			cronJobName:   "nightly",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			jobs, err := cjAPI.ListJobsForCronJob(ctx, tt.namespace, tt.cronJobName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(jobs))
+			for _, job := range jobs {
+				names = append(names, job.Name)
+			}
+			assert.Equal(t, tt.expectedJobs, names)
+		})
+	}
+}
+
+func TestCronJobAPI_ListRunsForCronJob(t *testing.T) {
+	fakeClient := fake.NewClientset(cronJobHistory()...)
+	cjAPI := NewCronJobAPI(fakeClient, jobapi.NewJobAPI(fakeClient, podapi.NewPodAPI(fakeClient)))
+
+	t.Run("Runs with their pods", func(t *testing.T) {
+		runs, err := cjAPI.ListRunsForCronJob(context.Background(), "test-namespace", "nightly")
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+
+		assert.Equal(t, "nightly-1", runs[0].Job.Name)
+		assert.Len(t, runs[0].Pods, 2)
+		assert.Equal(t, "nightly-2", runs[1].Job.Name)
+		require.Len(t, runs[1].Pods, 1)
+		assert.Equal(t, "nightly-2-klmno", runs[1].Pods[0].Name)
+	})
+
+	t.Run("Empty cronjob name", func(t *testing.T) {
+		_, err := cjAPI.ListRunsForCronJob(context.Background(), "test-namespace", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cronjob name")
+	})
+}
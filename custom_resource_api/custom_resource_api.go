@@ -0,0 +1,308 @@
+package customresourceapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// CustomResourceAPI provides high-level methods for retrieving resources that have no
+// typed client, such as those defined by CustomResourceDefinitions.
+//
+// Resources are addressed by their GroupVersionResource and returned as
+// unstructured.Unstructured objects; Decode and DecodeList convert them into typed
+// structs supplied by the caller.
+type CustomResourceAPI struct {
+	client  dynamic.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a CustomResourceAPI.
+type Option func(*CustomResourceAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(c *CustomResourceAPI) {
+		c.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *CustomResourceAPI) {
+		c.logger = logger
+	}
+}
+
+// NewCustomResourceAPI creates a new CustomResourceAPI instance using the provided dynamic client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewCustomResourceAPI(client dynamic.Interface, opts ...Option) *CustomResourceAPI {
+	c := &CustomResourceAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// GetCustomResourceByName retrieves a specific resource of the given kind by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name, e.g. cert-manager.io/v1 certificates.
+//   - namespace: Namespace of the resource; empty for cluster-scoped resources.
+//   - name: Name of the resource (must be non-empty).
+//
+// Returns the matched *unstructured.Unstructured or an error if not found or invalid.
+func (c *CustomResourceAPI) GetCustomResourceByName(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), namespace, name, "name", fmt.Sprintf("invalid %s name", gvr.Resource), err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: gvr.Resource, Namespace: namespace, Name: name}
+	start := time.Now()
+	obj, err := throttle.Do(ctx, c.limiter, func() (*unstructured.Unstructured, error) {
+		return c.resource(gvr, namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), namespace, name, fmt.Sprintf("failed to get %s %q%s", gvr.GroupResource(), name, in(namespace)), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, 1)
+
+	return obj, nil
+}
+
+// ListCustomResourcesByLabel lists resources of the given kind by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name.
+//   - namespace: Namespace scope; empty for cluster-scoped resources or every namespace.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching resources or an error.
+func (c *CustomResourceAPI) ListCustomResourcesByLabel(ctx context.Context, gvr schema.GroupVersionResource, namespace string, labelSelector string) ([]unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: gvr.Resource, Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*unstructured.UnstructuredList, error) {
+		return c.resource(gvr, namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), namespace, "", fmt.Sprintf("failed to list %s by label%s", gvr.GroupResource(), in(namespace)), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListCustomResourcesByField lists resources of the given kind by namespace and field selector.
+//
+// Custom resources only support the metadata.name and metadata.namespace fields.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name.
+//   - namespace: Namespace scope; empty for cluster-scoped resources or every namespace.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching resources or an error.
+func (c *CustomResourceAPI) ListCustomResourcesByField(ctx context.Context, gvr schema.GroupVersionResource, namespace string, fieldSelector string) ([]unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: gvr.Resource, Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*unstructured.UnstructuredList, error) {
+		return c.resource(gvr, namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), namespace, "", fmt.Sprintf("failed to list %s by field%s", gvr.GroupResource(), in(namespace)), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListCustomResourcesByLabelAllNamespaces lists resources of the given kind by label
+// selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching resources or an error.
+func (c *CustomResourceAPI) ListCustomResourcesByLabelAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, labelSelector string, namespaces *api.NamespaceFilter) ([]unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: gvr.Resource, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*unstructured.UnstructuredList, error) {
+		return c.resource(gvr, metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), "", "", fmt.Sprintf("failed to list %s by label in all namespaces", gvr.GroupResource()), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListCustomResourcesByFieldAllNamespaces lists resources of the given kind by field
+// selector in all namespaces.
+//
+// Custom resources only support the metadata.name and metadata.namespace fields.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching resources or an error.
+func (c *CustomResourceAPI) ListCustomResourcesByFieldAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, fieldSelector string, namespaces *api.NamespaceFilter) ([]unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: gvr.Resource, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*unstructured.UnstructuredList, error) {
+		return c.resource(gvr, metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), "", "", fmt.Sprintf("failed to list %s by field in all namespaces", gvr.GroupResource()), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListCustomResourcesByQuery lists resources of the given kind by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and plural resource name.
+//   - namespace: Namespace scope; empty for cluster-scoped resources or every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching resources, at most query.Limit when set, or an error.
+func (c *CustomResourceAPI) ListCustomResourcesByQuery(ctx context.Context, gvr schema.GroupVersionResource, namespace string, query api.ListQuery) ([]unstructured.Unstructured, error) {
+	if err := validateGVR(gvr); err != nil {
+		return nil, err
+	}
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError(gvr.GroupResource().String(), namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: gvr.Resource, Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, c.limiter, func() (*unstructured.UnstructuredList, error) {
+		return c.resource(gvr, namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError(gvr.GroupResource().String(), namespace, "", fmt.Sprintf("failed to list %s by query%s", gvr.GroupResource(), in(namespace)), err)
+		reqlog.Failed(ctx, c.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, c.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// resource returns the dynamic client for gvr, scoped to namespace unless it is empty.
+func (c *CustomResourceAPI) resource(gvr schema.GroupVersionResource, namespace string) dynamic.ResourceInterface {
+	if namespace == "" {
+		return c.client.Resource(gvr)
+	}
+	return c.client.Resource(gvr).Namespace(namespace)
+}
+
+// validateGVR checks that gvr names a resource and a version. The group is empty for
+// the core API group.
+func validateGVR(gvr schema.GroupVersionResource) error {
+	if err := val.ValidateWithTag(gvr.Version, "required"); err != nil {
+		return api.NewValidationError(gvr.GroupResource().String(), "", "", "version", "invalid resource version", err)
+	}
+	if err := val.ValidateWithTag(gvr.Resource, "required"); err != nil {
+		return api.NewValidationError(gvr.GroupResource().String(), "", "", "resource", "invalid resource", err)
+	}
+	return nil
+}
+
+// in formats the namespace part of error messages.
+func in(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return fmt.Sprintf(" in namespace %q", namespace)
+}
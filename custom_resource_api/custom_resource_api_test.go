@@ -0,0 +1,412 @@
+package customresourceapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+var (
+	certificates   = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	clusterIssuers = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "clusterissuers"}
+)
+
+// newObject returns an unstructured cert-manager object of kind.
+func newObject(kind, namespace, name string, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       kind,
+		"spec":       spec,
+	}}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+// newFakeClient returns a dynamic fake client serving certificates and clusterissuers.
+func newFakeClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		certificates:   "CertificateList",
+		clusterIssuers: "ClusterIssuerList",
+	}, objects...)
+}
+
+func TestNewCustomResourceAPI(t *testing.T) {
+	client := newFakeClient()
+	crAPI := NewCustomResourceAPI(client)
+	assert.NotNil(t, crAPI)
+	assert.Equal(t, client, crAPI.client)
+}
+
+func TestCustomResourceAPI_GetCustomResourceByName(t *testing.T) {
+	// Setup a namespaced certificate and a cluster-scoped issuer
+	fakeClient := newFakeClient(
+		newObject("Certificate", "test-namespace", "web-tls", nil, map[string]interface{}{"secretName": "web-tls"}),
+		newObject("ClusterIssuer", "", "letsencrypt", nil, map[string]interface{}{}),
+	)
+
+	// Initialize custom resource API
+	crAPI := NewCustomResourceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		gvr           schema.GroupVersionResource
+		namespace     string
+		resourceName  string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:         "Namespaced resource",
+			gvr:          certificates,
+			namespace:    "test-namespace",
+			resourceName: "web-tls",
+		},
+		{
+			name:         "Cluster-scoped resource",
+			gvr:          clusterIssuers,
+			namespace:    "",
+			resourceName: "letsencrypt",
+		},
+		{
+			name:          "Not found",
+			gvr:           certificates,
+			namespace:     "test-namespace",
+			resourceName:  "missing",
+			wantErr:       true,
+			errorContains: `failed to get certificates.cert-manager.io "missing" in namespace "test-namespace"`,
+		},
+		{
+			name:          "Empty name",
+			gvr:           certificates,
+			namespace:     "test-namespace",
+			resourceName:  "",
+			wantErr:       true,
+			errorContains: "invalid certificates name",
+		},
+		{
+			name:          "Missing resource",
+			gvr:           schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1"},
+			namespace:     "test-namespace",
+			resourceName:  "web-tls",
+			wantErr:       true,
+			errorContains: "invalid resource",
+		},
+		{
+			name:          "Missing version",
+			gvr:           schema.GroupVersionResource{Group: "cert-manager.io", Resource: "certificates"},
+			namespace:     "test-namespace",
+			resourceName:  "web-tls",
+			wantErr:       true,
+			errorContains: "invalid resource version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj, err := crAPI.GetCustomResourceByName(context.Background(), tt.gvr, tt.namespace, tt.resourceName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.resourceName, obj.GetName())
+			assert.Equal(t, tt.namespace, obj.GetNamespace())
+		})
+	}
+}
+
+func TestCustomResourceAPI_ListCustomResourcesByLabel(t *testing.T) {
+	// Setup certificates in two namespaces
+	fakeClient := newFakeClient(
+		newObject("Certificate", "test-namespace", "web-tls", map[string]string{"app": "web"}, nil),
+		newObject("Certificate", "test-namespace", "api-tls", map[string]string{"app": "api"}, nil),
+		newObject("Certificate", "other-namespace", "web-tls", map[string]string{"app": "web"}, nil),
+	)
+
+	// Initialize custom resource API
+	crAPI := NewCustomResourceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "Single namespace",
+			namespace:     "test-namespace",
+			labelSelector: "app=web",
+			expectedCount: 1,
+		},
+		{
+			name:          "Every namespace",
+			namespace:     "",
+			labelSelector: "app=web",
+			expectedCount: 2,
+		},
+		{
+			name:          "No match",
+			namespace:     "test-namespace",
+			labelSelector: "app=db",
+			expectedCount: 0,
+		},
+		{
+			name:          "Invalid label selector",
+			namespace:     "test-namespace",
+			labelSelector: "app=web,=invalid",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := crAPI.ListCustomResourcesByLabel(context.Background(), certificates, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, list, tt.expectedCount)
+		})
+	}
+}
+
+func TestCustomResourceAPI_ListCustomResourcesByField(t *testing.T) {
+	fakeClient := newFakeClient(
+		newObject("Certificate", "test-namespace", "web-tls", nil, nil),
+		newObject("Certificate", "other-namespace", "web-tls", nil, nil),
+	)
+
+	// Initialize custom resource API
+	crAPI := NewCustomResourceAPI(fakeClient)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			// The fake client does not evaluate field selectors
+			name:          "Valid field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=web-tls",
+			expectedCount: 1,
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Unsupported field",
+			namespace:     "test-namespace",
+			fieldSelector: "spec.secretName=web-tls",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := crAPI.ListCustomResourcesByField(context.Background(), certificates, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, list, tt.expectedCount)
+		})
+	}
+}
+
+func TestCustomResourceAPI_ListCustomResourcesAllNamespaces(t *testing.T) {
+	// Setup certificates in system and team namespaces
+	fakeClient := newFakeClient(
+		newObject("Certificate", "default", "web-tls", map[string]string{"app": "web"}, nil),
+		newObject("Certificate", "kube-system", "web-tls", map[string]string{"app": "web"}, nil),
+		newObject("Certificate", "team-a", "web-tls", map[string]string{"app": "web"}, nil),
+		newObject("Certificate", "team-b", "db-tls", map[string]string{"app": "db"}, nil),
+	)
+
+	// Initialize custom resource API
+	crAPI := NewCustomResourceAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"team-*"}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]unstructured.Unstructured, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]unstructured.Unstructured, error) {
+				return crAPI.ListCustomResourcesByLabelAllNamespaces(ctx, certificates, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]unstructured.Unstructured, error) {
+				return crAPI.ListCustomResourcesByLabelAllNamespaces(ctx, certificates, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			// The fake client does not evaluate field selectors
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]unstructured.Unstructured, error) {
+				return crAPI.ListCustomResourcesByFieldAllNamespaces(ctx, certificates, "metadata.name=web-tls", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Unsupported field",
+			list: func(ctx context.Context) ([]unstructured.Unstructured, error) {
+				return crAPI.ListCustomResourcesByFieldAllNamespaces(ctx, certificates, "spec.secretName=web-tls", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(list))
+			for _, item := range list {
+				namespaces = append(namespaces, item.GetNamespace())
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+		})
+	}
+}
+
+func TestCustomResourceAPI_ListCustomResourcesByQuery(t *testing.T) {
+	// Setup certificates with different labels
+	fakeClient := newFakeClient(
+		newObject("Certificate", "default", "web-tls", map[string]string{"app": "web"}, nil),
+		newObject("Certificate", "default", "api-tls", map[string]string{"app": "web", "tier": "frontend"}, nil),
+		newObject("Certificate", "default", "db-tls", map[string]string{"app": "db"}, nil),
+	)
+
+	// Initialize custom resource API
+	crAPI := NewCustomResourceAPI(fakeClient)
+
+	// The fake client does not evaluate field selectors and only records the selectors
+	// of a request, which are checked to be sent
+	tests := []struct {
+		name          string
+		gvr           schema.GroupVersionResource
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			gvr:           certificates,
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-tls", "api-tls"},
+		},
+		{
+			name: "List by label and field selectors with limit",
+			gvr:  certificates,
+			query: api.ListQuery{
+				LabelSelector: "app=web,tier=frontend",
+				FieldSelector: "metadata.name=api-tls",
+				Limit:         10,
+			},
+			expectedNames: []string{"api-tls"},
+		},
+		{
+			name:          "List with empty query",
+			gvr:           certificates,
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-tls", "api-tls", "db-tls"},
+		},
+		{
+			name:          "Missing resource",
+			gvr:           schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1"},
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			wantErr:       true,
+			errorContains: "invalid resource",
+		},
+		{
+			name:          "Negative limit",
+			gvr:           certificates,
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "invalid list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			list, err := crAPI.ListCustomResourcesByQuery(context.Background(), tt.gvr, "default", tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(list))
+			for _, item := range list {
+				names = append(names, item.GetName())
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.LabelSelector, listAction.GetListOptions().LabelSelector)
+			assert.Equal(t, tt.query.FieldSelector, listAction.GetListOptions().FieldSelector)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package customresourceapi
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kaudit/api"
+)
+
+// Decode converts obj into a new T, typically the Go type published alongside a
+// CustomResourceDefinition, such as cert-manager's Certificate.
+//
+// Fields of obj without a counterpart in T are dropped.
+func Decode[T any](obj *unstructured.Unstructured) (*T, error) {
+	if obj == nil {
+		return nil, api.NewValidationError("", "", "", "obj", "failed to decode", errors.New("object is nil"))
+	}
+
+	out := new(T)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), out); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %q into %T: %w", obj.GetKind(), obj.GetName(), out, err)
+	}
+
+	return out, nil
+}
+
+// DecodeList converts every item of list into a T, see Decode.
+func DecodeList[T any](list []unstructured.Unstructured) ([]T, error) {
+	out := make([]T, 0, len(list))
+	for i := range list {
+		item, err := Decode[T](&list[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *item)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package customresourceapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kaudit/api"
+)
+
+// certificate mirrors the parts of cert-manager's Certificate used in the tests.
+type certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec certificateSpec `json:"spec"`
+}
+
+type certificateSpec struct {
+	SecretName string   `json:"secretName"`
+	DNSNames   []string `json:"dnsNames,omitempty"`
+}
+
+func TestDecode(t *testing.T) {
+	crAPI := NewCustomResourceAPI(newFakeClient(
+		newObject("Certificate", "test-namespace", "web-tls", nil, map[string]interface{}{
+			"secretName": "web-tls",
+			"dnsNames":   []interface{}{"example.com", "www.example.com"},
+			"unknown":    true,
+		}),
+	))
+
+	t.Run("Typed struct", func(t *testing.T) {
+		obj, err := crAPI.GetCustomResourceByName(context.Background(), certificates, "test-namespace", "web-tls")
+		require.NoError(t, err)
+
+		cert, err := Decode[certificate](obj)
+		require.NoError(t, err)
+		assert.Equal(t, "Certificate", cert.Kind)
+		assert.Equal(t, "web-tls", cert.Name)
+		assert.Equal(t, certificateSpec{SecretName: "web-tls", DNSNames: []string{"example.com", "www.example.com"}}, cert.Spec)
+	})
+
+	t.Run("Mismatched type", func(t *testing.T) {
+		obj := newObject("Certificate", "test-namespace", "bad", nil, map[string]interface{}{"secretName": 42})
+
+		_, err := Decode[certificate](obj)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to decode Certificate "bad"`)
+	})
+
+	t.Run("Nil object", func(t *testing.T) {
+		_, err := Decode[certificate](nil)
+		require.ErrorIs(t, err, api.ErrValidation)
+	})
+}
+
+func TestDecodeList(t *testing.T) {
+	list := []unstructured.Unstructured{
+		*newObject("Certificate", "test-namespace", "web-tls", nil, map[string]interface{}{"secretName": "web-tls"}),
+		*newObject("Certificate", "test-namespace", "api-tls", nil, map[string]interface{}{"secretName": "api-tls"}),
+	}
+
+	certs, err := DecodeList[certificate](list)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.Equal(t, "web-tls", certs[0].Spec.SecretName)
+	assert.Equal(t, "api-tls", certs[1].Spec.SecretName)
+
+	list = append(list, *newObject("Certificate", "test-namespace", "bad", nil, map[string]interface{}{"secretName": 42}))
+	_, err = DecodeList[certificate](list)
+	require.Error(t, err)
+}
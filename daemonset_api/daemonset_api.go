@@ -0,0 +1,255 @@
+package daemonsetapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// DaemonSetAPI provides high-level methods for retrieving Kubernetes daemonsets.
+type DaemonSetAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a DaemonSetAPI.
+type Option func(*DaemonSetAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(d *DaemonSetAPI) {
+		d.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *DaemonSetAPI) {
+		d.logger = logger
+	}
+}
+
+// NewDaemonSetAPI creates a new DaemonSetAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewDaemonSetAPI(client kubernetes.Interface, opts ...Option) *DaemonSetAPI {
+	d := &DaemonSetAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// GetDaemonSetByName retrieves a specific DaemonSet by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the daemonset (must be non-empty).
+//   - name: Name of the daemonset (must be non-empty).
+//
+// Returns the matched *appsv1.DaemonSet or an error if not found or invalid.
+func (d *DaemonSetAPI) GetDaemonSetByName(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, name, "name", "invalid daemonset name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "daemonsets", Namespace: namespace, Name: name}
+	start := time.Now()
+	ds, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSet, error) {
+		return d.client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", namespace, name, fmt.Sprintf("failed to get daemonset %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 1)
+
+	return ds, nil
+}
+
+// ListDaemonSetsByLabel lists daemonsets by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching daemonsets or an error.
+func (d *DaemonSetAPI) ListDaemonSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.DaemonSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "daemonsets", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSetList, error) {
+		return d.client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", namespace, "", fmt.Sprintf("failed to list daemonsets by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListDaemonSetsByField lists daemonsets by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching daemonsets or an error.
+func (d *DaemonSetAPI) ListDaemonSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.DaemonSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "daemonsets", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSetList, error) {
+		return d.client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", namespace, "", fmt.Sprintf("failed to list daemonsets by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListDaemonSetsByLabelAllNamespaces lists daemonsets by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching daemonsets or an error.
+func (d *DaemonSetAPI) ListDaemonSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.DaemonSet, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "daemonsets", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSetList, error) {
+		return d.client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", "", "", "failed to list daemonsets by label in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListDaemonSetsByFieldAllNamespaces lists daemonsets by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching daemonsets or an error.
+func (d *DaemonSetAPI) ListDaemonSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.DaemonSet, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("DaemonSet", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "daemonsets", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSetList, error) {
+		return d.client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", "", "", "failed to list daemonsets by field in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListDaemonSetsByQuery lists daemonsets by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching daemonsets, at most query.Limit when set, or an error.
+func (d *DaemonSetAPI) ListDaemonSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.DaemonSet, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("DaemonSet", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "daemonsets", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*appsv1.DaemonSetList, error) {
+		return d.client.AppsV1().DaemonSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("DaemonSet", namespace, "", fmt.Sprintf("failed to list daemonsets by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
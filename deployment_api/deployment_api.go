@@ -3,23 +3,71 @@ package deploymentapi
 import (
 	"context"
 	"fmt"
+	"iter"
+	"log/slog"
+	"time"
 
 	"github.com/kaudit/val"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/retry"
+	"github.com/kaudit/api/internal/throttle"
+	"github.com/kaudit/api/internal/watcher"
 )
 
 // DeploymentAPI provides high-level methods for retrieving Kubernetes deployments.
 type DeploymentAPI struct {
-	client kubernetes.Interface
+	client  kubernetes.Interface
+	retry   api.RetryPolicy
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a DeploymentAPI.
+type Option func(*DeploymentAPI)
+
+// WithRetryPolicy retries Get and List requests failing with a transient apiserver
+// error according to policy. By default requests are not retried.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(d *DeploymentAPI) {
+		d.retry = policy
+	}
+}
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(d *DeploymentAPI) {
+		d.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(d *DeploymentAPI) {
+		d.logger = logger
+	}
 }
 
 // NewDeploymentAPI creates a new DeploymentAPI instance using the provided client.
-func NewDeploymentAPI(client kubernetes.Interface) *DeploymentAPI {
-	return &DeploymentAPI{
+//
+// Options such as WithRetryPolicy, WithRateLimiter and WithLogger customize the instance.
+func NewDeploymentAPI(client kubernetes.Interface, opts ...Option) *DeploymentAPI {
+	d := &DeploymentAPI{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // GetDeploymentByName retrieves a specific Deployment by namespace and name.
@@ -32,16 +80,25 @@ func NewDeploymentAPI(client kubernetes.Interface) *DeploymentAPI {
 // Returns the matched *appsv1.Deployment or an error if not found or invalid.
 func (d *DeploymentAPI) GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, name, "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(name, "required"); err != nil {
-		return nil, fmt.Errorf("invalid deployment name: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, name, "name", "invalid deployment name", err)
 	}
 
-	deploy, err := d.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	req := reqlog.Request{Verb: "get", Resource: "deployments", Namespace: namespace, Name: name}
+	start := time.Now()
+	deploy, err := retry.Do(ctx, d.retry, func() (*appsv1.Deployment, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.Deployment, error) {
+			return d.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deployment %q in namespace %q: %w", name, namespace, err)
+		err = api.NewResourceError("Deployment", namespace, name, fmt.Sprintf("failed to get deployment %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 1)
 
 	return deploy, nil
 }
@@ -56,20 +113,29 @@ func (d *DeploymentAPI) GetDeploymentByName(ctx context.Context, namespace, name
 // Returns all matching deployments or an error.
 func (d *DeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.Deployment, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, "", "labelSelector", "invalid label selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
 
-	list, err := d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+			return d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list deployments by label in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
@@ -84,20 +150,278 @@ func (d *DeploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace st
 // Returns all matching deployments or an error.
 func (d *DeploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.Deployment, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
-		return nil, fmt.Errorf("invalid field selector: %w", err)
+		return nil, api.NewValidationError("Deployment", namespace, "", "fieldSelector", "invalid field selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	}
 
-	list, err := d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+			return d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list deployments by field in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
+
+// ListDeploymentsByLabelAllNamespaces lists deployments by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching deployments or an error.
+func (d *DeploymentAPI) ListDeploymentsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+			return d.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Deployment", "", "", "failed to list deployments by label in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListDeploymentsByFieldAllNamespaces lists deployments by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching deployments or an error.
+func (d *DeploymentAPI) ListDeploymentsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+			return d.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Deployment", "", "", "failed to list deployments by field in all namespaces", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListDeploymentsByQuery lists deployments by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching deployments, at most query.Limit when set, or an error.
+func (d *DeploymentAPI) ListDeploymentsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.Deployment, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+		return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+			return d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListDeploymentsByLabelPaged lists deployments by namespace and label selector, fetching them in pages.
+// An empty namespace lists the deployments of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - pageSize: Maximum number of deployments requested per page (must be positive).
+//
+// Returns an iterator over all matching deployments. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (d *DeploymentAPI) ListDeploymentsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "labelSelector", "invalid label selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, d.listPage(namespace, "label"))
+}
+
+// ListDeploymentsByFieldPaged lists deployments by namespace and field selector, fetching them in pages.
+// An empty namespace lists the deployments of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - pageSize: Maximum number of deployments requested per page (must be positive).
+//
+// Returns an iterator over all matching deployments. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (d *DeploymentAPI) ListDeploymentsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "fieldSelector", "invalid field selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[appsv1.Deployment](api.NewValidationError("Deployment", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, d.listPage(namespace, "field"))
+}
+
+// WatchDeploymentsByLabel watches deployments by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns a channel of typed deployment events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (d *DeploymentAPI) WatchDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "deployments", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*appsv1.Deployment](ctx, opts, throttle.Watch(d.limiter, d.client.AppsV1().Deployments(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to watch deployments by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 0)
+
+	return events, nil
+}
+
+// WatchDeploymentsByField watches deployments by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns a channel of typed deployment events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (d *DeploymentAPI) WatchDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Deployment", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "deployments", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*appsv1.Deployment](ctx, opts, throttle.Watch(d.limiter, d.client.AppsV1().Deployments(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to watch deployments by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 0)
+
+	return events, nil
+}
+
+// listPage returns a pager.PageFunc listing deployments in the given namespace.
+func (d *DeploymentAPI) listPage(namespace, selectorKind string) pager.PageFunc[appsv1.Deployment] {
+	return func(ctx context.Context, opts metav1.ListOptions) ([]appsv1.Deployment, string, error) {
+		req := reqlog.Request{Verb: "list", Resource: "deployments", Namespace: namespace, LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+		start := time.Now()
+		list, err := retry.Do(ctx, d.retry, func() (*appsv1.DeploymentList, error) {
+			return throttle.Do(ctx, d.limiter, func() (*appsv1.DeploymentList, error) {
+				return d.client.AppsV1().Deployments(namespace).List(ctx, opts)
+			})
+		})
+		if err != nil {
+			err = api.NewResourceError("Deployment", namespace, "", fmt.Sprintf("failed to list deployments by %s in namespace %q", selectorKind, namespace), err)
+			reqlog.Failed(ctx, d.logger, req, start, err)
+			return nil, "", err
+		}
+		reqlog.Succeeded(ctx, d.logger, req, start, len(list.Items))
+
+		return list.Items, list.Continue, nil
+	}
+}
@@ -1,15 +1,30 @@
 package deploymentapi
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
 )
 
 func TestDeploymentAPI_GetDeploymentByName(t *testing.T) {
@@ -318,3 +333,795 @@ func TestDeploymentAPI_ListDeploymentsByField(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentAPI_ListDeploymentsAllNamespaces(t *testing.T) {
+	// Setup deployments with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	deploymentAPI := NewDeploymentAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every deployment of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]appsv1.Deployment, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]appsv1.Deployment, error) {
+				return deploymentAPI.ListDeploymentsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]appsv1.Deployment, error) {
+				return deploymentAPI.ListDeploymentsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]appsv1.Deployment, error) {
+				return deploymentAPI.ListDeploymentsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]appsv1.Deployment, error) {
+				return deploymentAPI.ListDeploymentsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]appsv1.Deployment, error) {
+				return deploymentAPI.ListDeploymentsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestDeploymentAPI_ListDeploymentsByQuery(t *testing.T) {
+	// Setup deployments with different labels
+	fakeClient := fake.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	deploymentAPI := NewDeploymentAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := deploymentAPI.ListDeploymentsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
+
+// pagingReactor serves deployments listings from the fake object tracker in pages of
+// opts.Limit items, encoding the offset of the next page in the continue token.
+// Every served request is recorded in calls.
+func pagingReactor(client *fake.Clientset, calls *[]metav1.ListOptions) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listAction := action.(k8stesting.ListActionImpl)
+		opts := listAction.ListOptions
+		*calls = append(*calls, opts)
+
+		obj, err := client.Tracker().List(
+			appsv1.SchemeGroupVersion.WithResource("deployments"),
+			appsv1.SchemeGroupVersion.WithKind("Deployment"),
+			listAction.GetNamespace(),
+		)
+		if err != nil {
+			return true, nil, err
+		}
+
+		var items []appsv1.Deployment
+		for _, item := range obj.(*appsv1.DeploymentList).Items {
+			if listAction.GetListRestrictions().Labels.Matches(labels.Set(item.Labels)) {
+				items = append(items, item)
+			}
+		}
+
+		offset, _ := strconv.Atoi(opts.Continue)
+		end := min(offset+int(opts.Limit), len(items))
+		page := &appsv1.DeploymentList{Items: items[offset:end]}
+		if end < len(items) {
+			page.Continue = strconv.Itoa(end)
+		}
+		return true, page, nil
+	}
+}
+
+func TestDeploymentAPI_ListDeploymentsByLabelPaged(t *testing.T) {
+	// Create test deployments
+	var objects []runtime.Object
+	for i := range 5 {
+		objects = append(objects, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("deployment-%d", i),
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		})
+	}
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		pageSize      int64
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Single page",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      10,
+			wantCount:     5,
+			wantPages:     1,
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Zero page size",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      0,
+			wantErr:       true,
+			errMsg:        "invalid page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "deployments", pagingReactor(client, &calls))
+			deploymentAPI := NewDeploymentAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var got []appsv1.Deployment
+			var gotErr error
+			for item, err := range deploymentAPI.ListDeploymentsByLabelPaged(ctx, tt.namespace, tt.labelSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, item)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+				assert.Empty(t, got)
+				assert.Empty(t, calls)
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, got, tt.wantCount)
+				assert.Len(t, calls, tt.wantPages)
+				for _, call := range calls {
+					assert.Equal(t, tt.pageSize, call.Limit)
+					assert.Equal(t, tt.labelSelector, call.LabelSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentAPI_ListDeploymentsByFieldPaged(t *testing.T) {
+	// Create test deployments
+	var objects []runtime.Object
+	for i := range 5 {
+		objects = append(objects, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("deployment-%d", i),
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		})
+	}
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		pageSize      int64
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Single page",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      10,
+			wantCount:     5,
+			wantPages:     1,
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Zero page size",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      0,
+			wantErr:       true,
+			errMsg:        "invalid page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "deployments", pagingReactor(client, &calls))
+			deploymentAPI := NewDeploymentAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var got []appsv1.Deployment
+			var gotErr error
+			for item, err := range deploymentAPI.ListDeploymentsByFieldPaged(ctx, tt.namespace, tt.fieldSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, item)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+				assert.Empty(t, got)
+				assert.Empty(t, calls)
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, got, tt.wantCount)
+				assert.Len(t, calls, tt.wantPages)
+				for _, call := range calls {
+					assert.Equal(t, tt.pageSize, call.Limit)
+					assert.Equal(t, tt.fieldSelector, call.FieldSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentAPI_WatchDeploymentsByLabel(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errMsg:        "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Watch refused",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			watchErr:      apierrors.NewForbidden(appsv1.Resource("deployments"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch deployments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("deployments", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			deploymentAPI := NewDeploymentAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := deploymentAPI.WatchDeploymentsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Namespace:       "test-namespace",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestDeploymentAPI_WatchDeploymentsByField(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test",
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test",
+			wantErr:       true,
+			errMsg:        "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Watch refused",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test",
+			watchErr:      apierrors.NewForbidden(appsv1.Resource("deployments"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch deployments",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("deployments", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			deploymentAPI := NewDeploymentAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := deploymentAPI.WatchDeploymentsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Namespace:       "test-namespace",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestDeploymentAPI_ErrorCategories(t *testing.T) {
+	resource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	tests := []struct {
+		name     string
+		reactor  k8stesting.ReactionFunc
+		objName  string
+		category error
+		field    string
+	}{
+		{
+			name:     "Not found",
+			objName:  "missing",
+			category: api.ErrNotFound,
+		},
+		{
+			name: "Forbidden",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(resource, "web", errors.New("rbac denied"))
+			},
+			objName:  "web",
+			category: api.ErrForbidden,
+		},
+		{
+			name: "Timeout",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewTimeoutError("request timed out", 1)
+			},
+			objName:  "web",
+			category: api.ErrTimeout,
+		},
+		{
+			name: "Conflict",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(resource, "web", errors.New("object was modified"))
+			},
+			objName:  "web",
+			category: api.ErrConflict,
+		},
+		{
+			name:     "Validation",
+			objName:  "",
+			category: api.ErrValidation,
+			field:    "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset()
+			if tt.reactor != nil {
+				fakeClient.PrependReactor("get", "deployments", tt.reactor)
+			}
+			deploymentAPI := NewDeploymentAPI(fakeClient)
+
+			_, err := deploymentAPI.GetDeploymentByName(context.Background(), "default", tt.objName)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.category)
+
+			if tt.field != "" {
+				var validationErr *api.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "Deployment", validationErr.Kind)
+				assert.Equal(t, tt.field, validationErr.Field)
+				return
+			}
+
+			var resourceErr *api.ResourceError
+			require.ErrorAs(t, err, &resourceErr)
+			assert.Equal(t, "Deployment", resourceErr.Kind)
+			assert.Equal(t, "default", resourceErr.Namespace)
+			assert.Equal(t, tt.objName, resourceErr.Name)
+		})
+	}
+}
+
+func TestDeploymentAPI_Retry(t *testing.T) {
+	policy := api.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		Multiplier:           2,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+
+	tests := []struct {
+		name          string
+		verb          string
+		failures      int
+		err           error
+		expectedCalls int
+		wantErr       bool
+	}{
+		{
+			name:          "Get succeeds after throttling",
+			verb:          "get",
+			failures:      2,
+			err:           apierrors.NewTooManyRequests("slow down", 0),
+			expectedCalls: 3,
+		},
+		{
+			name:          "List succeeds after unavailability",
+			verb:          "list",
+			failures:      1,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 2,
+		},
+		{
+			name:          "Gives up after max attempts",
+			verb:          "list",
+			failures:      3,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 3,
+			wantErr:       true,
+		},
+		{
+			name:          "Non-retryable error",
+			verb:          "get",
+			failures:      1,
+			err:           apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", errors.New("rbac denied")),
+			expectedCalls: 1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			calls := 0
+			fakeClient.PrependReactor(tt.verb, "deployments", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= tt.failures {
+					return true, nil, tt.err
+				}
+				return false, nil, nil
+			})
+			deploymentAPI := NewDeploymentAPI(fakeClient, WithRetryPolicy(policy))
+
+			var err error
+			if tt.verb == "get" {
+				_, err = deploymentAPI.GetDeploymentByName(context.Background(), "default", "web")
+			} else {
+				_, err = deploymentAPI.ListDeploymentsByLabel(context.Background(), "default", "app=web")
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeploymentAPI_Logger(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	var buf bytes.Buffer
+	deploymentAPI := NewDeploymentAPI(fakeClient, WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := deploymentAPI.WatchDeploymentsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+
+	// Watches are logged once opened, without an item count.
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=watch resource=deployments namespace=default labelSelector="app=web" duration=`)
+	assert.NotContains(t, buf.String(), "items=")
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// APIResource describes a resource served by the API server in one group version.
+type APIResource struct {
+	Group    string
+	Version  string
+	Resource string
+	Kind     string
+	// Namespaced is false for cluster-scoped resources such as Nodes.
+	Namespaced bool
+	// Verbs are the operations the resource supports, e.g. "get", "list" and "watch".
+	Verbs []string
+	// Preferred is true when Version is the preferred version of Group.
+	Preferred bool
+}
+
+// GroupVersionResource returns the GroupVersionResource addressing r, e.g. for use
+// with CustomResourceAPI.
+func (r APIResource) GroupVersionResource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// APIDeprecation records the Kubernetes minor versions deprecating and removing a
+// resource in one group version, e.g. ingresses in networking.k8s.io/v1beta1.
+type APIDeprecation struct {
+	Group    string
+	Version  string
+	Resource string
+	// DeprecatedIn and RemovedIn are Kubernetes versions such as "v1.22". RemovedIn is
+	// empty when no removal is scheduled, and both are empty when the deprecation was
+	// inferred from a newer preferred version of the group.
+	DeprecatedIn string
+	RemovedIn    string
+	// Replacement is the group version to migrate to, e.g. "networking.k8s.io/v1", or
+	// empty when there is none.
+	Replacement string
+}
+
+// DeprecatedResource is a served resource with a deprecation recorded for it.
+type DeprecatedResource struct {
+	APIResource
+	Deprecation APIDeprecation
+	// Removed is true when the resource is no longer served as of the target version
+	// it was checked against.
+	Removed bool
+}
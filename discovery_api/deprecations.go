@@ -0,0 +1,123 @@
+package discoveryapi
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/kaudit/api"
+)
+
+// deprecations lists the built-in APIs removed from Kubernetes since v1.16, after the
+// upstream deprecated API migration guide, followed by those deprecated but still
+// served, whose RemovedIn is empty until a removal is scheduled.
+var deprecations = []api.APIDeprecation{
+	// v1.16
+	{Group: "extensions", Version: "v1beta1", Resource: "daemonsets", DeprecatedIn: "v1.8", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "extensions", Version: "v1beta1", Resource: "deployments", DeprecatedIn: "v1.8", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "extensions", Version: "v1beta1", Resource: "replicasets", DeprecatedIn: "v1.8", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "extensions", Version: "v1beta1", Resource: "networkpolicies", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "networking.k8s.io/v1"},
+	{Group: "extensions", Version: "v1beta1", Resource: "podsecuritypolicies", DeprecatedIn: "v1.10", RemovedIn: "v1.16", Replacement: "policy/v1beta1"},
+	{Group: "apps", Version: "v1beta1", Resource: "deployments", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "apps", Version: "v1beta1", Resource: "statefulsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "apps", Version: "v1beta2", Resource: "daemonsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "apps", Version: "v1beta2", Resource: "deployments", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "apps", Version: "v1beta2", Resource: "replicasets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+	{Group: "apps", Version: "v1beta2", Resource: "statefulsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: "apps/v1"},
+
+	// v1.22
+	{Group: "extensions", Version: "v1beta1", Resource: "ingresses", DeprecatedIn: "v1.14", RemovedIn: "v1.22", Replacement: "networking.k8s.io/v1"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "networking.k8s.io/v1"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingressclasses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "networking.k8s.io/v1"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Resource: "mutatingwebhookconfigurations", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: "admissionregistration.k8s.io/v1"},
+	{Group: "admissionregistration.k8s.io", Version: "v1beta1", Resource: "validatingwebhookconfigurations", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: "admissionregistration.k8s.io/v1"},
+	{Group: "apiextensions.k8s.io", Version: "v1beta1", Resource: "customresourcedefinitions", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: "apiextensions.k8s.io/v1"},
+	{Group: "apiregistration.k8s.io", Version: "v1beta1", Resource: "apiservices", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "apiregistration.k8s.io/v1"},
+	{Group: "authentication.k8s.io", Version: "v1beta1", Resource: "tokenreviews", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "authentication.k8s.io/v1"},
+	{Group: "authorization.k8s.io", Version: "v1beta1", Resource: "localsubjectaccessreviews", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "authorization.k8s.io/v1"},
+	{Group: "authorization.k8s.io", Version: "v1beta1", Resource: "selfsubjectaccessreviews", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "authorization.k8s.io/v1"},
+	{Group: "authorization.k8s.io", Version: "v1beta1", Resource: "subjectaccessreviews", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "authorization.k8s.io/v1"},
+	{Group: "certificates.k8s.io", Version: "v1beta1", Resource: "certificatesigningrequests", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "certificates.k8s.io/v1"},
+	{Group: "coordination.k8s.io", Version: "v1beta1", Resource: "leases", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "coordination.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Resource: "clusterroles", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Resource: "clusterrolebindings", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Resource: "roles", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Resource: "rolebindings", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{Group: "scheduling.k8s.io", Version: "v1beta1", Resource: "priorityclasses", DeprecatedIn: "v1.14", RemovedIn: "v1.22", Replacement: "scheduling.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Resource: "csidrivers", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "storage.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Resource: "csinodes", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: "storage.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Resource: "storageclasses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "storage.k8s.io/v1"},
+	{Group: "storage.k8s.io", Version: "v1beta1", Resource: "volumeattachments", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: "storage.k8s.io/v1"},
+
+	// v1.25
+	{Group: "batch", Version: "v1beta1", Resource: "cronjobs", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: "batch/v1"},
+	{Group: "discovery.k8s.io", Version: "v1beta1", Resource: "endpointslices", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: "discovery.k8s.io/v1"},
+	{Group: "events.k8s.io", Version: "v1beta1", Resource: "events", DeprecatedIn: "v1.19", RemovedIn: "v1.25", Replacement: "events.k8s.io/v1"},
+	{Group: "autoscaling", Version: "v2beta1", Resource: "horizontalpodautoscalers", DeprecatedIn: "v1.22", RemovedIn: "v1.25", Replacement: "autoscaling/v2"},
+	{Group: "policy", Version: "v1beta1", Resource: "poddisruptionbudgets", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: "policy/v1"},
+	{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: ""},
+	{Group: "node.k8s.io", Version: "v1beta1", Resource: "runtimeclasses", DeprecatedIn: "v1.20", RemovedIn: "v1.25", Replacement: "node.k8s.io/v1"},
+
+	// v1.26
+	{Group: "autoscaling", Version: "v2beta2", Resource: "horizontalpodautoscalers", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: "autoscaling/v2"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Resource: "flowschemas", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta1", Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+
+	// v1.27
+	{Group: "storage.k8s.io", Version: "v1beta1", Resource: "csistoragecapacities", DeprecatedIn: "v1.24", RemovedIn: "v1.27", Replacement: "storage.k8s.io/v1"},
+
+	// v1.29
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Resource: "flowschemas", DeprecatedIn: "v1.26", RemovedIn: "v1.29", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta2", Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.26", RemovedIn: "v1.29", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+
+	// v1.32
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Resource: "flowschemas", DeprecatedIn: "v1.29", RemovedIn: "v1.32", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+	{Group: "flowcontrol.apiserver.k8s.io", Version: "v1beta3", Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.29", RemovedIn: "v1.32", Replacement: "flowcontrol.apiserver.k8s.io/v1"},
+
+	// Deprecated, still served
+	{Group: "", Version: "v1", Resource: "componentstatuses", DeprecatedIn: "v1.19", RemovedIn: "", Replacement: ""},
+	{Group: "", Version: "v1", Resource: "endpoints", DeprecatedIn: "v1.33", RemovedIn: "", Replacement: "discovery.k8s.io/v1"},
+}
+
+// Deprecations returns the built-in table of deprecated APIs that
+// DiscoveryAPI.ListDeprecatedResources checks served resources against. It does not
+// list the older versions of a group that are superseded by its preferred version, which
+// ListDeprecatedResources detects from discovery.
+func Deprecations() []api.APIDeprecation {
+	return slices.Clone(deprecations)
+}
+
+// deprecationOf returns the deprecation recorded for r, if any.
+func deprecationOf(r api.APIResource) (api.APIDeprecation, bool) {
+	i := slices.IndexFunc(deprecations, func(d api.APIDeprecation) bool {
+		return d.Group == r.Group && d.Version == r.Version && d.Resource == r.Resource
+	})
+	if i < 0 {
+		return api.APIDeprecation{}, false
+	}
+	return deprecations[i], true
+}
+
+// supersededOf returns the deprecation of r implied by discovery: r is superseded when it
+// is served in a version of its group other than the preferred one, and the preferred
+// version serves it too. preferred maps the resources served in a preferred version to
+// that version.
+func supersededOf(r api.APIResource, preferred map[schema.GroupResource]string) (api.APIDeprecation, bool) {
+	preferredVersion, ok := preferred[schema.GroupResource{Group: r.Group, Resource: r.Resource}]
+	if !ok || r.Preferred {
+		return api.APIDeprecation{}, false
+	}
+	return api.APIDeprecation{
+		Group:       r.Group,
+		Version:     r.Version,
+		Resource:    r.Resource,
+		Replacement: schema.GroupVersion{Group: r.Group, Version: preferredVersion}.String(),
+	}, true
+}
+
+// removedAsOf reports whether d is removed as of target; it is not when no removal is
+// scheduled.
+func removedAsOf(d api.APIDeprecation, target *version.Version) bool {
+	return d.RemovedIn != "" && target.AtLeast(version.MustParseGeneric(d.RemovedIn))
+}
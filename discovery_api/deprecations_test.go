@@ -0,0 +1,68 @@
+package discoveryapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/kaudit/api"
+)
+
+func TestDeprecations(t *testing.T) {
+	table := Deprecations()
+	require.NotEmpty(t, table)
+
+	seen := make(map[string]bool, len(table))
+	for _, d := range table {
+		key := d.Group + "/" + d.Version + "/" + d.Resource
+		assert.False(t, seen[key], "duplicate entry %s", key)
+		seen[key] = true
+
+		deprecatedIn, err := version.ParseGeneric(d.DeprecatedIn)
+		require.NoError(t, err, key)
+		if d.RemovedIn == "" {
+			continue
+		}
+		removedIn, err := version.ParseGeneric(d.RemovedIn)
+		require.NoError(t, err, key)
+		assert.True(t, deprecatedIn.LessThan(removedIn), "%s is removed before it is deprecated", key)
+	}
+
+	// The returned table is a copy
+	table[0].RemovedIn = "v0.0"
+	assert.NotEqual(t, "v0.0", Deprecations()[0].RemovedIn)
+}
+
+func TestDeprecationOf(t *testing.T) {
+	d, ok := deprecationOf(api.APIResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"})
+	require.True(t, ok)
+	assert.Equal(t, "batch/v1", d.Replacement)
+
+	_, ok = deprecationOf(api.APIResource{Group: "batch", Version: "v1", Resource: "cronjobs"})
+	assert.False(t, ok)
+
+	d, ok = deprecationOf(api.APIResource{Version: "v1", Resource: "endpoints"})
+	require.True(t, ok)
+	assert.Empty(t, d.RemovedIn)
+	assert.False(t, removedAsOf(d, version.MustParseGeneric("v1.40")))
+}
+
+func TestSupersededOf(t *testing.T) {
+	preferred := map[schema.GroupResource]string{
+		{Group: "example.com", Resource: "widgets"}: "v1",
+	}
+
+	d, ok := supersededOf(api.APIResource{Group: "example.com", Version: "v1alpha1", Resource: "widgets"}, preferred)
+	require.True(t, ok)
+	assert.Equal(t, api.APIDeprecation{Group: "example.com", Version: "v1alpha1", Resource: "widgets", Replacement: "example.com/v1"}, d)
+
+	_, ok = supersededOf(api.APIResource{Group: "example.com", Version: "v1", Resource: "widgets", Preferred: true}, preferred)
+	assert.False(t, ok)
+
+	// Not served in the preferred version, so nothing replaces it.
+	_, ok = supersededOf(api.APIResource{Group: "example.com", Version: "v1alpha1", Resource: "gadgets"}, preferred)
+	assert.False(t, ok)
+}
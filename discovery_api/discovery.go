@@ -0,0 +1,269 @@
+package discoveryapi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// DiscoveryAPI provides high-level methods for enumerating the API groups, versions
+// and resources served by the API server, and for detecting deprecated APIs among them.
+//
+// The discovery endpoints do not accept a context: ctx is only checked before each
+// request is issued.
+type DiscoveryAPI struct {
+	client  discovery.DiscoveryInterface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// NewDiscoveryAPI creates a new DiscoveryAPI instance using the discovery client of
+// the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewDiscoveryAPI(client kubernetes.Interface, opts ...Option) *DiscoveryAPI {
+	o := newOptions(opts)
+	return &DiscoveryAPI{
+		client:  client.Discovery(),
+		limiter: o.limiter,
+		logger:  o.logger,
+	}
+}
+
+// ListServerResources lists every resource served by the API server in every group
+// version, sorted by group, version and resource. Subresources such as pods/exec are
+// not included.
+//
+// Aggregated API servers that fail to answer, such as an unavailable metrics-server,
+// do not prevent the other groups from being listed: their resources are omitted and
+// the error naming them is returned along with the resources that were discovered.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//
+// Returns the served resources or an error.
+func (d *DiscoveryAPI) ListServerResources(ctx context.Context) ([]api.APIResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list server resources: %w", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "apiresources"}
+	start := time.Now()
+	release, err := d.limiter.Acquire(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list server resources: %w", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	groups, lists, err := d.client.ServerGroupsAndResources()
+	release()
+	if err != nil {
+		// Groups failing discovery are reported as well when the others were listed
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, fmt.Errorf("failed to list server resources: %w", err)
+		}
+	} else {
+		reqlog.Succeeded(ctx, d.logger, req, start, len(lists))
+	}
+
+	preferred := make(map[string]string, len(groups))
+	for _, group := range groups {
+		preferred[group.Name] = group.PreferredVersion.Version
+	}
+
+	var resources []api.APIResource
+	for _, list := range lists {
+		gv, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+			resources = append(resources, api.APIResource{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   r.Name,
+				Kind:       r.Kind,
+				Namespaced: r.Namespaced,
+				Verbs:      slices.Clone([]string(r.Verbs)),
+				Preferred:  preferred[gv.Group] == gv.Version,
+			})
+		}
+	}
+
+	slices.SortFunc(resources, func(a, b api.APIResource) int {
+		return cmp.Or(
+			cmp.Compare(a.Group, b.Group),
+			cmp.Compare(a.Version, b.Version),
+			cmp.Compare(a.Resource, b.Resource),
+		)
+	})
+
+	if err != nil {
+		return resources, fmt.Errorf("failed to list server resources: %w", err)
+	}
+	return resources, nil
+}
+
+// ListPreferredResources lists the resources served in the preferred version of
+// their group, see ListServerResources.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//
+// Returns the served resources in their preferred versions or an error.
+func (d *DiscoveryAPI) ListPreferredResources(ctx context.Context) ([]api.APIResource, error) {
+	resources, err := d.ListServerResources(ctx)
+	resources = slices.DeleteFunc(resources, func(r api.APIResource) bool {
+		return !r.Preferred
+	})
+	return resources, err
+}
+
+// IsServed reports whether the API server serves a resource.
+//
+// When gvr.Resource is empty, IsServed reports whether the group version is served at
+// all, e.g. networking.k8s.io/v1beta1.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - gvr: Group, version and optional plural resource name; the group is empty for the
+//     core API group.
+//
+// Returns whether the resource is served or an error.
+func (d *DiscoveryAPI) IsServed(ctx context.Context, gvr schema.GroupVersionResource) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion(), err)
+	}
+	if gvr.Version == "" {
+		return false, api.NewValidationError(gvr.GroupResource().String(), "", "", "gvr", "invalid resource version", errors.New("version is required"))
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "apiresources", Name: gvr.GroupVersion().String()}
+	start := time.Now()
+	list, err := throttle.Do(ctx, d.limiter, func() (*metav1.APIResourceList, error) {
+		return d.client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	})
+	if apierrors.IsNotFound(err) {
+		reqlog.Succeeded(ctx, d.logger, req, start, 0)
+		return false, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion(), err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return false, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 1)
+
+	if gvr.Resource == "" {
+		return true, nil
+	}
+	return slices.ContainsFunc(list.APIResources, func(r metav1.APIResource) bool {
+		return r.Name == gvr.Resource
+	}), nil
+}
+
+// ListDeprecatedResources lists the served resources that are deprecated as of
+// targetVersion according to the table returned by Deprecations. Resources also
+// removed as of targetVersion are flagged as Removed: they will stop being served when
+// the cluster is upgraded to it.
+//
+// Resources missing from the table, such as those of aggregated APIs and CRDs, are
+// reported as well when the served version is superseded: the group prefers another
+// version serving the same resource. Their deprecation names the preferred version as
+// the replacement and leaves DeprecatedIn and RemovedIn empty.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - targetVersion: Kubernetes version such as "v1.25" or "1.25.3"; empty for the
+//     version of the API server.
+//
+// Returns the deprecated resources or an error. As with ListServerResources, groups
+// that fail to be discovered are reported in an error along with the results.
+func (d *DiscoveryAPI) ListDeprecatedResources(ctx context.Context, targetVersion string) ([]api.DeprecatedResource, error) {
+	target, err := d.targetVersion(ctx, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := d.ListServerResources(ctx)
+	if resources == nil {
+		return nil, err
+	}
+
+	preferred := make(map[schema.GroupResource]string)
+	for _, r := range resources {
+		if r.Preferred {
+			preferred[schema.GroupResource{Group: r.Group, Resource: r.Resource}] = r.Version
+		}
+	}
+
+	var deprecated []api.DeprecatedResource
+	for _, r := range resources {
+		deprecation, ok := deprecationOf(r)
+		if ok && target.LessThan(version.MustParseGeneric(deprecation.DeprecatedIn)) {
+			continue
+		}
+		if !ok {
+			if deprecation, ok = supersededOf(r, preferred); !ok {
+				continue
+			}
+		}
+		deprecated = append(deprecated, api.DeprecatedResource{
+			APIResource: r,
+			Deprecation: deprecation,
+			Removed:     removedAsOf(deprecation, target),
+		})
+	}
+
+	return deprecated, err
+}
+
+// targetVersion parses targetVersion, or returns the version of the API server when it
+// is empty.
+func (d *DiscoveryAPI) targetVersion(ctx context.Context, targetVersion string) (*version.Version, error) {
+	if targetVersion != "" {
+		v, err := version.ParseGeneric(targetVersion)
+		if err != nil {
+			return nil, api.NewValidationError("", "", "", "targetVersion", "invalid target version", err)
+		}
+		return v, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+	req := reqlog.Request{Verb: "get", Resource: "version"}
+	start := time.Now()
+	info, err := throttle.Do(ctx, d.limiter, d.client.ServerVersion)
+	if err != nil {
+		err = fmt.Errorf("failed to get server version: %w", err)
+		reqlog.Failed(ctx, d.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, d.logger, req, start, 1)
+	v, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse server version %q: %w", info.GitVersion, err)
+	}
+	return v, nil
+}
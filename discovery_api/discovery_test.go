@@ -0,0 +1,363 @@
+package discoveryapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+// newFakeClient returns a client serving a 1.21 cluster with:
+//   - core/v1 pods (and pods/exec) and nodes
+//   - networking.k8s.io/v1 and the deprecated v1beta1 ingresses, v1 being preferred
+//   - batch/v1 jobs and the deprecated batch/v1beta1 cronjobs
+//   - cert-manager.io/v1 certificates
+func newFakeClient() *fake.Clientset {
+	client := fake.NewClientset()
+	fd := client.Discovery().(*fakediscovery.FakeDiscovery)
+	fd.FakedServerVersion = &version.Info{GitVersion: "v1.21.14"}
+	fd.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list", "watch"}},
+				{Name: "pods/exec", Kind: "PodExecOptions", Namespaced: true, Verbs: metav1.Verbs{"create"}},
+				{Name: "nodes", Kind: "Node", Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "ingresses", Kind: "Ingress", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "networking.k8s.io/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "ingresses", Kind: "Ingress", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "batch/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "jobs", Kind: "Job", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "batch/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "cronjobs", Kind: "CronJob", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: "Certificate", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		},
+	}
+	return client
+}
+
+// resource is implemented by api.APIResource and api.DeprecatedResource.
+type resource interface {
+	GroupVersionResource() schema.GroupVersionResource
+}
+
+// summarize renders resources as group/version/resource for comparison.
+func summarize[T resource](resources []T) []string {
+	out := make([]string, 0, len(resources))
+	for _, r := range resources {
+		gvr := r.GroupVersionResource()
+		out = append(out, gvr.Group+"/"+gvr.Version+"/"+gvr.Resource)
+	}
+	return out
+}
+
+func TestNewDiscoveryAPI(t *testing.T) {
+	client := fake.NewClientset()
+	discoveryAPI := NewDiscoveryAPI(client)
+	assert.NotNil(t, discoveryAPI)
+	assert.Equal(t, client.Discovery(), discoveryAPI.client)
+}
+
+func TestDiscoveryAPI_ListServerResources(t *testing.T) {
+	t.Run("Inventory", func(t *testing.T) {
+		discoveryAPI := NewDiscoveryAPI(newFakeClient())
+
+		resources, err := discoveryAPI.ListServerResources(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"/v1/nodes",
+			"/v1/pods",
+			"batch/v1/jobs",
+			"batch/v1beta1/cronjobs",
+			"cert-manager.io/v1/certificates",
+			"networking.k8s.io/v1/ingresses",
+			"networking.k8s.io/v1beta1/ingresses",
+		}, summarize(resources))
+
+		assert.Equal(t, api.APIResource{
+			Version:    "v1",
+			Resource:   "nodes",
+			Kind:       "Node",
+			Namespaced: false,
+			Verbs:      []string{"get", "list"},
+			Preferred:  true,
+		}, resources[0])
+		assert.True(t, resources[5].Preferred)
+		assert.False(t, resources[6].Preferred)
+	})
+
+	t.Run("Partial discovery failure", func(t *testing.T) {
+		client := newFakeClient()
+		failed := &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{
+			{Group: "metrics.k8s.io", Version: "v1beta1"}: errors.New("service unavailable"),
+		}}
+		client.PrependReactor("get", "resource", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, failed
+		})
+		discoveryAPI := NewDiscoveryAPI(client)
+
+		resources, err := discoveryAPI.ListServerResources(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "metrics.k8s.io/v1beta1")
+		assert.Len(t, resources, 7)
+	})
+
+	t.Run("Discovery failure", func(t *testing.T) {
+		client := newFakeClient()
+		client.PrependReactor("get", "group", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		discoveryAPI := NewDiscoveryAPI(client)
+
+		resources, err := discoveryAPI.ListServerResources(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list server resources")
+		assert.Nil(t, resources)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		discoveryAPI := NewDiscoveryAPI(newFakeClient())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := discoveryAPI.ListServerResources(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDiscoveryAPI_ListPreferredResources(t *testing.T) {
+	discoveryAPI := NewDiscoveryAPI(newFakeClient())
+
+	resources, err := discoveryAPI.ListPreferredResources(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/v1/nodes",
+		"/v1/pods",
+		"batch/v1/jobs",
+		"cert-manager.io/v1/certificates",
+		"networking.k8s.io/v1/ingresses",
+	}, summarize(resources))
+}
+
+func TestDiscoveryAPI_IsServed(t *testing.T) {
+	discoveryAPI := NewDiscoveryAPI(newFakeClient())
+
+	tests := []struct {
+		name          string
+		gvr           schema.GroupVersionResource
+		expected      bool
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:     "Served resource",
+			gvr:      schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"},
+			expected: true,
+		},
+		{
+			name:     "Served group version",
+			gvr:      schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1"},
+			expected: true,
+		},
+		{
+			name:     "Core group",
+			gvr:      schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+			expected: true,
+		},
+		{
+			name:     "Resource not served in group version",
+			gvr:      schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingressclasses"},
+			expected: false,
+		},
+		{
+			name:     "Group version not served",
+			gvr:      schema.GroupVersionResource{Group: "extensions", Version: "v1beta1"},
+			expected: false,
+		},
+		{
+			name:          "Missing version",
+			gvr:           schema.GroupVersionResource{Group: "networking.k8s.io", Resource: "ingresses"},
+			wantErr:       true,
+			errorContains: "invalid resource version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served, err := discoveryAPI.IsServed(context.Background(), tt.gvr)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, api.ErrValidation)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, served)
+		})
+	}
+}
+
+func TestDiscoveryAPI_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	discoveryAPI := NewDiscoveryAPI(newFakeClient(), WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	_, err := discoveryAPI.IsServed(context.Background(), schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=get resource=apiresources name=networking.k8s.io/v1 duration=`)
+
+	_, err = discoveryAPI.ListDeprecatedResources(context.Background(), "")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=get resource=version duration=`)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=list resource=apiresources duration=`)
+}
+
+func TestDiscoveryAPI_ListDeprecatedResources(t *testing.T) {
+	discoveryAPI := NewDiscoveryAPI(newFakeClient())
+
+	tests := []struct {
+		name          string
+		targetVersion string
+		expected      []string
+		removed       []bool
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "Server version",
+			targetVersion: "",
+			expected:      []string{"batch/v1beta1/cronjobs", "networking.k8s.io/v1beta1/ingresses"},
+			removed:       []bool{false, false},
+		},
+		{
+			name:          "Upgrade target removing ingresses",
+			targetVersion: "v1.22",
+			expected:      []string{"batch/v1beta1/cronjobs", "networking.k8s.io/v1beta1/ingresses"},
+			removed:       []bool{false, true},
+		},
+		{
+			name:          "Upgrade target removing both",
+			targetVersion: "1.25.0",
+			expected:      []string{"batch/v1beta1/cronjobs", "networking.k8s.io/v1beta1/ingresses"},
+			removed:       []bool{true, true},
+		},
+		{
+			name:          "Target before the cronjob deprecation",
+			targetVersion: "v1.20",
+			expected:      []string{"networking.k8s.io/v1beta1/ingresses"},
+			removed:       []bool{false},
+		},
+		{
+			name:          "Invalid target version",
+			targetVersion: "latest",
+			wantErr:       true,
+			errorContains: "invalid target version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deprecated, err := discoveryAPI.ListDeprecatedResources(context.Background(), tt.targetVersion)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, api.ErrValidation)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, summarize(deprecated))
+			removed := make([]bool, 0, len(deprecated))
+			for _, d := range deprecated {
+				removed = append(removed, d.Removed)
+			}
+			assert.Equal(t, tt.removed, removed)
+		})
+	}
+
+	t.Run("Replacement", func(t *testing.T) {
+		deprecated, err := discoveryAPI.ListDeprecatedResources(context.Background(), "v1.22")
+		require.NoError(t, err)
+		require.Len(t, deprecated, 2)
+		assert.Equal(t, "networking.k8s.io/v1", deprecated[1].Deprecation.Replacement)
+		assert.Equal(t, "v1.22", deprecated[1].Deprecation.RemovedIn)
+	})
+
+	t.Run("Served deprecations", func(t *testing.T) {
+		client := fake.NewClientset()
+		fd := client.Discovery().(*fakediscovery.FakeDiscovery)
+		fd.FakedServerVersion = &version.Info{GitVersion: "v1.33.1"}
+		fd.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{Name: "componentstatuses", Kind: "ComponentStatus", Verbs: metav1.Verbs{"get", "list"}},
+					{Name: "endpoints", Kind: "Endpoints", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+					{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				},
+			},
+			{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{
+					{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				},
+			},
+			{
+				GroupVersion: "example.com/v1alpha1",
+				APIResources: []metav1.APIResource{
+					{Name: "gadgets", Kind: "Gadget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+					{Name: "widgets", Kind: "Widget", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				},
+			},
+		}
+
+		deprecated, err := NewDiscoveryAPI(client).ListDeprecatedResources(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/v1/componentstatuses", "/v1/endpoints", "example.com/v1alpha1/widgets"}, summarize(deprecated))
+		for _, d := range deprecated {
+			assert.False(t, d.Removed)
+		}
+		assert.Equal(t, "discovery.k8s.io/v1", deprecated[1].Deprecation.Replacement)
+		assert.Equal(t, "example.com/v1", deprecated[2].Deprecation.Replacement)
+		assert.Empty(t, deprecated[2].Deprecation.DeprecatedIn)
+	})
+}
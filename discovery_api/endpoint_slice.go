@@ -0,0 +1,235 @@
+package discoveryapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// EndpointSliceAPI provides high-level methods for retrieving discovery.k8s.io/v1 EndpointSlices,
+// which list the network endpoints backing each Service.
+type EndpointSliceAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// NewEndpointSliceAPI creates a new EndpointSliceAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewEndpointSliceAPI(client kubernetes.Interface, opts ...Option) *EndpointSliceAPI {
+	o := newOptions(opts)
+	return &EndpointSliceAPI{
+		client:  client,
+		limiter: o.limiter,
+		logger:  o.logger,
+	}
+}
+
+// GetEndpointSliceByName retrieves a specific EndpointSlice by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the endpointslice (must be non-empty).
+//   - name: Name of the endpointslice (must be non-empty).
+//
+// Returns the matched *discoveryv1.EndpointSlice or an error if not found or invalid.
+func (e *EndpointSliceAPI) GetEndpointSliceByName(ctx context.Context, namespace, name string) (*discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, name, "name", "invalid endpointslice name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "endpointslices", Namespace: namespace, Name: name}
+	start := time.Now()
+	slice, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSlice, error) {
+		return e.client.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", namespace, name, fmt.Sprintf("failed to get endpointslice %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, 1)
+
+	return slice, nil
+}
+
+// ListEndpointSlicesByLabel lists endpointslices by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching endpointslices or an error.
+func (e *EndpointSliceAPI) ListEndpointSlicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "endpointslices", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSliceList, error) {
+		return e.client.DiscoveryV1().EndpointSlices(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", namespace, "", fmt.Sprintf("failed to list endpointslices by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEndpointSlicesByField lists endpointslices by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching endpointslices or an error.
+func (e *EndpointSliceAPI) ListEndpointSlicesByField(ctx context.Context, namespace string, fieldSelector string) ([]discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "endpointslices", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSliceList, error) {
+		return e.client.DiscoveryV1().EndpointSlices(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", namespace, "", fmt.Sprintf("failed to list endpointslices by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEndpointSlicesByLabelAllNamespaces lists endpointslices by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching endpointslices or an error.
+func (e *EndpointSliceAPI) ListEndpointSlicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "endpointslices", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSliceList, error) {
+		return e.client.DiscoveryV1().EndpointSlices(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", "", "", "failed to list endpointslices by label in all namespaces", err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListEndpointSlicesByFieldAllNamespaces lists endpointslices by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching endpointslices or an error.
+func (e *EndpointSliceAPI) ListEndpointSlicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "endpointslices", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSliceList, error) {
+		return e.client.DiscoveryV1().EndpointSlices(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", "", "", "failed to list endpointslices by field in all namespaces", err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListEndpointSlicesByQuery lists endpointslices by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching endpointslices, at most query.Limit when set, or an error.
+func (e *EndpointSliceAPI) ListEndpointSlicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]discoveryv1.EndpointSlice, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("EndpointSlice", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "endpointslices", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*discoveryv1.EndpointSliceList, error) {
+		return e.client.DiscoveryV1().EndpointSlices(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("EndpointSlice", namespace, "", fmt.Sprintf("failed to list endpointslices by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,526 @@
+package discoveryapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestNewEndpointSliceAPI(t *testing.T) {
+	client := fake.NewClientset()
+	api := NewEndpointSliceAPI(client)
+	assert.NotNil(t, api)
+	assert.Equal(t, client, api.client)
+}
+
+func TestEndpointSliceAPI_GetEndpointSliceByName(t *testing.T) {
+	// Setup a endpointslice in the test namespace
+	testEndpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-endpointslice",
+			Namespace: "test-namespace",
+		},
+	}
+
+	// Create fake clientset with test endpointslice
+	fakeClient := fake.NewClientset(testEndpointSlice)
+
+	// Initialize endpointslice API
+	sliceAPI := NewEndpointSliceAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		sliceName     string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Successfully get endpointslice",
+			namespace: "test-namespace",
+			sliceName: "test-endpointslice",
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			sliceName:     "test-endpointslice",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty endpointslice name",
+			namespace:     "test-namespace",
+			sliceName:     "",
+			wantErr:       true,
+			errorContains: "invalid endpointslice name",
+		},
+		{
+			name:          "EndpointSlice not found",
+			namespace:     "test-namespace",
+			sliceName:     "nonexistent-endpointslice",
+			wantErr:       true,
+			errorContains: "failed to get endpointslice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			slice, err := sliceAPI.GetEndpointSliceByName(ctx, tt.namespace, tt.sliceName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, slice)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, slice)
+				assert.Equal(t, tt.sliceName, slice.Name)
+				assert.Equal(t, tt.namespace, slice.Namespace)
+			}
+		})
+	}
+}
+
+func TestEndpointSliceAPI_ListEndpointSlicesByLabel(t *testing.T) {
+	// Setup test endpointslices
+	testEndpointSlices := []*discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-endpointslice-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-endpointslice-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-endpointslice",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-endpointslice",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testEndpointSlices {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize endpointslice API
+	sliceAPI := NewEndpointSliceAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List endpointslices by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-endpointslice-1", "test-endpointslice-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List endpointslices with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-endpointslice-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			endpointSlices, err := sliceAPI.ListEndpointSlicesByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, endpointSlices)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, endpointSlices, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(endpointSlices))
+				for _, item := range endpointSlices {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestEndpointSliceAPI_ListEndpointSlicesByField(t *testing.T) {
+	// Setup test endpointslices
+	testEndpointSlices := []*discoveryv1.EndpointSlice{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-endpointslice-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-endpointslice-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-endpointslice",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-endpointslice",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testEndpointSlices {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize endpointslice API
+	sliceAPI := NewEndpointSliceAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every endpointslice
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List endpointslices by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-endpointslice-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-endpointslice-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			endpointSlices, err := sliceAPI.ListEndpointSlicesByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, endpointSlices)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, endpointSlices, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestEndpointSliceAPI_ListEndpointSlicesAllNamespaces(t *testing.T) {
+	// Setup endpointslices with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	sliceAPI := NewEndpointSliceAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every endpointslice of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]discoveryv1.EndpointSlice, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+				return sliceAPI.ListEndpointSlicesByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+				return sliceAPI.ListEndpointSlicesByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+				return sliceAPI.ListEndpointSlicesByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+				return sliceAPI.ListEndpointSlicesByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+				return sliceAPI.ListEndpointSlicesByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestEndpointSliceAPI_ListEndpointSlicesByQuery(t *testing.T) {
+	// Setup endpointslices with different labels
+	fakeClient := fake.NewClientset(
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	sliceAPI := NewEndpointSliceAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := sliceAPI.ListEndpointSlicesByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package discoveryapi
+
+import (
+	"log/slog"
+
+	"github.com/kaudit/api"
+)
+
+// options holds the settings shared by the APIs of this package.
+type options struct {
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a DiscoveryAPI or an EndpointSliceAPI.
+type Option func(*options)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+//
+// A DiscoveryAPI call fetching every group at once is admitted as a single request.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(o *options) {
+		o.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+//
+// DiscoveryAPI logs the discovery documents it fetches with the resource "apiresources"
+// and the server version with the resource "version".
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// newOptions applies opts to the defaults.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
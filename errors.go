@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrContinueExpired is returned by paginated listings when the apiserver rejects a
+// continue token with 410 Gone, typically because the resourceVersion it refers to has
+// been compacted. The listing cannot be resumed and must be restarted from the first page.
+var ErrContinueExpired = errors.New("continue token expired")
+
+// ErrNamespaceNotAllowed is returned for calls addressing a namespace outside the
+// allowlist set with k8sapi.WithNamespaceAllowlist. It matches ErrForbidden.
+var ErrNamespaceNotAllowed = fmt.Errorf("namespace not allowed: %w", ErrForbidden)
+
+// Error categories reported by the resource APIs. Errors returned by the resource APIs
+// match at most one of them with errors.Is; errors.As with a *ResourceError or a
+// *ValidationError recovers the object the request was about. Failures of DiscoveryAPI
+// calls about the server itself, such as its version or served resources, are not
+// about an object and carry no category.
+var (
+	// ErrNotFound reports that the requested object does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrForbidden reports that the caller is not allowed to perform the request.
+	ErrForbidden = errors.New("forbidden")
+	// ErrValidation reports invalid input, rejected either before the request is sent
+	// or by the API server.
+	ErrValidation = errors.New("validation failed")
+	// ErrTimeout reports that the request did not complete in time, either because the
+	// context deadline expired or because the API server timed out.
+	ErrTimeout = errors.New("timeout")
+	// ErrConflict reports a conflicting concurrent modification of the object.
+	ErrConflict = errors.New("conflict")
+)
+
+// ResourceError is returned when a request about an object, or a list of objects, of a
+// resource kind fails. It wraps the underlying error, so helpers such as
+// apierrors.IsNotFound keep working, and the matching error category, if any.
+type ResourceError struct {
+	// Kind is the kind of the object, e.g. "Pod", or the group resource of a custom
+	// resource, e.g. "widgets.example.com".
+	Kind string
+	// Namespace and Name identify the object; they are empty when they do not apply,
+	// e.g. Name for listings and Namespace for cluster-scoped kinds.
+	Namespace string
+	Name      string
+	// Err is the underlying error.
+	Err error
+
+	msg      string
+	category error
+}
+
+// NewResourceError returns a *ResourceError describing the failure of a request about
+// an object of kind. msg describes the failed request and prefixes the message of err.
+//
+// The error category is derived from err: Kubernetes status errors such as NotFound or
+// Forbidden, and context.DeadlineExceeded, map to ErrNotFound, ErrForbidden,
+// ErrValidation, ErrTimeout or ErrConflict; other errors have no category.
+func NewResourceError(kind, namespace, name, msg string, err error) error {
+	return &ResourceError{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Err:       err,
+		msg:       msg,
+		category:  categoryOf(err),
+	}
+}
+
+// Error implements the error interface.
+func (e *ResourceError) Error() string {
+	return e.msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error category, if any, and the underlying error.
+func (e *ResourceError) Unwrap() []error {
+	if e.category == nil {
+		return []error{e.Err}
+	}
+	return []error{e.category, e.Err}
+}
+
+// ValidationError is returned when the input of a request is invalid. It matches
+// ErrValidation with errors.Is.
+type ValidationError struct {
+	// Kind, Namespace and Name identify the object the request was about, as far as
+	// they are known; see ResourceError.
+	Kind      string
+	Namespace string
+	Name      string
+	// Field is the name of the invalid parameter, e.g. "namespace" or "labelSelector".
+	Field string
+	// Err is the underlying validation error.
+	Err error
+
+	msg string
+}
+
+// NewValidationError returns a *ValidationError reporting that field is invalid in a
+// request about an object of kind. msg describes the invalid input and prefixes the
+// message of err.
+func NewValidationError(kind, namespace, name, field, msg string, err error) error {
+	return &ValidationError{
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		Field:     field,
+		Err:       err,
+		msg:       msg,
+	}
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying validation error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// categoryOf returns the error category matching err, or nil.
+func categoryOf(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrForbidden), errors.Is(err, ErrValidation),
+		errors.Is(err, ErrTimeout), errors.Is(err, ErrConflict):
+		// err already carries its category.
+		return nil
+	case apierrors.IsNotFound(err):
+		return ErrNotFound
+	case apierrors.IsForbidden(err):
+		return ErrForbidden
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return ErrValidation
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout
+	case apierrors.IsConflict(err):
+		return ErrConflict
+	}
+	return nil
+}
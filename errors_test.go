@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestNewResourceError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	categories := []error{ErrNotFound, ErrForbidden, ErrValidation, ErrTimeout, ErrConflict}
+
+	tests := []struct {
+		name     string
+		err      error
+		category error
+	}{
+		{
+			name:     "Not found",
+			err:      apierrors.NewNotFound(pods, "web"),
+			category: ErrNotFound,
+		},
+		{
+			name:     "Forbidden",
+			err:      apierrors.NewForbidden(pods, "web", errors.New("rbac denied")),
+			category: ErrForbidden,
+		},
+		{
+			name:     "Invalid",
+			err:      apierrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "web", field.ErrorList{field.Required(field.NewPath("spec"), "")}),
+			category: ErrValidation,
+		},
+		{
+			name:     "Bad request",
+			err:      apierrors.NewBadRequest("malformed selector"),
+			category: ErrValidation,
+		},
+		{
+			name:     "Server timeout",
+			err:      apierrors.NewServerTimeout(pods, "get", 1),
+			category: ErrTimeout,
+		},
+		{
+			name:     "Context deadline",
+			err:      fmt.Errorf("Get \"https://apiserver\": %w", context.DeadlineExceeded),
+			category: ErrTimeout,
+		},
+		{
+			name:     "Conflict",
+			err:      apierrors.NewConflict(pods, "web", errors.New("object was modified")),
+			category: ErrConflict,
+		},
+		{
+			name:     "Uncategorized",
+			err:      errors.New("connection refused"),
+			category: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewResourceError("Pod", "default", "web", `failed to get pod "web" in namespace "default"`, tt.err)
+
+			assert.Equal(t, `failed to get pod "web" in namespace "default": `+tt.err.Error(), err.Error())
+			assert.ErrorIs(t, err, tt.err)
+			for _, category := range categories {
+				assert.Equal(t, category == tt.category, errors.Is(err, category), "category %v", category)
+			}
+
+			var resourceErr *ResourceError
+			require.ErrorAs(t, err, &resourceErr)
+			assert.Equal(t, "Pod", resourceErr.Kind)
+			assert.Equal(t, "default", resourceErr.Namespace)
+			assert.Equal(t, "web", resourceErr.Name)
+			assert.Equal(t, tt.err, resourceErr.Err)
+		})
+	}
+
+	t.Run("Kubernetes helpers still apply", func(t *testing.T) {
+		err := fmt.Errorf("outer: %w", NewResourceError("Pod", "default", "web", "failed", apierrors.NewNotFound(pods, "web")))
+		assert.True(t, apierrors.IsNotFound(err))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Nested errors keep their category", func(t *testing.T) {
+		inner := NewValidationError("Pod", "default", "", "labelSelector", "invalid label selector", errors.New("bad"))
+		err := NewResourceError("Pod", "default", "", "failed to list pods", inner)
+
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.NotErrorIs(t, err, ErrNotFound)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "labelSelector", validationErr.Field)
+	})
+}
+
+func TestNewValidationError(t *testing.T) {
+	cause := errors.New("Key: '' Error:Field validation for '' failed on the 'required' tag")
+	err := NewValidationError("Pod", "default", "", "name", "invalid pod name", cause)
+
+	assert.Equal(t, "invalid pod name: "+cause.Error(), err.Error())
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrNotFound)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Pod", validationErr.Kind)
+	assert.Equal(t, "default", validationErr.Namespace)
+	assert.Equal(t, "name", validationErr.Field)
+
+	var resourceErr *ResourceError
+	assert.False(t, errors.As(err, &resourceErr))
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EventFilter selects the Events EventAPI.ListEvents and EventAPI.ListCoreEvents return.
+//
+// Events are stored in the namespace of the object they relate to, so Namespace is
+// required; every other field is optional and empty fields match any Event.
+type EventFilter struct {
+	// Namespace is the namespace of the involved object and of its Events.
+	Namespace string `validate:"required"`
+	// Kind, Name and UID identify the involved object, e.g. Kind "Pod". Matching on
+	// UID excludes Events of earlier objects that had the same name.
+	Kind string
+	Name string
+	UID  types.UID
+	// Reason is the machine-readable reason of the Event, e.g. "BackOff".
+	Reason string
+	// Type is either "Normal" or "Warning".
+	Type string `validate:"omitempty,oneof=Normal Warning"`
+}
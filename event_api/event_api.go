@@ -0,0 +1,390 @@
+package eventapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// EventAPI provides high-level methods for retrieving Kubernetes events.
+//
+// The Get and List methods read events.k8s.io/v1 Events. ListCoreEvents reads the
+// same Events through the legacy core/v1 API, whose objects carry the involvedObject
+// and source fields older tooling expects.
+type EventAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a EventAPI.
+type Option func(*EventAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(e *EventAPI) {
+		e.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(e *EventAPI) {
+		e.logger = logger
+	}
+}
+
+// NewEventAPI creates a new EventAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewEventAPI(client kubernetes.Interface, opts ...Option) *EventAPI {
+	e := &EventAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// GetEventByName retrieves a specific Event by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the event (must be non-empty).
+//   - name: Name of the event (must be non-empty).
+//
+// Returns the matched *eventsv1.Event or an error if not found or invalid.
+func (e *EventAPI) GetEventByName(ctx context.Context, namespace, name string) (*eventsv1.Event, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, name, "name", "invalid event name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "events", Namespace: namespace, Name: name}
+	start := time.Now()
+	event, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.Event, error) {
+		return e.client.EventsV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", namespace, name, fmt.Sprintf("failed to get event %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, 1)
+
+	return event, nil
+}
+
+// ListEventsByLabel lists events by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListEventsByLabel(ctx context.Context, namespace string, labelSelector string) ([]eventsv1.Event, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", namespace, "", fmt.Sprintf("failed to list events by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEventsByField lists events by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListEventsByField(ctx context.Context, namespace string, fieldSelector string) ([]eventsv1.Event, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Event", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", namespace, "", fmt.Sprintf("failed to list events by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEventsByLabelAllNamespaces lists events by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListEventsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]eventsv1.Event, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Event", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", "", "", "failed to list events by label in all namespaces", err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListEventsByFieldAllNamespaces lists events by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListEventsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]eventsv1.Event, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Event", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", "", "", "failed to list events by field in all namespaces", err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListEventsByQuery lists events by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching events, at most query.Limit when set, or an error.
+func (e *EventAPI) ListEventsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]eventsv1.Event, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Event", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "events", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", namespace, "", fmt.Sprintf("failed to list events by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEvents lists events.k8s.io/v1 Events matching filter.
+//
+// The filter is translated into a field selector on the regarding object, reason and
+// type, so matching happens on the API server.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - filter: Criteria the Events must match (Namespace must be non-empty).
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListEvents(ctx context.Context, filter api.EventFilter) ([]eventsv1.Event, error) {
+	if err := val.ValidateStruct(filter); err != nil {
+		return nil, api.NewValidationError("Event", filter.Namespace, "", "filter", "invalid event filter", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector(filter, "regarding"),
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", Namespace: filter.Namespace, FieldSelector: opts.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*eventsv1.EventList, error) {
+		return e.client.EventsV1().Events(filter.Namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", filter.Namespace, "", fmt.Sprintf("failed to list events in namespace %q", filter.Namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListCoreEvents lists core/v1 Events matching filter.
+//
+// The filter is translated into a field selector on the involved object, reason and
+// type, so matching happens on the API server.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - filter: Criteria the Events must match (Namespace must be non-empty).
+//
+// Returns all matching events or an error.
+func (e *EventAPI) ListCoreEvents(ctx context.Context, filter api.EventFilter) ([]corev1.Event, error) {
+	if err := val.ValidateStruct(filter); err != nil {
+		return nil, api.NewValidationError("Event", filter.Namespace, "", "filter", "invalid event filter", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector(filter, "involvedObject"),
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "events", Namespace: filter.Namespace, FieldSelector: opts.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, e.limiter, func() (*corev1.EventList, error) {
+		return e.client.CoreV1().Events(filter.Namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Event", filter.Namespace, "", fmt.Sprintf("failed to list core events in namespace %q", filter.Namespace), err)
+		reqlog.Failed(ctx, e.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, e.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListEventsForPod lists the events.k8s.io/v1 Events of a pod, such as one returned
+// by api.PodAPI.GetPodByName.
+//
+// Events are matched on the pod's UID when it is set, so Events of an earlier pod with
+// the same name are excluded.
+//
+// Returns all events of the pod or an error.
+func (e *EventAPI) ListEventsForPod(ctx context.Context, pod *corev1.Pod) ([]eventsv1.Event, error) {
+	if pod == nil {
+		return nil, api.NewValidationError("Pod", "", "", "pod", "invalid pod", errors.New("pod is nil"))
+	}
+
+	return e.ListEvents(ctx, filterFor("Pod", &pod.ObjectMeta))
+}
+
+// ListEventsForDeployment lists the events.k8s.io/v1 Events of a deployment, such as
+// one returned by api.DeploymentAPI.GetDeploymentByName.
+//
+// Only Events about the Deployment object itself are returned; Events of its
+// ReplicaSets and Pods are reported against those objects.
+//
+// Returns all events of the deployment or an error.
+func (e *EventAPI) ListEventsForDeployment(ctx context.Context, deployment *appsv1.Deployment) ([]eventsv1.Event, error) {
+	if deployment == nil {
+		return nil, api.NewValidationError("Deployment", "", "", "deployment", "invalid deployment", errors.New("deployment is nil"))
+	}
+
+	return e.ListEvents(ctx, filterFor("Deployment", &deployment.ObjectMeta))
+}
+
+// filterFor returns the filter matching the Events of the object of the given kind.
+//
+// The kind is passed explicitly because objects returned by the typed clients do not
+// have their TypeMeta populated.
+func filterFor(kind string, meta *metav1.ObjectMeta) api.EventFilter {
+	return api.EventFilter{
+		Namespace: meta.Namespace,
+		Kind:      kind,
+		Name:      meta.Name,
+		UID:       meta.UID,
+	}
+}
+
+// fieldSelector translates filter into a field selector, with the involved object
+// fields under prefix: "regarding" for events.k8s.io/v1, "involvedObject" for core/v1.
+func fieldSelector(filter api.EventFilter, prefix string) string {
+	set := fields.Set{}
+	for key, value := range map[string]string{
+		prefix + ".kind": filter.Kind,
+		prefix + ".name": filter.Name,
+		prefix + ".uid":  string(filter.UID),
+		"reason":         filter.Reason,
+		"type":           filter.Type,
+	} {
+		if value != "" {
+			set[key] = value
+		}
+	}
+	return fields.SelectorFromSet(set).String()
+}
@@ -0,0 +1,738 @@
+package eventapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestNewEventAPI(t *testing.T) {
+	client := fake.NewClientset()
+	eventAPI := NewEventAPI(client)
+	assert.NotNil(t, eventAPI)
+	assert.Equal(t, client, eventAPI.client)
+}
+
+func TestEventAPI_GetEventByName(t *testing.T) {
+	// Setup a event in the test namespace
+	testEvent := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-event",
+			Namespace: "test-namespace",
+		},
+		Reason: "BackOff",
+		Type:   "Warning",
+	}
+
+	// Create fake clientset with test event
+	fakeClient := fake.NewClientset(testEvent)
+
+	// Initialize event API
+	eventAPI := NewEventAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		eventName     string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Successfully get event",
+			namespace: "test-namespace",
+			eventName: "test-event",
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			eventName:     "test-event",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty event name",
+			namespace:     "test-namespace",
+			eventName:     "",
+			wantErr:       true,
+			errorContains: "invalid event name",
+		},
+		{
+			name:          "Event not found",
+			namespace:     "test-namespace",
+			eventName:     "nonexistent-event",
+			wantErr:       true,
+			errorContains: "failed to get event",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			event, err := eventAPI.GetEventByName(ctx, tt.namespace, tt.eventName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, event)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, event)
+				assert.Equal(t, tt.eventName, event.Name)
+				assert.Equal(t, tt.namespace, event.Namespace)
+			}
+		})
+	}
+}
+
+func TestEventAPI_ListEventsByLabel(t *testing.T) {
+	// Setup test events
+	testEvents := []*eventsv1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-event-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-event-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-event",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-event",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testEvents {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize event API
+	eventAPI := NewEventAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List events by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-event-1", "test-event-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List events with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-event-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			events, err := eventAPI.ListEventsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, events)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, events, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(events))
+				for _, item := range events {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestEventAPI_ListEventsByField(t *testing.T) {
+	// Setup test events
+	testEvents := []*eventsv1.Event{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-event-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-event-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-event",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-event",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Reason: "BackOff",
+			Type:   "Warning",
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testEvents {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize event API
+	eventAPI := NewEventAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every event
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List events by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-event-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-event-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			events, err := eventAPI.ListEventsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, events)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, events, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestEventAPI_ListEventsAllNamespaces(t *testing.T) {
+	// Setup events with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	eventAPI := NewEventAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every event of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]eventsv1.Event, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]eventsv1.Event, error) {
+				return eventAPI.ListEventsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]eventsv1.Event, error) {
+				return eventAPI.ListEventsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]eventsv1.Event, error) {
+				return eventAPI.ListEventsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]eventsv1.Event, error) {
+				return eventAPI.ListEventsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]eventsv1.Event, error) {
+				return eventAPI.ListEventsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestEventAPI_ListEventsByQuery(t *testing.T) {
+	// Setup events with different labels
+	fakeClient := fake.NewClientset(
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&eventsv1.Event{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	eventAPI := NewEventAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := eventAPI.ListEventsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
+
+// eventsReactor serves events.k8s.io/v1 and core/v1 list calls from items, evaluating
+// field selectors the fake clientset would otherwise ignore, and records the selectors.
+func eventsReactor(client *fake.Clientset, items []eventsv1.Event, selectors *[]string) {
+	client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		selector := action.(k8stesting.ListActionImpl).GetListRestrictions().Fields
+		*selectors = append(*selectors, selector.String())
+
+		prefix := "regarding"
+		if action.GetResource().Group == "" {
+			prefix = "involvedObject"
+		}
+
+		var matched []eventsv1.Event
+		for _, event := range items {
+			set := fields.Set{
+				prefix + ".kind": event.Regarding.Kind,
+				prefix + ".name": event.Regarding.Name,
+				prefix + ".uid":  string(event.Regarding.UID),
+				"reason":         event.Reason,
+				"type":           event.Type,
+			}
+			if event.Namespace == action.GetNamespace() && selector.Matches(set) {
+				matched = append(matched, event)
+			}
+		}
+
+		if prefix == "regarding" {
+			return true, &eventsv1.EventList{Items: matched}, nil
+		}
+		list := &corev1.EventList{}
+		for _, event := range matched {
+			list.Items = append(list.Items, corev1.Event{
+				ObjectMeta:     event.ObjectMeta,
+				InvolvedObject: event.Regarding,
+				Reason:         event.Reason,
+				Type:           event.Type,
+			})
+		}
+		return true, list, nil
+	})
+}
+
+// eventFixture returns events about a crashlooping pod, its previous incarnation and
+// a deployment.
+func eventFixture() []eventsv1.Event {
+	event := func(name, kind, object string, uid types.UID, reason, eventType string) eventsv1.Event {
+		return eventsv1.Event{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+			Regarding:  corev1.ObjectReference{Kind: kind, Namespace: "test-namespace", Name: object, UID: uid},
+			Reason:     reason,
+			Type:       eventType,
+		}
+	}
+
+	return []eventsv1.Event{
+		event("web.1", "Pod", "web", "pod-uid", "Pulled", "Normal"),
+		event("web.2", "Pod", "web", "pod-uid", "BackOff", "Warning"),
+		event("web.3", "Pod", "web", "old-pod-uid", "Killing", "Normal"),
+		event("frontend.1", "Deployment", "frontend", "deploy-uid", "ScalingReplicaSet", "Normal"),
+	}
+}
+
+func TestEventAPI_ListEvents(t *testing.T) {
+	tests := []struct {
+		name             string
+		filter           api.EventFilter
+		core             bool
+		expectedSelector string
+		expectedEvents   []string
+		wantErr          bool
+		errorContains    string
+	}{
+		{
+			name:             "Filter by object",
+			filter:           api.EventFilter{Namespace: "test-namespace", Kind: "Pod", Name: "web"},
+			expectedSelector: "regarding.kind=Pod,regarding.name=web",
+			expectedEvents:   []string{"web.1", "web.2", "web.3"},
+		},
+		{
+			name:             "Filter by reason and type",
+			filter:           api.EventFilter{Namespace: "test-namespace", Reason: "BackOff", Type: "Warning"},
+			expectedSelector: "reason=BackOff,type=Warning",
+			expectedEvents:   []string{"web.2"},
+		},
+		{
+			name:             "Core events filter by uid",
+			filter:           api.EventFilter{Namespace: "test-namespace", UID: "old-pod-uid"},
+			core:             true,
+			expectedSelector: "involvedObject.uid=old-pod-uid",
+			expectedEvents:   []string{"web.3"},
+		},
+		{
+			name:             "Namespace only",
+			filter:           api.EventFilter{Namespace: "test-namespace"},
+			expectedSelector: "",
+			expectedEvents:   []string{"web.1", "web.2", "web.3", "frontend.1"},
+		},
+		{
+			name:          "Empty namespace",
+			filter:        api.EventFilter{Kind: "Pod", Name: "web"},
+			wantErr:       true,
+			errorContains: "invalid event filter",
+		},
+		{
+			name:          "Unknown type",
+			filter:        api.EventFilter{Namespace: "test-namespace", Type: "Error"},
+			core:          true,
+			wantErr:       true,
+			errorContains: "invalid event filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var selectors []string
+			fakeClient := fake.NewClientset()
+			eventsReactor(fakeClient, eventFixture(), &selectors)
+			eventAPI := NewEventAPI(fakeClient)
+
+			var names []string
+			var err error
+			if tt.core {
+				var events []corev1.Event
+				events, err = eventAPI.ListCoreEvents(context.Background(), tt.filter)
+				for _, event := range events {
+					names = append(names, event.Name)
+				}
+			} else {
+				var events []eventsv1.Event
+				events, err = eventAPI.ListEvents(context.Background(), tt.filter)
+				for _, event := range events {
+					names = append(names, event.Name)
+				}
+			}
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Empty(t, selectors)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.expectedSelector}, selectors)
+			assert.ElementsMatch(t, tt.expectedEvents, names)
+		})
+	}
+}
+
+func TestEventAPI_ListEventsForObject(t *testing.T) {
+	var selectors []string
+	fakeClient := fake.NewClientset()
+	eventsReactor(fakeClient, eventFixture(), &selectors)
+	eventAPI := NewEventAPI(fakeClient)
+	ctx := context.Background()
+
+	t.Run("Pod", func(t *testing.T) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-namespace", UID: "pod-uid"}}
+
+		events, err := eventAPI.ListEventsForPod(ctx, pod)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(events))
+		for _, event := range events {
+			names = append(names, event.Name)
+		}
+		assert.ElementsMatch(t, []string{"web.1", "web.2"}, names)
+	})
+
+	t.Run("Deployment", func(t *testing.T) {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "test-namespace", UID: "deploy-uid"}}
+
+		events, err := eventAPI.ListEventsForDeployment(ctx, deployment)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "ScalingReplicaSet", events[0].Reason)
+	})
+
+	t.Run("Nil objects", func(t *testing.T) {
+		_, err := eventAPI.ListEventsForPod(ctx, nil)
+		require.ErrorIs(t, err, api.ErrValidation)
+		assert.Contains(t, err.Error(), "invalid pod")
+
+		_, err = eventAPI.ListEventsForDeployment(ctx, nil)
+		require.ErrorIs(t, err, api.ErrValidation)
+		assert.Contains(t, err.Error(), "invalid deployment")
+	})
+}
@@ -0,0 +1,109 @@
+package graphapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteDOT writes g to w in the Graphviz DOT language, e.g. to render it with
+// "dot -Tsvg". Nodes are labelled with their kind and name and shaped by kind, stubs
+// are dashed, and edges are labelled with their type.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+
+	for _, n := range g.Nodes() {
+		style := "solid"
+		if n.Stub {
+			style = "dashed"
+		}
+		fmt.Fprintf(bw, "\t%q [label=%q, shape=%s, style=%s];\n",
+			n.ID.String(), string(n.ID.Kind)+"\n"+n.ID.Name, shape(n.ID.Kind), style)
+	}
+	for _, e := range g.Edges() {
+		style := "solid"
+		if e.Type == EdgeContains {
+			style = "dotted"
+		}
+		fmt.Fprintf(bw, "\t%q -> %q [label=%q, style=%s];\n", e.From.String(), e.To.String(), e.Type, style)
+	}
+
+	fmt.Fprintln(bw, "}")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write graph as DOT: %w", err)
+	}
+	return nil
+}
+
+// shape returns the DOT shape of the nodes of kind.
+func shape(kind Kind) string {
+	switch kind {
+	case KindNamespace:
+		return "folder"
+	case KindService:
+		return "ellipse"
+	case KindPod:
+		return "box"
+	default:
+		return "component"
+	}
+}
+
+// jsonGraph is the JSON document written by WriteJSON.
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID        string            `json:"id"`
+	Kind      Kind              `json:"kind"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name"`
+	UID       string            `json:"uid,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Stub      bool              `json:"stub,omitempty"`
+}
+
+type jsonEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Type EdgeType `json:"type"`
+}
+
+// WriteJSON writes g to w as a JSON document of the form
+//
+//	{
+//	  "nodes": [{"id": "Pod/shop/web-1", "kind": "Pod", "namespace": "shop", "name": "web-1", ...}],
+//	  "edges": [{"from": "ReplicaSet/shop/web-7d4b9", "to": "Pod/shop/web-1", "type": "owns"}]
+//	}
+//
+// where ids are formatted by NodeID.String, and nodes and edges are ordered as by
+// Nodes and Edges.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	doc := jsonGraph{Nodes: []jsonNode{}, Edges: []jsonEdge{}}
+	for _, n := range g.Nodes() {
+		doc.Nodes = append(doc.Nodes, jsonNode{
+			ID:        n.ID.String(),
+			Kind:      n.ID.Kind,
+			Namespace: n.ID.Namespace,
+			Name:      n.ID.Name,
+			UID:       string(n.UID),
+			Labels:    n.Labels,
+			Stub:      n.Stub,
+		})
+	}
+	for _, e := range g.Edges() {
+		doc.Edges = append(doc.Edges, jsonEdge{From: e.From.String(), To: e.To.String(), Type: e.Type})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write graph as JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package graphapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	graph := buildFixture(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, graph.WriteDOT(&buf))
+
+	dot := buf.String()
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("digraph {\n\trankdir=LR;\n")))
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("}\n")))
+	assert.Contains(t, dot, `"Namespace/shop" [label="Namespace\nshop", shape=folder, style=solid];`)
+	assert.Contains(t, dot, `"Pod/shop/web-1" [label="Pod\nweb-1", shape=box, style=solid];`)
+	assert.Contains(t, dot, `"StatefulSet/shop/db" [label="StatefulSet\ndb", shape=component, style=dashed];`)
+	assert.Contains(t, dot, `"Node/node-1" [label="Node\nnode-1", shape=component, style=dashed];`)
+	assert.Contains(t, dot, `"Service/shop/web" -> "Pod/shop/web-1" [label="selects", style=solid];`)
+	assert.Contains(t, dot, `"ReplicaSet/shop/web-abc" -> "Pod/shop/web-2" [label="owns", style=solid];`)
+	assert.Contains(t, dot, `"Namespace/other" -> "Pod/other/web-x" [label="contains", style=dotted];`)
+
+	err := graph.WriteDOT(failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to write graph as DOT")
+}
+
+func TestGraph_WriteJSON(t *testing.T) {
+	graph := buildFixture(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, graph.WriteJSON(&buf))
+
+	var doc jsonGraph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Len(t, doc.Nodes, len(graph.Nodes()))
+	assert.Len(t, doc.Edges, len(graph.Edges()))
+	assert.Contains(t, doc.Nodes, jsonNode{
+		ID:        "Pod/shop/web-1",
+		Kind:      KindPod,
+		Namespace: "shop",
+		Name:      "web-1",
+		UID:       "pod-web-1",
+		Labels:    map[string]string{"app": "web"},
+	})
+	assert.Contains(t, doc.Nodes, jsonNode{
+		ID:        "StatefulSet/shop/db",
+		Kind:      "StatefulSet",
+		Namespace: "shop",
+		Name:      "db",
+		UID:       "sts-db",
+		Stub:      true,
+	})
+	assert.Contains(t, doc.Edges, jsonEdge{From: "Deployment/shop/web", To: "ReplicaSet/shop/web-abc", Type: EdgeOwns})
+
+	err := graph.WriteJSON(failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to write graph as JSON")
+}
+
+func TestGraph_WriteJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, newGraph().WriteJSON(&buf))
+	assert.JSONEq(t, `{"nodes": [], "edges": []}`, buf.String())
+}
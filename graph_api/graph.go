@@ -0,0 +1,323 @@
+package graphapi
+
+import (
+	"cmp"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Kind is the kind of the object a node stands for.
+type Kind string
+
+// Kinds of the objects a GraphAPI fetches. Nodes of other kinds are stubs, see Node.
+const (
+	KindNamespace  Kind = "Namespace"
+	KindDeployment Kind = "Deployment"
+	KindReplicaSet Kind = "ReplicaSet"
+	KindPod        Kind = "Pod"
+	KindService    Kind = "Service"
+)
+
+// clusterScopedKinds are the built-in kinds without a namespace that may own namespaced
+// objects, such as the Node owning the mirror Pods of its static Pods.
+var clusterScopedKinds = []Kind{
+	"Node",
+	"PersistentVolume",
+	"StorageClass",
+	"IngressClass",
+	"PriorityClass",
+	"RuntimeClass",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"CustomResourceDefinition",
+	"APIService",
+	"CertificateSigningRequest",
+	"CSIDriver",
+	"CSINode",
+	"VolumeAttachment",
+	"MutatingWebhookConfiguration",
+	"ValidatingWebhookConfiguration",
+}
+
+// NodeID identifies a node by the kind, namespace and name of its object. The
+// Namespace of a namespace node is empty.
+type NodeID struct {
+	Kind      Kind
+	Namespace string
+	Name      string
+}
+
+// String returns id as "Kind/namespace/name", or "Namespace/name" for a namespace.
+func (id NodeID) String() string {
+	if id.Namespace == "" {
+		return string(id.Kind) + "/" + id.Name
+	}
+	return string(id.Kind) + "/" + id.Namespace + "/" + id.Name
+}
+
+// compare orders ids by namespace, kind and name.
+func (id NodeID) compare(other NodeID) int {
+	return cmp.Or(
+		cmp.Compare(id.Namespace, other.Namespace),
+		cmp.Compare(id.Kind, other.Kind),
+		cmp.Compare(id.Name, other.Name),
+	)
+}
+
+// Node is an object of the graph.
+type Node struct {
+	ID     NodeID
+	UID    types.UID
+	Labels map[string]string
+	// Stub reports a node known only from the owner reference of another object, for
+	// owner kinds the GraphAPI does not fetch, such as StatefulSet or Job. Its UID is
+	// the one of the reference and it has no labels. The stub of a cluster-scoped kind,
+	// such as Node, has an empty Namespace and is not contained in any namespace.
+	Stub bool
+}
+
+// EdgeType is the relationship an edge stands for.
+type EdgeType string
+
+const (
+	// EdgeSelects goes from a Service to each Pod of its namespace its selector matches.
+	EdgeSelects EdgeType = "selects"
+	// EdgeOwns goes from an owner to each object listing it in its ownerReferences, such
+	// as from a Deployment to its ReplicaSets and from a ReplicaSet to its Pods.
+	EdgeOwns EdgeType = "owns"
+	// EdgeContains goes from a Namespace to each object of the namespace.
+	EdgeContains EdgeType = "contains"
+)
+
+// Edge is a relationship from one node to another.
+type Edge struct {
+	From NodeID
+	To   NodeID
+	Type EdgeType
+}
+
+// Graph is the ownership and selection graph of the objects of one or more namespaces,
+// as built by GraphAPI.Build.
+//
+// A Graph is immutable once built and safe for concurrent use.
+type Graph struct {
+	nodes map[NodeID]*Node
+	out   map[NodeID][]Edge
+	in    map[NodeID][]Edge
+}
+
+// newGraph returns an empty graph.
+func newGraph() *Graph {
+	return &Graph{
+		nodes: make(map[NodeID]*Node),
+		out:   make(map[NodeID][]Edge),
+		in:    make(map[NodeID][]Edge),
+	}
+}
+
+// Node returns the node of id, and whether there is one.
+func (g *Graph) Node(id NodeID) (Node, bool) {
+	n, ok := g.nodes[id]
+	if !ok {
+		return Node{}, false
+	}
+	return *n, true
+}
+
+// Nodes returns every node, ordered by namespace, kind and name.
+func (g *Graph) Nodes() []Node {
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, *n)
+	}
+	slices.SortFunc(nodes, func(a, b Node) int {
+		return a.ID.compare(b.ID)
+	})
+	return nodes
+}
+
+// Edges returns every edge, ordered by source, type and target.
+func (g *Graph) Edges() []Edge {
+	var edges []Edge
+	for _, out := range g.out {
+		edges = append(edges, out...)
+	}
+	slices.SortFunc(edges, func(a, b Edge) int {
+		return cmp.Or(a.From.compare(b.From), cmp.Compare(a.Type, b.Type), a.To.compare(b.To))
+	})
+	return edges
+}
+
+// Owners returns the objects whose deletion deletes the object of id: its owners,
+// following ownerReferences transitively, nearest first, then its namespace. The owners
+// of a Pod created by a Deployment are its ReplicaSet, the Deployment and the Namespace.
+//
+// Returns nil if there is no node of id.
+func (g *Graph) Owners(id NodeID) []Node {
+	if _, ok := g.nodes[id]; !ok {
+		return nil
+	}
+
+	owners := g.walk(id, g.in, func(e Edge) NodeID { return e.From }, EdgeOwns)
+	if ns := (NodeID{Kind: KindNamespace, Name: id.Namespace}); id.Namespace != "" && g.nodes[ns] != nil {
+		owners = append(owners, *g.nodes[ns])
+	}
+	return owners
+}
+
+// Dependents returns the objects deleted together with the object of id: the objects
+// it owns, following ownerReferences transitively, nearest first, or every object of
+// the namespace for a Namespace.
+//
+// Returns nil if there is no node of id.
+func (g *Graph) Dependents(id NodeID) []Node {
+	return g.walk(id, g.out, func(e Edge) NodeID { return e.To }, EdgeOwns, EdgeContains)
+}
+
+// Selected returns the Pods the Service of id selects, ordered by name.
+func (g *Graph) Selected(id NodeID) []Node {
+	return g.neighbors(id, g.out, func(e Edge) NodeID { return e.To }, EdgeSelects)
+}
+
+// SelectedBy returns the Services selecting the Pod of id, ordered by name.
+func (g *Graph) SelectedBy(id NodeID) []Node {
+	return g.neighbors(id, g.in, func(e Edge) NodeID { return e.From }, EdgeSelects)
+}
+
+// Orphans returns the objects cut off from the workload chain, ordered by namespace,
+// kind and name:
+//
+//   - Pods and ReplicaSets without an owner, because they never had one or because
+//     their owner no longer exists.
+//   - Services selecting no Pod.
+func (g *Graph) Orphans() []Node {
+	var orphans []Node
+	for _, n := range g.Nodes() {
+		switch n.ID.Kind {
+		case KindPod, KindReplicaSet:
+			if !slices.ContainsFunc(g.in[n.ID], func(e Edge) bool { return e.Type == EdgeOwns }) {
+				orphans = append(orphans, n)
+			}
+		case KindService:
+			if len(g.Selected(n.ID)) == 0 {
+				orphans = append(orphans, n)
+			}
+		}
+	}
+	return orphans
+}
+
+// walk returns the nodes reachable from id through edges of edgeTypes, breadth first.
+func (g *Graph) walk(id NodeID, adjacency map[NodeID][]Edge, next func(Edge) NodeID, edgeTypes ...EdgeType) []Node {
+	if _, ok := g.nodes[id]; !ok {
+		return nil
+	}
+
+	var nodes []Node
+	seen := map[NodeID]bool{id: true}
+	queue := []NodeID{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, n := range g.neighbors(current, adjacency, next, edgeTypes...) {
+			if seen[n.ID] {
+				continue
+			}
+			seen[n.ID] = true
+			nodes = append(nodes, n)
+			queue = append(queue, n.ID)
+		}
+	}
+	return nodes
+}
+
+// neighbors returns the nodes adjacent to id through edges of edgeTypes, ordered by
+// namespace, kind and name.
+func (g *Graph) neighbors(id NodeID, adjacency map[NodeID][]Edge, next func(Edge) NodeID, edgeTypes ...EdgeType) []Node {
+	var nodes []Node
+	for _, e := range adjacency[id] {
+		if slices.Contains(edgeTypes, e.Type) {
+			nodes = append(nodes, *g.nodes[next(e)])
+		}
+	}
+	slices.SortFunc(nodes, func(a, b Node) int {
+		return a.ID.compare(b.ID)
+	})
+	return nodes
+}
+
+// addNode adds the node of obj, of kind, and the edge from its namespace.
+func (g *Graph) addNode(kind Kind, obj metav1.Object) {
+	id := NodeID{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	g.nodes[id] = &Node{ID: id, UID: obj.GetUID(), Labels: obj.GetLabels()}
+	g.addContains(id)
+}
+
+// addContains adds the edge from the namespace of id, if it is in the graph.
+func (g *Graph) addContains(id NodeID) {
+	if ns := (NodeID{Kind: KindNamespace, Name: id.Namespace}); id.Namespace != "" && g.nodes[ns] != nil {
+		g.addEdge(ns, id, EdgeContains)
+	}
+}
+
+// addEdge adds the edge from one node to another.
+func (g *Graph) addEdge(from, to NodeID, typ EdgeType) {
+	e := Edge{From: from, To: to, Type: typ}
+	g.out[from] = append(g.out[from], e)
+	g.in[to] = append(g.in[to], e)
+}
+
+// linkOwners adds the edges from the owners of the object of id, of the given
+// references. An owner of a fetched kind is matched on its UID and left out when it no
+// longer exists; an owner of another kind is added as a stub, in the namespace of id
+// unless its kind is in clusterScoped.
+func (g *Graph) linkOwners(id NodeID, refs []metav1.OwnerReference, byUID map[types.UID]NodeID, clusterScoped map[Kind]bool) {
+	for _, ref := range refs {
+		if owner, ok := byUID[ref.UID]; ok {
+			g.addEdge(owner, id, EdgeOwns)
+			continue
+		}
+		if fetched(Kind(ref.Kind)) {
+			continue
+		}
+
+		owner := NodeID{Kind: Kind(ref.Kind), Namespace: id.Namespace, Name: ref.Name}
+		if clusterScoped[owner.Kind] {
+			owner.Namespace = ""
+		}
+		if _, ok := g.nodes[owner]; !ok {
+			g.nodes[owner] = &Node{ID: owner, UID: ref.UID, Stub: true}
+			byUID[ref.UID] = owner
+			g.addContains(owner)
+		}
+		g.addEdge(owner, id, EdgeOwns)
+	}
+}
+
+// linkSelected adds the edges from the Service of id, with selector, to the pods it
+// matches, which are the Pods of its namespace. A Service without a selector selects no
+// Pod.
+func (g *Graph) linkSelected(id NodeID, selector map[string]string, pods []NodeID) {
+	if len(selector) == 0 {
+		return
+	}
+
+	s := labels.SelectorFromSet(selector)
+	for _, pod := range pods {
+		if s.Matches(labels.Set(g.nodes[pod].Labels)) {
+			g.addEdge(id, pod, EdgeSelects)
+		}
+	}
+}
+
+// fetched reports whether the objects of kind are fetched by a GraphAPI.
+func fetched(kind Kind) bool {
+	switch kind {
+	case KindNamespace, KindDeployment, KindReplicaSet, KindPod, KindService:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package graphapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kaudit/api"
+)
+
+// GraphAPI builds the ownership and selection graph of the workloads of a cluster:
+// Services select Pods, Pods are owned by ReplicaSets, ReplicaSets by Deployments, and
+// every object is contained in its Namespace. It answers questions such as what a
+// Service routes to and which Deployment owns those Pods.
+type GraphAPI struct {
+	services    api.ServiceAPI
+	pods        api.PodAPI
+	replicaSets api.ReplicaSetAPI
+	deployments api.DeploymentAPI
+	namespaces  api.NamespaceAPI
+
+	clusterScoped map[Kind]bool
+	logger        *slog.Logger
+}
+
+// Option configures a GraphAPI.
+type Option func(*GraphAPI)
+
+// WithClusterScopedKinds declares owner kinds without a namespace besides the built-in
+// ones such as Node, typically cluster-scoped custom resources owning namespaced objects.
+// Owner references do not tell the scope of their kind, so the stubs of other kinds are
+// placed in the namespace of their dependent.
+func WithClusterScopedKinds(kinds ...Kind) Option {
+	return func(g *GraphAPI) {
+		for _, kind := range kinds {
+			g.clusterScoped[kind] = true
+		}
+	}
+}
+
+// WithLogger logs every Build at debug level, with its namespaces, duration and the
+// number of nodes and edges of the graph, and failed builds at warn level with their
+// error. The requests are logged by the APIs the objects are fetched through. By
+// default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(g *GraphAPI) {
+		g.logger = logger
+	}
+}
+
+// NewGraphAPI creates a new GraphAPI instance fetching objects through the provided
+// APIs, which may be the live or the cached ones.
+//
+// Options such as WithClusterScopedKinds and WithLogger customize the instance.
+func NewGraphAPI(services api.ServiceAPI, pods api.PodAPI, replicaSets api.ReplicaSetAPI, deployments api.DeploymentAPI, namespaces api.NamespaceAPI, opts ...Option) *GraphAPI {
+	g := &GraphAPI{
+		services:      services,
+		pods:          pods,
+		replicaSets:   replicaSets,
+		deployments:   deployments,
+		namespaces:    namespaces,
+		clusterScoped: make(map[Kind]bool),
+	}
+	for _, kind := range clusterScopedKinds {
+		g.clusterScoped[kind] = true
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Build fetches the given Namespaces, or every Namespace when none are given, with the
+// Deployments, ReplicaSets, Pods and Services they contain, and returns their graph.
+// Without namespaces, each kind is listed across the cluster with a single request;
+// otherwise each kind is listed once per namespace.
+//
+// Edges come from the ownerReferences of the objects and from the selectors of the
+// Services, see EdgeType. Owners of kinds that are not fetched, such as the
+// StatefulSet owning a Pod or the Node owning a mirror Pod, are added as stub nodes.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespaces: Names of the namespaces to build the graph of (each must be non-empty).
+//
+// Returns the graph or an error if any object cannot be fetched.
+func (g *GraphAPI) Build(ctx context.Context, namespaces ...string) (*Graph, error) {
+	start := time.Now()
+	graph, err := g.build(ctx, namespaces)
+	if err != nil {
+		if g.logger != nil {
+			g.logger.WarnContext(ctx, "failed to build graph",
+				"namespaces", namespaces, "duration", time.Since(start), "error", err)
+		}
+		return nil, err
+	}
+	if g.logger != nil {
+		edges := 0
+		for _, out := range graph.out {
+			edges += len(out)
+		}
+		g.logger.DebugContext(ctx, "built graph",
+			"namespaces", namespaces, "nodes", len(graph.nodes), "edges", edges, "duration", time.Since(start))
+	}
+	return graph, nil
+}
+
+// build fetches the objects of namespaces, or of every namespace, and links them.
+func (g *GraphAPI) build(ctx context.Context, namespaces []string) (*Graph, error) {
+	list, err := g.listNamespaces(ctx, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := newGraph()
+	for i := range list {
+		graph.addNode(KindNamespace, &list[i])
+	}
+
+	b := builder{
+		graph:         graph,
+		clusterScoped: g.clusterScoped,
+		byUID:         make(map[types.UID]NodeID),
+		pods:          make(map[string][]NodeID),
+	}
+	if len(namespaces) == 0 {
+		// Every namespace is listed with one request per kind
+		if err := g.fetch(ctx, &b, metav1.NamespaceAll); err != nil {
+			return nil, fmt.Errorf("failed to build graph: %w", err)
+		}
+	} else {
+		for _, ns := range list {
+			if err := g.fetch(ctx, &b, ns.Name); err != nil {
+				return nil, fmt.Errorf("failed to build graph of namespace %q: %w", ns.Name, err)
+			}
+		}
+	}
+	b.link()
+
+	return graph, nil
+}
+
+// listNamespaces returns the Namespaces of the given names, or every Namespace.
+func (g *GraphAPI) listNamespaces(ctx context.Context, names []string) ([]corev1.Namespace, error) {
+	if len(names) == 0 {
+		list, err := g.namespaces.ListNamespacesByQuery(ctx, api.ListQuery{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build graph: %w", err)
+		}
+		return list, nil
+	}
+
+	list := make([]corev1.Namespace, 0, len(names))
+	for _, name := range names {
+		if err := val.ValidateWithTag(name, "required"); err != nil {
+			return nil, api.NewValidationError("Namespace", "", name, "namespaces", "invalid namespace", err)
+		}
+		ns, err := g.namespaces.GetNamespaceByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build graph of namespace %q: %w", name, err)
+		}
+		list = append(list, *ns)
+	}
+	return list, nil
+}
+
+// fetch adds the objects of namespace, or of every namespace when empty, to b.
+func (g *GraphAPI) fetch(ctx context.Context, b *builder, namespace string) error {
+	deployments, err := g.deployments.ListDeploymentsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return err
+	}
+	for i := range deployments {
+		b.add(KindDeployment, &deployments[i])
+	}
+
+	replicaSets, err := g.replicaSets.ListReplicaSetsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return err
+	}
+	for i := range replicaSets {
+		b.add(KindReplicaSet, &replicaSets[i])
+	}
+
+	pods, err := g.pods.ListPodsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return err
+	}
+	for i := range pods {
+		b.pods[pods[i].Namespace] = append(b.pods[pods[i].Namespace], b.add(KindPod, &pods[i]))
+	}
+
+	services, err := g.services.ListServicesByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return err
+	}
+	for i := range services {
+		id := b.add(KindService, &services[i])
+		b.services = append(b.services, service{id: id, selector: services[i].Spec.Selector})
+	}
+
+	return nil
+}
+
+// builder collects the objects of a graph until every owner is known and the edges
+// between them can be added.
+type builder struct {
+	graph         *Graph
+	clusterScoped map[Kind]bool
+	byUID         map[types.UID]NodeID
+	owned         []owned
+	pods          map[string][]NodeID // by namespace
+	services      []service
+}
+
+// owned is an object of the graph and its owner references.
+type owned struct {
+	id   NodeID
+	refs []metav1.OwnerReference
+}
+
+// service is a Service of the graph and its selector.
+type service struct {
+	id       NodeID
+	selector map[string]string
+}
+
+// add adds the node of obj, of kind, and returns its id.
+func (b *builder) add(kind Kind, obj metav1.Object) NodeID {
+	b.graph.addNode(kind, obj)
+	id := NodeID{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	b.byUID[obj.GetUID()] = id
+	if refs := obj.GetOwnerReferences(); len(refs) > 0 {
+		b.owned = append(b.owned, owned{id: id, refs: refs})
+	}
+	return id
+}
+
+// link adds the ownership and selection edges between the collected objects.
+func (b *builder) link() {
+	for _, o := range b.owned {
+		b.graph.linkOwners(o.id, o.refs, b.byUID, b.clusterScoped)
+	}
+	for _, s := range b.services {
+		b.graph.linkSelected(s.id, s.selector, b.pods[s.id.Namespace])
+	}
+}
@@ -0,0 +1,279 @@
+package graphapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/deployment_api"
+	"github.com/kaudit/api/namespace_api"
+	"github.com/kaudit/api/pod_api"
+	"github.com/kaudit/api/replicaset_api"
+	"github.com/kaudit/api/service_api"
+)
+
+// graphFixture returns objects in "shop" and "other":
+//   - Deployment "web" owns ReplicaSet "web-abc", which owns Pods "web-1" and "web-2"
+//   - ReplicaSet "stale" is owned by a Deployment that no longer exists
+//   - Pod "db-0" is owned by StatefulSet "db", which is not fetched
+//   - Pod "debug" has no owner
+//   - Mirror Pods "static-web" and "static-x" of "other" are owned by Node "node-1"
+//   - Service "web" selects "web-1" and "web-2", Service "db" selects "db-0" and
+//     Service "external" has no selector
+//   - Pod "web-x" of "other" has no owner and matches the selector of "web"
+func graphFixture() []runtime.Object {
+	owner := func(kind, name, uid string) []metav1.OwnerReference {
+		controller := true
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller}}
+	}
+	meta := func(namespace, name, uid string, labels map[string]string, owners []metav1.OwnerReference) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			UID:             types.UID(uid),
+			Labels:          labels,
+			OwnerReferences: owners,
+		}
+	}
+	pod := func(namespace, name, app string, owners []metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: meta(namespace, name, "pod-"+name, map[string]string{"app": app}, owners)}
+	}
+	service := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: meta("shop", name, "svc-"+name, nil, nil),
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}
+	}
+
+	return []runtime.Object{
+		&corev1.Namespace{ObjectMeta: meta("", "shop", "ns-shop", nil, nil)},
+		&corev1.Namespace{ObjectMeta: meta("", "other", "ns-other", nil, nil)},
+		&appsv1.Deployment{ObjectMeta: meta("shop", "web", "deploy-web", nil, nil)},
+		&appsv1.ReplicaSet{ObjectMeta: meta("shop", "web-abc", "rs-web", nil, owner("Deployment", "web", "deploy-web"))},
+		&appsv1.ReplicaSet{ObjectMeta: meta("shop", "stale", "rs-stale", nil, owner("Deployment", "gone", "deploy-gone"))},
+		pod("shop", "web-1", "web", owner("ReplicaSet", "web-abc", "rs-web")),
+		pod("shop", "web-2", "web", owner("ReplicaSet", "web-abc", "rs-web")),
+		pod("shop", "db-0", "db", owner("StatefulSet", "db", "sts-db")),
+		pod("shop", "debug", "debug", nil),
+		pod("shop", "static-web", "static", owner("Node", "node-1", "node-1")),
+		pod("other", "static-x", "static", owner("Node", "node-1", "node-1")),
+		pod("other", "web-x", "web", nil),
+		service("web", map[string]string{"app": "web"}),
+		service("db", map[string]string{"app": "db"}),
+		service("external", nil),
+	}
+}
+
+// newFixtureAPI returns a GraphAPI over the objects of graphFixture, configured by
+// opts, and its client.
+func newFixtureAPI(opts ...Option) (*GraphAPI, *fake.Clientset) {
+	fakeClient := fake.NewClientset(graphFixture()...)
+	return NewGraphAPI(
+		serviceapi.NewServiceAPI(fakeClient),
+		podapi.NewPodAPI(fakeClient),
+		replicasetapi.NewReplicaSetAPI(fakeClient),
+		deploymentapi.NewDeploymentAPI(fakeClient),
+		namespaceapi.NewNamespaceAPI(fakeClient),
+		opts...,
+	), fakeClient
+}
+
+// buildFixture returns the graph of every namespace of graphFixture.
+func buildFixture(t *testing.T) *Graph {
+	t.Helper()
+	graphAPI, _ := newFixtureAPI()
+	graph, err := graphAPI.Build(context.Background())
+	require.NoError(t, err)
+	return graph
+}
+
+// ids returns the ids of nodes.
+func ids(nodes []Node) []NodeID {
+	var result []NodeID
+	for _, n := range nodes {
+		result = append(result, n.ID)
+	}
+	return result
+}
+
+func TestGraphAPI_Build(t *testing.T) {
+	graphAPI, _ := newFixtureAPI()
+
+	tests := []struct {
+		name          string
+		namespaces    []string
+		expected      []NodeID
+		wantErr       bool
+		errorContains string
+		category      error
+	}{
+		{
+			name:       "Every namespace",
+			namespaces: nil,
+			expected: []NodeID{
+				{Kind: KindNamespace, Name: "other"},
+				{Kind: KindNamespace, Name: "shop"},
+				{Kind: "Node", Name: "node-1"},
+				{Kind: KindPod, Namespace: "other", Name: "static-x"},
+				{Kind: KindPod, Namespace: "other", Name: "web-x"},
+				{Kind: KindDeployment, Namespace: "shop", Name: "web"},
+				{Kind: KindPod, Namespace: "shop", Name: "db-0"},
+				{Kind: KindPod, Namespace: "shop", Name: "debug"},
+				{Kind: KindPod, Namespace: "shop", Name: "static-web"},
+				{Kind: KindPod, Namespace: "shop", Name: "web-1"},
+				{Kind: KindPod, Namespace: "shop", Name: "web-2"},
+				{Kind: KindReplicaSet, Namespace: "shop", Name: "stale"},
+				{Kind: KindReplicaSet, Namespace: "shop", Name: "web-abc"},
+				{Kind: KindService, Namespace: "shop", Name: "db"},
+				{Kind: KindService, Namespace: "shop", Name: "external"},
+				{Kind: KindService, Namespace: "shop", Name: "web"},
+				{Kind: "StatefulSet", Namespace: "shop", Name: "db"},
+			},
+		},
+		{
+			name:       "Given namespace",
+			namespaces: []string{"other"},
+			expected: []NodeID{
+				{Kind: KindNamespace, Name: "other"},
+				{Kind: "Node", Name: "node-1"},
+				{Kind: KindPod, Namespace: "other", Name: "static-x"},
+				{Kind: KindPod, Namespace: "other", Name: "web-x"},
+			},
+		},
+		{
+			name:          "Missing namespace",
+			namespaces:    []string{"shop", "missing"},
+			wantErr:       true,
+			errorContains: `failed to build graph of namespace "missing"`,
+			category:      api.ErrNotFound,
+		},
+		{
+			name:          "Empty namespace",
+			namespaces:    []string{""},
+			wantErr:       true,
+			errorContains: "invalid namespace",
+			category:      api.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := graphAPI.Build(context.Background(), tt.namespaces...)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.category)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, graph)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(graph.Nodes()))
+		})
+	}
+}
+
+func TestGraphAPI_Build_Requests(t *testing.T) {
+	graphAPI, fakeClient := newFixtureAPI()
+
+	// Without namespaces, each kind is listed once across the cluster
+	_, err := graphAPI.Build(context.Background())
+	require.NoError(t, err)
+	var requests []string
+	for _, action := range fakeClient.Actions() {
+		requests = append(requests, action.GetVerb()+" "+action.GetResource().Resource+" "+action.GetNamespace())
+	}
+	assert.Equal(t, []string{
+		"list namespaces ",
+		"list deployments ",
+		"list replicasets ",
+		"list pods ",
+		"list services ",
+	}, requests)
+
+	// With namespaces, each kind is listed once per namespace
+	fakeClient.ClearActions()
+	_, err = graphAPI.Build(context.Background(), "shop", "other")
+	require.NoError(t, err)
+	assert.Len(t, fakeClient.Actions(), 2+2*4)
+}
+
+func TestGraphAPI_Build_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	graphAPI, _ := newFixtureAPI(WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	graph, err := graphAPI.Build(context.Background(), "shop")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), fmt.Sprintf(`level=DEBUG msg="built graph" namespaces=[shop] nodes=%d edges=%d duration=`, len(graph.Nodes()), len(graph.Edges())))
+
+	_, err = graphAPI.Build(context.Background(), "missing")
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), `level=WARN msg="failed to build graph" namespaces=[missing] duration=`)
+}
+
+func TestGraphAPI_Build_Nodes(t *testing.T) {
+	graph := buildFixture(t)
+
+	pod, ok := graph.Node(NodeID{Kind: KindPod, Namespace: "shop", Name: "web-1"})
+	require.True(t, ok)
+	assert.Equal(t, types.UID("pod-web-1"), pod.UID)
+	assert.Equal(t, map[string]string{"app": "web"}, pod.Labels)
+	assert.False(t, pod.Stub)
+
+	stub, ok := graph.Node(NodeID{Kind: "StatefulSet", Namespace: "shop", Name: "db"})
+	require.True(t, ok)
+	assert.Equal(t, types.UID("sts-db"), stub.UID)
+	assert.True(t, stub.Stub)
+
+	_, ok = graph.Node(NodeID{Kind: KindDeployment, Namespace: "shop", Name: "gone"})
+	assert.False(t, ok)
+}
+
+func TestGraphAPI_Build_ClusterScopedOwners(t *testing.T) {
+	owner := func(kind, name, uid string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid)}}
+	}
+	fakeClient := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "a", OwnerReferences: owner("Tenant", "acme", "tenant-acme")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "b", OwnerReferences: owner("Tenant", "acme", "tenant-acme")}},
+	)
+	newAPI := func(opts ...Option) *GraphAPI {
+		return NewGraphAPI(
+			serviceapi.NewServiceAPI(fakeClient),
+			podapi.NewPodAPI(fakeClient),
+			replicasetapi.NewReplicaSetAPI(fakeClient),
+			deploymentapi.NewDeploymentAPI(fakeClient),
+			namespaceapi.NewNamespaceAPI(fakeClient),
+			opts...,
+		)
+	}
+	podA := NodeID{Kind: KindPod, Namespace: "team-a", Name: "a"}
+	podB := NodeID{Kind: KindPod, Namespace: "team-b", Name: "b"}
+
+	// The scope of a custom kind is unknown, its stub is placed in the namespace of the
+	// first dependent found
+	graph, err := newAPI().Build(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []NodeID{{Kind: "Tenant", Namespace: "team-a", Name: "acme"}, {Kind: KindNamespace, Name: "team-a"}}, ids(graph.Owners(podA)))
+
+	graph, err = newAPI(WithClusterScopedKinds("Tenant")).Build(context.Background())
+	require.NoError(t, err)
+	tenant := NodeID{Kind: "Tenant", Name: "acme"}
+	assert.Equal(t, []NodeID{tenant, {Kind: KindNamespace, Name: "team-a"}}, ids(graph.Owners(podA)))
+	assert.Equal(t, []NodeID{tenant, {Kind: KindNamespace, Name: "team-b"}}, ids(graph.Owners(podB)))
+	assert.Equal(t, []NodeID{podA, podB}, ids(graph.Dependents(tenant)))
+}
@@ -0,0 +1,147 @@
+package graphapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	shop      = NodeID{Kind: KindNamespace, Name: "shop"}
+	other     = NodeID{Kind: KindNamespace, Name: "other"}
+	webDeploy = NodeID{Kind: KindDeployment, Namespace: "shop", Name: "web"}
+	webRS     = NodeID{Kind: KindReplicaSet, Namespace: "shop", Name: "web-abc"}
+	staleRS   = NodeID{Kind: KindReplicaSet, Namespace: "shop", Name: "stale"}
+	web1      = NodeID{Kind: KindPod, Namespace: "shop", Name: "web-1"}
+	web2      = NodeID{Kind: KindPod, Namespace: "shop", Name: "web-2"}
+	db0       = NodeID{Kind: KindPod, Namespace: "shop", Name: "db-0"}
+	debug     = NodeID{Kind: KindPod, Namespace: "shop", Name: "debug"}
+	staticWeb = NodeID{Kind: KindPod, Namespace: "shop", Name: "static-web"}
+	staticX   = NodeID{Kind: KindPod, Namespace: "other", Name: "static-x"}
+	node1     = NodeID{Kind: "Node", Name: "node-1"}
+	webX      = NodeID{Kind: KindPod, Namespace: "other", Name: "web-x"}
+	dbSTS     = NodeID{Kind: "StatefulSet", Namespace: "shop", Name: "db"}
+	webSvc    = NodeID{Kind: KindService, Namespace: "shop", Name: "web"}
+	dbSvc     = NodeID{Kind: KindService, Namespace: "shop", Name: "db"}
+	extSvc    = NodeID{Kind: KindService, Namespace: "shop", Name: "external"}
+	missing   = NodeID{Kind: KindPod, Namespace: "shop", Name: "missing"}
+)
+
+func TestNodeID_String(t *testing.T) {
+	assert.Equal(t, "Pod/shop/web-1", web1.String())
+	assert.Equal(t, "Namespace/shop", shop.String())
+}
+
+func TestGraph_Owners(t *testing.T) {
+	graph := buildFixture(t)
+
+	tests := []struct {
+		name     string
+		id       NodeID
+		expected []NodeID
+	}{
+		{name: "Pod of a Deployment", id: web1, expected: []NodeID{webRS, webDeploy, shop}},
+		{name: "Pod of a StatefulSet", id: db0, expected: []NodeID{dbSTS, shop}},
+		{name: "ReplicaSet of a deleted Deployment", id: staleRS, expected: []NodeID{shop}},
+		{name: "Mirror Pod of a Node", id: staticWeb, expected: []NodeID{node1, shop}},
+		{name: "Stub Node", id: node1, expected: nil},
+		{name: "Bare Pod", id: webX, expected: []NodeID{other}},
+		{name: "Namespace", id: shop, expected: nil},
+		{name: "Missing node", id: missing, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(graph.Owners(tt.id)))
+		})
+	}
+}
+
+func TestGraph_Dependents(t *testing.T) {
+	graph := buildFixture(t)
+
+	tests := []struct {
+		name     string
+		id       NodeID
+		expected []NodeID
+	}{
+		{name: "Deployment", id: webDeploy, expected: []NodeID{webRS, web1, web2}},
+		{name: "ReplicaSet", id: webRS, expected: []NodeID{web1, web2}},
+		{name: "Stub StatefulSet", id: dbSTS, expected: []NodeID{db0}},
+		{name: "Stub Node across namespaces", id: node1, expected: []NodeID{staticX, staticWeb}},
+		{name: "Namespace", id: other, expected: []NodeID{staticX, webX}},
+		{name: "Pod", id: web1, expected: nil},
+		{name: "Missing node", id: missing, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(graph.Dependents(tt.id)))
+		})
+	}
+}
+
+func TestGraph_Dependents_Namespace(t *testing.T) {
+	graph := buildFixture(t)
+
+	// Every object of the namespace is a dependent, stubs included.
+	dependents := ids(graph.Dependents(shop))
+	assert.Len(t, dependents, 12)
+	assert.Contains(t, dependents, dbSTS)
+	assert.NotContains(t, dependents, webX)
+	assert.NotContains(t, dependents, node1)
+}
+
+func TestGraph_Selected(t *testing.T) {
+	graph := buildFixture(t)
+
+	tests := []struct {
+		name     string
+		id       NodeID
+		expected []NodeID
+	}{
+		{name: "Service in the namespace of its Pods only", id: webSvc, expected: []NodeID{web1, web2}},
+		{name: "Service of a StatefulSet", id: dbSvc, expected: []NodeID{db0}},
+		{name: "Service without selector", id: extSvc, expected: nil},
+		{name: "Missing node", id: missing, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ids(graph.Selected(tt.id)))
+		})
+	}
+}
+
+func TestGraph_SelectedBy(t *testing.T) {
+	graph := buildFixture(t)
+
+	assert.Equal(t, []NodeID{webSvc}, ids(graph.SelectedBy(web2)))
+	assert.Equal(t, []NodeID{dbSvc}, ids(graph.SelectedBy(db0)))
+	assert.Empty(t, graph.SelectedBy(webX))
+	assert.Empty(t, graph.SelectedBy(debug))
+}
+
+func TestGraph_Orphans(t *testing.T) {
+	graph := buildFixture(t)
+
+	assert.Equal(t, []NodeID{webX, debug, staleRS, extSvc}, ids(graph.Orphans()))
+}
+
+func TestGraph_Edges(t *testing.T) {
+	graph := buildFixture(t)
+
+	edges := graph.Edges()
+	assert.Contains(t, edges, Edge{From: webDeploy, To: webRS, Type: EdgeOwns})
+	assert.Contains(t, edges, Edge{From: webRS, To: web1, Type: EdgeOwns})
+	assert.Contains(t, edges, Edge{From: dbSTS, To: db0, Type: EdgeOwns})
+	assert.Contains(t, edges, Edge{From: webSvc, To: web2, Type: EdgeSelects})
+	assert.Contains(t, edges, Edge{From: shop, To: dbSTS, Type: EdgeContains})
+	assert.Contains(t, edges, Edge{From: node1, To: staticX, Type: EdgeOwns})
+	assert.NotContains(t, edges, Edge{From: webSvc, To: webX, Type: EdgeSelects})
+	// 14 objects contained in their namespace, 6 ownership and 3 selection edges.
+	assert.Len(t, edges, 23)
+	for _, e := range edges {
+		assert.NotEqual(t, node1, e.To, "cluster-scoped stub has an incoming %s edge", e.Type)
+	}
+}
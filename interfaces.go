@@ -2,20 +2,39 @@ package api
 
 import (
 	"context"
+	"iter"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // DeploymentAPI defines an interface for interacting with Kubernetes Deployments.
 // This interface provides a simplified abstraction over the Kubernetes client-go library
 // for common Deployment operations. It allows retrieving individual Deployments by name
 // and listing Deployments by either label selectors or field selectors, all within the
-// context of a specific namespace.
+// context of a specific namespace. The Paged variants follow continue tokens so large
+// result sets, in one namespace or in all of them when it is empty, can be consumed
+// page by page, and the Watch variants stream changes. The AllNamespaces variants list
+// the Deployments of the namespaces matched by a NamespaceFilter.
 type DeploymentAPI interface {
 	GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error)
 	ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.Deployment, error)
 	ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.Deployment, error)
+	ListDeploymentsByQuery(ctx context.Context, namespace string, query ListQuery) ([]appsv1.Deployment, error)
+	ListDeploymentsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]appsv1.Deployment, error)
+	ListDeploymentsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]appsv1.Deployment, error)
+	ListDeploymentsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error]
+	ListDeploymentsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error]
+	WatchDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan WatchEvent[*appsv1.Deployment], error)
+	WatchDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan WatchEvent[*appsv1.Deployment], error)
 }
 
 // NamespaceAPI defines an interface for interacting with Kubernetes Namespaces.
@@ -23,11 +42,18 @@ type DeploymentAPI interface {
 // providing methods to retrieve individual Namespaces by name and to list Namespaces
 // matching certain criteria using label or field selectors. Unlike other resources,
 // Namespaces are cluster-wide objects and don't exist within other namespaces,
-// so no namespace parameter is required for listing operations.
+// so no namespace parameter is required for listing operations. The Paged variants
+// follow continue tokens so large result sets can be consumed page by page, and
+// the Watch variants stream changes.
 type NamespaceAPI interface {
 	GetNamespaceByName(ctx context.Context, name string) (*corev1.Namespace, error)
 	ListNamespacesByLabel(ctx context.Context, labelSelector string) ([]corev1.Namespace, error)
 	ListNamespacesByField(ctx context.Context, fieldSelector string) ([]corev1.Namespace, error)
+	ListNamespacesByQuery(ctx context.Context, query ListQuery) ([]corev1.Namespace, error)
+	ListNamespacesByLabelPaged(ctx context.Context, labelSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error]
+	ListNamespacesByFieldPaged(ctx context.Context, fieldSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error]
+	WatchNamespacesByLabel(ctx context.Context, labelSelector string) (<-chan WatchEvent[*corev1.Namespace], error)
+	WatchNamespacesByField(ctx context.Context, fieldSelector string) (<-chan WatchEvent[*corev1.Namespace], error)
 }
 
 // ServiceAPI defines an interface for interacting with Kubernetes Services.
@@ -36,10 +62,21 @@ type NamespaceAPI interface {
 // their name within a specific namespace, as well as list Services that match
 // particular label or field selectors. Services provide network access to sets of Pods,
 // and this interface helps abstract the details of how these Services are queried.
+// The Paged variants follow continue tokens so large result sets, in one namespace or
+// in all of them when it is empty, can be consumed page by page, and the Watch variants
+// stream changes. The AllNamespaces variants list the Services of the namespaces
+// matched by a NamespaceFilter.
 type ServiceAPI interface {
 	GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error)
 	ListServicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Service, error)
 	ListServicesByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Service, error)
+	ListServicesByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.Service, error)
+	ListServicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]corev1.Service, error)
+	ListServicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]corev1.Service, error)
+	ListServicesByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Service, error]
+	ListServicesByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Service, error]
+	WatchServicesByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan WatchEvent[*corev1.Service], error)
+	WatchServicesByField(ctx context.Context, namespace string, fieldSelector string) (<-chan WatchEvent[*corev1.Service], error)
 }
 
 // PodAPI defines an interface for interacting with Kubernetes Pods.
@@ -48,9 +85,316 @@ type ServiceAPI interface {
 // methods to retrieve individual Pods by name within a namespace, and to list
 // Pods that match specific criteria using label or field selectors. Pods
 // represent containers running on your cluster, and this interface simplifies
-// interaction with them.
+// interaction with them. The Paged variants follow continue tokens so large result
+// sets, such as cluster-wide pod listings with an empty namespace, can be consumed
+// page by page, and the Watch variants stream changes. The AllNamespaces variants list
+// the Pods of the namespaces matched by a NamespaceFilter.
 type PodAPI interface {
 	GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error)
 	ListPodsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error)
 	ListPodsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Pod, error)
+	ListPodsByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.Pod, error)
+	ListPodsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]corev1.Pod, error)
+	ListPodsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]corev1.Pod, error)
+	ListPodsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Pod, error]
+	ListPodsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Pod, error]
+	WatchPodsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan WatchEvent[*corev1.Pod], error)
+	WatchPodsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan WatchEvent[*corev1.Pod], error)
+}
+
+// StatefulSetAPI defines an interface for interacting with Kubernetes StatefulSets.
+// StatefulSets manage stateful workloads with stable network identities and persistent
+// storage. This interface provides methods to retrieve individual StatefulSets by name
+// and to list StatefulSets by label or field selectors within a specific namespace, or
+// in every namespace matched by a NamespaceFilter.
+type StatefulSetAPI interface {
+	GetStatefulSetByName(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error)
+	ListStatefulSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.StatefulSet, error)
+	ListStatefulSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.StatefulSet, error)
+	ListStatefulSetsByQuery(ctx context.Context, namespace string, query ListQuery) ([]appsv1.StatefulSet, error)
+	ListStatefulSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]appsv1.StatefulSet, error)
+	ListStatefulSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]appsv1.StatefulSet, error)
+}
+
+// DaemonSetAPI defines an interface for interacting with Kubernetes DaemonSets.
+// DaemonSets run a copy of a Pod on every eligible node, which makes them the usual
+// vehicle for node agents. This interface provides methods to retrieve individual
+// DaemonSets by name and to list DaemonSets by label or field selectors within a
+// specific namespace, or in every namespace matched by a NamespaceFilter.
+type DaemonSetAPI interface {
+	GetDaemonSetByName(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error)
+	ListDaemonSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.DaemonSet, error)
+	ListDaemonSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.DaemonSet, error)
+	ListDaemonSetsByQuery(ctx context.Context, namespace string, query ListQuery) ([]appsv1.DaemonSet, error)
+	ListDaemonSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]appsv1.DaemonSet, error)
+	ListDaemonSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]appsv1.DaemonSet, error)
+}
+
+// ReplicaSetAPI defines an interface for interacting with Kubernetes ReplicaSets.
+// ReplicaSets keep a stable set of replica Pods running and are normally owned by
+// Deployments. This interface provides methods to retrieve individual ReplicaSets by
+// name and to list ReplicaSets by label or field selectors within a specific namespace,
+// or in every namespace matched by a NamespaceFilter.
+type ReplicaSetAPI interface {
+	GetReplicaSetByName(ctx context.Context, namespace, name string) (*appsv1.ReplicaSet, error)
+	ListReplicaSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.ReplicaSet, error)
+	ListReplicaSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.ReplicaSet, error)
+	ListReplicaSetsByQuery(ctx context.Context, namespace string, query ListQuery) ([]appsv1.ReplicaSet, error)
+	ListReplicaSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]appsv1.ReplicaSet, error)
+	ListReplicaSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]appsv1.ReplicaSet, error)
+}
+
+// JobAPI defines an interface for interacting with Kubernetes Jobs.
+// Jobs run Pods to completion, either directly or on behalf of a CronJob. This
+// interface provides methods to retrieve individual Jobs by name, to list Jobs by
+// label or field selectors within a specific namespace or in every namespace matched by
+// a NamespaceFilter, and to list the Pods a Job created.
+type JobAPI interface {
+	GetJobByName(ctx context.Context, namespace, name string) (*batchv1.Job, error)
+	ListJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.Job, error)
+	ListJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.Job, error)
+	ListJobsByQuery(ctx context.Context, namespace string, query ListQuery) ([]batchv1.Job, error)
+	ListJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]batchv1.Job, error)
+	ListJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]batchv1.Job, error)
+	ListPodsForJob(ctx context.Context, namespace, name string) ([]corev1.Pod, error)
+}
+
+// CronJobAPI defines an interface for interacting with Kubernetes CronJobs.
+// CronJobs create Jobs on a repeating schedule. Besides retrieving CronJobs by name
+// and listing them by label or field selectors, in one namespace or in every namespace
+// matched by a NamespaceFilter, this interface resolves the Jobs a CronJob owns and the
+// run history built from those Jobs and their Pods.
+type CronJobAPI interface {
+	GetCronJobByName(ctx context.Context, namespace, name string) (*batchv1.CronJob, error)
+	ListCronJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.CronJob, error)
+	ListCronJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.CronJob, error)
+	ListCronJobsByQuery(ctx context.Context, namespace string, query ListQuery) ([]batchv1.CronJob, error)
+	ListCronJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]batchv1.CronJob, error)
+	ListCronJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]batchv1.CronJob, error)
+	ListJobsForCronJob(ctx context.Context, namespace, name string) ([]batchv1.Job, error)
+	ListRunsForCronJob(ctx context.Context, namespace, name string) ([]JobRun, error)
+}
+
+// ConfigMapAPI defines an interface for interacting with Kubernetes ConfigMaps.
+// ConfigMaps hold non-confidential configuration consumed by Pods as environment
+// variables, command-line arguments or mounted files. This interface provides methods
+// to retrieve individual ConfigMaps by name and to list ConfigMaps by label or field
+// selectors within a specific namespace, or in every namespace matched by a
+// NamespaceFilter.
+type ConfigMapAPI interface {
+	GetConfigMapByName(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error)
+	ListConfigMapsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ConfigMap, error)
+	ListConfigMapsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ConfigMap, error)
+	ListConfigMapsByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.ConfigMap, error)
+	ListConfigMapsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]corev1.ConfigMap, error)
+	ListConfigMapsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]corev1.ConfigMap, error)
+}
+
+// SecretAPI defines an interface for auditing Kubernetes Secrets without access to
+// their values. Secrets are returned as SecretMetadata, which carries the object
+// metadata alone; implementations request nothing more from the apiserver.
+// This interface provides methods to retrieve individual Secrets by name and to list
+// Secrets by label or field selectors within a specific namespace, or in every namespace
+// matched by a NamespaceFilter.
+type SecretAPI interface {
+	GetSecretByName(ctx context.Context, namespace, name string) (*SecretMetadata, error)
+	ListSecretsByLabel(ctx context.Context, namespace string, labelSelector string) ([]SecretMetadata, error)
+	ListSecretsByField(ctx context.Context, namespace string, fieldSelector string) ([]SecretMetadata, error)
+	ListSecretsByQuery(ctx context.Context, namespace string, query ListQuery) ([]SecretMetadata, error)
+	ListSecretsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]SecretMetadata, error)
+	ListSecretsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]SecretMetadata, error)
+}
+
+// RBACAPI defines an interface for inspecting Kubernetes RBAC objects: Roles,
+// ClusterRoles, RoleBindings and ClusterRoleBindings. Besides retrieving each kind by
+// name and listing it by label or field selectors, it answers audit questions that
+// span kinds — the subjects bound to a ClusterRole, the roles granting a verb on a
+// resource and the rules effectively granted to a ServiceAccount — with aggregated
+// ClusterRoles resolved. Roles and RoleBindings can also be listed in every namespace
+// matched by a NamespaceFilter.
+type RBACAPI interface {
+	GetRoleByName(ctx context.Context, namespace, name string) (*rbacv1.Role, error)
+	ListRolesByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.Role, error)
+	ListRolesByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.Role, error)
+	ListRolesByQuery(ctx context.Context, namespace string, query ListQuery) ([]rbacv1.Role, error)
+	ListRolesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]rbacv1.Role, error)
+	ListRolesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]rbacv1.Role, error)
+
+	GetClusterRoleByName(ctx context.Context, name string) (*rbacv1.ClusterRole, error)
+	ListClusterRolesByLabel(ctx context.Context, labelSelector string) ([]rbacv1.ClusterRole, error)
+	ListClusterRolesByField(ctx context.Context, fieldSelector string) ([]rbacv1.ClusterRole, error)
+	ListClusterRolesByQuery(ctx context.Context, query ListQuery) ([]rbacv1.ClusterRole, error)
+
+	GetRoleBindingByName(ctx context.Context, namespace, name string) (*rbacv1.RoleBinding, error)
+	ListRoleBindingsByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.RoleBinding, error)
+	ListRoleBindingsByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.RoleBinding, error)
+	ListRoleBindingsByQuery(ctx context.Context, namespace string, query ListQuery) ([]rbacv1.RoleBinding, error)
+	ListRoleBindingsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]rbacv1.RoleBinding, error)
+	ListRoleBindingsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]rbacv1.RoleBinding, error)
+
+	GetClusterRoleBindingByName(ctx context.Context, name string) (*rbacv1.ClusterRoleBinding, error)
+	ListClusterRoleBindingsByLabel(ctx context.Context, labelSelector string) ([]rbacv1.ClusterRoleBinding, error)
+	ListClusterRoleBindingsByField(ctx context.Context, fieldSelector string) ([]rbacv1.ClusterRoleBinding, error)
+	ListClusterRoleBindingsByQuery(ctx context.Context, query ListQuery) ([]rbacv1.ClusterRoleBinding, error)
+
+	ListSubjectsForClusterRole(ctx context.Context, name string) ([]BoundSubject, error)
+	ListRolesGranting(ctx context.Context, verb, resource string) ([]RoleGrant, error)
+	ListEffectiveRulesForServiceAccount(ctx context.Context, namespace, name string) ([]EffectiveRule, error)
+}
+
+// ServiceAccountAPI defines an interface for interacting with Kubernetes
+// ServiceAccounts, the identities Pods authenticate to the API server with. Besides
+// retrieving ServiceAccounts by name and listing them by label or field selectors, it
+// reports which Pods run as each ServiceAccount of a namespace and whether they have an
+// API token mounted, which also reveals unused and missing ServiceAccounts. The
+// AllNamespaces variants list the ServiceAccounts of every namespace matched by a
+// NamespaceFilter.
+type ServiceAccountAPI interface {
+	GetServiceAccountByName(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error)
+	ListServiceAccountsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ServiceAccount, error)
+	ListServiceAccountsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ServiceAccount, error)
+	ListServiceAccountsByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.ServiceAccount, error)
+	ListServiceAccountsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]corev1.ServiceAccount, error)
+	ListServiceAccountsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]corev1.ServiceAccount, error)
+	ListServiceAccountUsage(ctx context.Context, namespace string) ([]ServiceAccountUsage, error)
+}
+
+// NodeAPI defines an interface for interacting with Kubernetes Nodes.
+// Nodes are cluster-scoped and are the machines Pods are scheduled on. Besides
+// retrieving Nodes by name and listing them by label or field selectors, it lists the
+// Nodes that are not ready or carry a given taint, and the Pods scheduled on a Node.
+// Helpers for conditions, taints, allocatable resources and kubelet version skew of a
+// single Node are provided by the node_api package.
+type NodeAPI interface {
+	GetNodeByName(ctx context.Context, name string) (*corev1.Node, error)
+	ListNodesByLabel(ctx context.Context, labelSelector string) ([]corev1.Node, error)
+	ListNodesByField(ctx context.Context, fieldSelector string) ([]corev1.Node, error)
+	ListNodesByQuery(ctx context.Context, query ListQuery) ([]corev1.Node, error)
+	ListNotReadyNodes(ctx context.Context) ([]corev1.Node, error)
+	ListNodesWithTaint(ctx context.Context, key string, effect corev1.TaintEffect) ([]corev1.Node, error)
+	ListPodsOnNode(ctx context.Context, name string) ([]corev1.Pod, error)
+}
+
+// EventAPI defines an interface for retrieving Kubernetes Events, the records the
+// control plane emits about state changes and failures of other objects. Events are
+// read through events.k8s.io/v1, or through core/v1 with ListCoreEvents, and can be
+// filtered by the object they relate to, their reason and their type. Convenience
+// methods accept the objects returned by PodAPI and DeploymentAPI directly. The
+// AllNamespaces variants list the Events of every namespace matched by a
+// NamespaceFilter.
+type EventAPI interface {
+	GetEventByName(ctx context.Context, namespace, name string) (*eventsv1.Event, error)
+	ListEventsByLabel(ctx context.Context, namespace string, labelSelector string) ([]eventsv1.Event, error)
+	ListEventsByField(ctx context.Context, namespace string, fieldSelector string) ([]eventsv1.Event, error)
+	ListEventsByQuery(ctx context.Context, namespace string, query ListQuery) ([]eventsv1.Event, error)
+	ListEventsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]eventsv1.Event, error)
+	ListEventsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]eventsv1.Event, error)
+	ListEvents(ctx context.Context, filter EventFilter) ([]eventsv1.Event, error)
+	ListCoreEvents(ctx context.Context, filter EventFilter) ([]corev1.Event, error)
+	ListEventsForPod(ctx context.Context, pod *corev1.Pod) ([]eventsv1.Event, error)
+	ListEventsForDeployment(ctx context.Context, deployment *appsv1.Deployment) ([]eventsv1.Event, error)
+}
+
+// NetworkingAPI defines an interface for interacting with networking.k8s.io/v1
+// resources that govern network exposure: Ingresses and the IngressClasses that
+// implement them, and the NetworkPolicies restricting traffic between Pods. It provides
+// methods to retrieve each kind by name and to list it by label or field selectors;
+// IngressClasses are cluster-scoped. The AllNamespaces variants list Ingresses and
+// NetworkPolicies in every namespace matched by a NamespaceFilter.
+type NetworkingAPI interface {
+	GetIngressByName(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error)
+	ListIngressesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.Ingress, error)
+	ListIngressesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.Ingress, error)
+	ListIngressesByQuery(ctx context.Context, namespace string, query ListQuery) ([]networkingv1.Ingress, error)
+	ListIngressesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]networkingv1.Ingress, error)
+	ListIngressesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]networkingv1.Ingress, error)
+
+	GetIngressClassByName(ctx context.Context, name string) (*networkingv1.IngressClass, error)
+	ListIngressClassesByLabel(ctx context.Context, labelSelector string) ([]networkingv1.IngressClass, error)
+	ListIngressClassesByField(ctx context.Context, fieldSelector string) ([]networkingv1.IngressClass, error)
+	ListIngressClassesByQuery(ctx context.Context, query ListQuery) ([]networkingv1.IngressClass, error)
+
+	GetNetworkPolicyByName(ctx context.Context, namespace, name string) (*networkingv1.NetworkPolicy, error)
+	ListNetworkPoliciesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.NetworkPolicy, error)
+	ListNetworkPoliciesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.NetworkPolicy, error)
+	ListNetworkPoliciesByQuery(ctx context.Context, namespace string, query ListQuery) ([]networkingv1.NetworkPolicy, error)
+	ListNetworkPoliciesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]networkingv1.NetworkPolicy, error)
+	ListNetworkPoliciesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]networkingv1.NetworkPolicy, error)
+}
+
+// EndpointSliceAPI defines an interface for interacting with discovery.k8s.io/v1
+// EndpointSlices, which record the network endpoints backing a Service. This interface
+// provides methods to retrieve individual EndpointSlices by name and to list them by
+// label or field selectors within a specific namespace; the kubernetes.io/service-name
+// label selects the slices of a given Service. The AllNamespaces variants list the
+// slices of every namespace matched by a NamespaceFilter.
+type EndpointSliceAPI interface {
+	GetEndpointSliceByName(ctx context.Context, namespace, name string) (*discoveryv1.EndpointSlice, error)
+	ListEndpointSlicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]discoveryv1.EndpointSlice, error)
+	ListEndpointSlicesByField(ctx context.Context, namespace string, fieldSelector string) ([]discoveryv1.EndpointSlice, error)
+	ListEndpointSlicesByQuery(ctx context.Context, namespace string, query ListQuery) ([]discoveryv1.EndpointSlice, error)
+	ListEndpointSlicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]discoveryv1.EndpointSlice, error)
+	ListEndpointSlicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]discoveryv1.EndpointSlice, error)
+}
+
+// StorageAPI defines an interface for interacting with the resources that provide
+// persistent storage: PersistentVolumes and the PersistentVolumeClaims bound to them,
+// the StorageClasses provisioning them and the VolumeAttachments attaching them to
+// Nodes. It provides methods to retrieve each kind by name and to list it by label or
+// field selectors; all kinds but PersistentVolumeClaims are cluster-scoped. It also
+// resolves the volume bound to a claim and the Pods mounting it. The AllNamespaces
+// variants list the claims of every namespace matched by a NamespaceFilter.
+type StorageAPI interface {
+	GetPersistentVolumeByName(ctx context.Context, name string) (*corev1.PersistentVolume, error)
+	ListPersistentVolumesByLabel(ctx context.Context, labelSelector string) ([]corev1.PersistentVolume, error)
+	ListPersistentVolumesByField(ctx context.Context, fieldSelector string) ([]corev1.PersistentVolume, error)
+	ListPersistentVolumesByQuery(ctx context.Context, query ListQuery) ([]corev1.PersistentVolume, error)
+
+	GetPersistentVolumeClaimByName(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error)
+	ListPersistentVolumeClaimsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.PersistentVolumeClaim, error)
+	ListPersistentVolumeClaimsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.PersistentVolumeClaim, error)
+	ListPersistentVolumeClaimsByQuery(ctx context.Context, namespace string, query ListQuery) ([]corev1.PersistentVolumeClaim, error)
+	ListPersistentVolumeClaimsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *NamespaceFilter) ([]corev1.PersistentVolumeClaim, error)
+	ListPersistentVolumeClaimsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *NamespaceFilter) ([]corev1.PersistentVolumeClaim, error)
+
+	GetStorageClassByName(ctx context.Context, name string) (*storagev1.StorageClass, error)
+	ListStorageClassesByLabel(ctx context.Context, labelSelector string) ([]storagev1.StorageClass, error)
+	ListStorageClassesByField(ctx context.Context, fieldSelector string) ([]storagev1.StorageClass, error)
+	ListStorageClassesByQuery(ctx context.Context, query ListQuery) ([]storagev1.StorageClass, error)
+
+	GetVolumeAttachmentByName(ctx context.Context, name string) (*storagev1.VolumeAttachment, error)
+	ListVolumeAttachmentsByLabel(ctx context.Context, labelSelector string) ([]storagev1.VolumeAttachment, error)
+	ListVolumeAttachmentsByField(ctx context.Context, fieldSelector string) ([]storagev1.VolumeAttachment, error)
+	ListVolumeAttachmentsByQuery(ctx context.Context, query ListQuery) ([]storagev1.VolumeAttachment, error)
+
+	GetVolumeForClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolume, error)
+	ListPodsForClaim(ctx context.Context, namespace, name string) ([]corev1.Pod, error)
+	ListClaimBindings(ctx context.Context, namespace string) ([]ClaimBinding, error)
+}
+
+// CustomResourceAPI defines an interface for interacting with resources that have no
+// typed client, such as those defined by CustomResourceDefinitions. Resources are
+// addressed by their GroupVersionResource and returned as unstructured objects; an
+// empty namespace addresses cluster-scoped resources, or every namespace when listing.
+// The AllNamespaces variants list the resources of the namespaces matched by a
+// NamespaceFilter. The customresourceapi package decodes the results into typed structs.
+type CustomResourceAPI interface {
+	GetCustomResourceByName(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	ListCustomResourcesByLabel(ctx context.Context, gvr schema.GroupVersionResource, namespace string, labelSelector string) ([]unstructured.Unstructured, error)
+	ListCustomResourcesByField(ctx context.Context, gvr schema.GroupVersionResource, namespace string, fieldSelector string) ([]unstructured.Unstructured, error)
+	ListCustomResourcesByQuery(ctx context.Context, gvr schema.GroupVersionResource, namespace string, query ListQuery) ([]unstructured.Unstructured, error)
+	ListCustomResourcesByLabelAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, labelSelector string, namespaces *NamespaceFilter) ([]unstructured.Unstructured, error)
+	ListCustomResourcesByFieldAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, fieldSelector string, namespaces *NamespaceFilter) ([]unstructured.Unstructured, error)
+}
+
+// DiscoveryAPI defines an interface for enumerating the API groups, versions and
+// resources served by the cluster, including those of CustomResourceDefinitions and
+// aggregated API servers. It reports which version of each group is preferred, whether
+// a given resource is served, and which served resources are deprecated or removed as
+// of a Kubernetes version according to a built-in table.
+type DiscoveryAPI interface {
+	ListServerResources(ctx context.Context) ([]APIResource, error)
+	ListPreferredResources(ctx context.Context) ([]APIResource, error)
+	IsServed(ctx context.Context, gvr schema.GroupVersionResource) (bool, error)
+	ListDeprecatedResources(ctx context.Context, targetVersion string) ([]DeprecatedResource, error)
 }
@@ -0,0 +1,1608 @@
+package intercept
+
+import (
+	"context"
+	"iter"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kaudit/api"
+)
+
+// deploymentAPI decorates an api.DeploymentAPI with Hooks.
+type deploymentAPI struct {
+	next  api.DeploymentAPI
+	hooks Hooks
+}
+
+// NewDeploymentAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewDeploymentAPI(next api.DeploymentAPI, hooks Hooks) api.DeploymentAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &deploymentAPI{next: next, hooks: hooks}
+}
+
+func (d *deploymentAPI) GetDeploymentByName(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "GetDeploymentByName", Resource: "deployments", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*appsv1.Deployment, error) {
+		return d.next.GetDeploymentByName(ctx, namespace, name)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByLabel", Resource: "deployments", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.Deployment, error) {
+		return d.next.ListDeploymentsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByField", Resource: "deployments", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.Deployment, error) {
+		return d.next.ListDeploymentsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByQuery", Resource: "deployments", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.Deployment, error) {
+		return d.next.ListDeploymentsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByLabelAllNamespaces", Resource: "deployments", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.Deployment, error) {
+		return d.next.ListDeploymentsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.Deployment, error) {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByFieldAllNamespaces", Resource: "deployments", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.Deployment, error) {
+		return d.next.ListDeploymentsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByLabelPaged", Resource: "deployments", Verb: "list", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[appsv1.Deployment, error] {
+		return d.next.ListDeploymentsByLabelPaged(ctx, namespace, labelSelector, pageSize)
+	})
+}
+
+func (d *deploymentAPI) ListDeploymentsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[appsv1.Deployment, error] {
+	call := Call{API: "DeploymentAPI", Method: "ListDeploymentsByFieldPaged", Resource: "deployments", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[appsv1.Deployment, error] {
+		return d.next.ListDeploymentsByFieldPaged(ctx, namespace, fieldSelector, pageSize)
+	})
+}
+
+func (d *deploymentAPI) WatchDeploymentsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	call := Call{API: "DeploymentAPI", Method: "WatchDeploymentsByLabel", Resource: "deployments", Verb: "watch", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+		return d.next.WatchDeploymentsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *deploymentAPI) WatchDeploymentsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+	call := Call{API: "DeploymentAPI", Method: "WatchDeploymentsByField", Resource: "deployments", Verb: "watch", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*appsv1.Deployment], error) {
+		return d.next.WatchDeploymentsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+// namespaceAPI decorates an api.NamespaceAPI with Hooks.
+type namespaceAPI struct {
+	next  api.NamespaceAPI
+	hooks Hooks
+}
+
+// NewNamespaceAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewNamespaceAPI(next api.NamespaceAPI, hooks Hooks) api.NamespaceAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &namespaceAPI{next: next, hooks: hooks}
+}
+
+func (d *namespaceAPI) GetNamespaceByName(ctx context.Context, name string) (*corev1.Namespace, error) {
+	call := Call{API: "NamespaceAPI", Method: "GetNamespaceByName", Resource: "namespaces", Verb: "get", Namespace: name, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.Namespace, error) {
+		return d.next.GetNamespaceByName(ctx, name)
+	})
+}
+
+func (d *namespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector string) ([]corev1.Namespace, error) {
+	call := Call{API: "NamespaceAPI", Method: "ListNamespacesByLabel", Resource: "namespaces", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Namespace, error) {
+		return d.next.ListNamespacesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *namespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector string) ([]corev1.Namespace, error) {
+	call := Call{API: "NamespaceAPI", Method: "ListNamespacesByField", Resource: "namespaces", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Namespace, error) {
+		return d.next.ListNamespacesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *namespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.Namespace, error) {
+	call := Call{API: "NamespaceAPI", Method: "ListNamespacesByQuery", Resource: "namespaces", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Namespace, error) {
+		return d.next.ListNamespacesByQuery(ctx, query)
+	})
+}
+
+func (d *namespaceAPI) ListNamespacesByLabelPaged(ctx context.Context, labelSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	call := Call{API: "NamespaceAPI", Method: "ListNamespacesByLabelPaged", Resource: "namespaces", Verb: "list", LabelSelector: labelSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Namespace, error] {
+		return d.next.ListNamespacesByLabelPaged(ctx, labelSelector, pageSize)
+	})
+}
+
+func (d *namespaceAPI) ListNamespacesByFieldPaged(ctx context.Context, fieldSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	call := Call{API: "NamespaceAPI", Method: "ListNamespacesByFieldPaged", Resource: "namespaces", Verb: "list", FieldSelector: fieldSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Namespace, error] {
+		return d.next.ListNamespacesByFieldPaged(ctx, fieldSelector, pageSize)
+	})
+}
+
+func (d *namespaceAPI) WatchNamespacesByLabel(ctx context.Context, labelSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	call := Call{API: "NamespaceAPI", Method: "WatchNamespacesByLabel", Resource: "namespaces", Verb: "watch", LabelSelector: labelSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+		return d.next.WatchNamespacesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *namespaceAPI) WatchNamespacesByField(ctx context.Context, fieldSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	call := Call{API: "NamespaceAPI", Method: "WatchNamespacesByField", Resource: "namespaces", Verb: "watch", FieldSelector: fieldSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+		return d.next.WatchNamespacesByField(ctx, fieldSelector)
+	})
+}
+
+// serviceAPI decorates an api.ServiceAPI with Hooks.
+type serviceAPI struct {
+	next  api.ServiceAPI
+	hooks Hooks
+}
+
+// NewServiceAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewServiceAPI(next api.ServiceAPI, hooks Hooks) api.ServiceAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &serviceAPI{next: next, hooks: hooks}
+}
+
+func (d *serviceAPI) GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "GetServiceByName", Resource: "services", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.Service, error) {
+		return d.next.GetServiceByName(ctx, namespace, name)
+	})
+}
+
+func (d *serviceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByLabel", Resource: "services", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Service, error) {
+		return d.next.ListServicesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *serviceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByField", Resource: "services", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Service, error) {
+		return d.next.ListServicesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *serviceAPI) ListServicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByQuery", Resource: "services", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Service, error) {
+		return d.next.ListServicesByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *serviceAPI) ListServicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByLabelAllNamespaces", Resource: "services", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Service, error) {
+		return d.next.ListServicesByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *serviceAPI) ListServicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByFieldAllNamespaces", Resource: "services", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Service, error) {
+		return d.next.ListServicesByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *serviceAPI) ListServicesByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByLabelPaged", Resource: "services", Verb: "list", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Service, error] {
+		return d.next.ListServicesByLabelPaged(ctx, namespace, labelSelector, pageSize)
+	})
+}
+
+func (d *serviceAPI) ListServicesByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	call := Call{API: "ServiceAPI", Method: "ListServicesByFieldPaged", Resource: "services", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Service, error] {
+		return d.next.ListServicesByFieldPaged(ctx, namespace, fieldSelector, pageSize)
+	})
+}
+
+func (d *serviceAPI) WatchServicesByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	call := Call{API: "ServiceAPI", Method: "WatchServicesByLabel", Resource: "services", Verb: "watch", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Service], error) {
+		return d.next.WatchServicesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *serviceAPI) WatchServicesByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	call := Call{API: "ServiceAPI", Method: "WatchServicesByField", Resource: "services", Verb: "watch", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Service], error) {
+		return d.next.WatchServicesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+// podAPI decorates an api.PodAPI with Hooks.
+type podAPI struct {
+	next  api.PodAPI
+	hooks Hooks
+}
+
+// NewPodAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewPodAPI(next api.PodAPI, hooks Hooks) api.PodAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &podAPI{next: next, hooks: hooks}
+}
+
+func (d *podAPI) GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "GetPodByName", Resource: "pods", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.Pod, error) {
+		return d.next.GetPodByName(ctx, namespace, name)
+	})
+}
+
+func (d *podAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "ListPodsByLabel", Resource: "pods", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *podAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "ListPodsByField", Resource: "pods", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *podAPI) ListPodsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "ListPodsByQuery", Resource: "pods", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *podAPI) ListPodsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "ListPodsByLabelAllNamespaces", Resource: "pods", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *podAPI) ListPodsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	call := Call{API: "PodAPI", Method: "ListPodsByFieldAllNamespaces", Resource: "pods", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *podAPI) ListPodsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	call := Call{API: "PodAPI", Method: "ListPodsByLabelPaged", Resource: "pods", Verb: "list", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Pod, error] {
+		return d.next.ListPodsByLabelPaged(ctx, namespace, labelSelector, pageSize)
+	})
+}
+
+func (d *podAPI) ListPodsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	call := Call{API: "PodAPI", Method: "ListPodsByFieldPaged", Resource: "pods", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return paged(ctx, d.hooks, call, func(ctx context.Context) iter.Seq2[corev1.Pod, error] {
+		return d.next.ListPodsByFieldPaged(ctx, namespace, fieldSelector, pageSize)
+	})
+}
+
+func (d *podAPI) WatchPodsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	call := Call{API: "PodAPI", Method: "WatchPodsByLabel", Resource: "pods", Verb: "watch", Namespace: namespace, LabelSelector: labelSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Pod], error) {
+		return d.next.WatchPodsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *podAPI) WatchPodsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	call := Call{API: "PodAPI", Method: "WatchPodsByField", Resource: "pods", Verb: "watch", Namespace: namespace, FieldSelector: fieldSelector, Stream: true}
+	return watch(ctx, d.hooks, call, func(ctx context.Context) (<-chan api.WatchEvent[*corev1.Pod], error) {
+		return d.next.WatchPodsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+// statefulSetAPI decorates an api.StatefulSetAPI with Hooks.
+type statefulSetAPI struct {
+	next  api.StatefulSetAPI
+	hooks Hooks
+}
+
+// NewStatefulSetAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewStatefulSetAPI(next api.StatefulSetAPI, hooks Hooks) api.StatefulSetAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &statefulSetAPI{next: next, hooks: hooks}
+}
+
+func (d *statefulSetAPI) GetStatefulSetByName(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "GetStatefulSetByName", Resource: "statefulsets", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*appsv1.StatefulSet, error) {
+		return d.next.GetStatefulSetByName(ctx, namespace, name)
+	})
+}
+
+func (d *statefulSetAPI) ListStatefulSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "ListStatefulSetsByLabel", Resource: "statefulsets", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.StatefulSet, error) {
+		return d.next.ListStatefulSetsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *statefulSetAPI) ListStatefulSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "ListStatefulSetsByField", Resource: "statefulsets", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.StatefulSet, error) {
+		return d.next.ListStatefulSetsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *statefulSetAPI) ListStatefulSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "ListStatefulSetsByQuery", Resource: "statefulsets", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.StatefulSet, error) {
+		return d.next.ListStatefulSetsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *statefulSetAPI) ListStatefulSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "ListStatefulSetsByLabelAllNamespaces", Resource: "statefulsets", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.StatefulSet, error) {
+		return d.next.ListStatefulSetsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *statefulSetAPI) ListStatefulSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.StatefulSet, error) {
+	call := Call{API: "StatefulSetAPI", Method: "ListStatefulSetsByFieldAllNamespaces", Resource: "statefulsets", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.StatefulSet, error) {
+		return d.next.ListStatefulSetsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// daemonSetAPI decorates an api.DaemonSetAPI with Hooks.
+type daemonSetAPI struct {
+	next  api.DaemonSetAPI
+	hooks Hooks
+}
+
+// NewDaemonSetAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewDaemonSetAPI(next api.DaemonSetAPI, hooks Hooks) api.DaemonSetAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &daemonSetAPI{next: next, hooks: hooks}
+}
+
+func (d *daemonSetAPI) GetDaemonSetByName(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "GetDaemonSetByName", Resource: "daemonsets", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*appsv1.DaemonSet, error) {
+		return d.next.GetDaemonSetByName(ctx, namespace, name)
+	})
+}
+
+func (d *daemonSetAPI) ListDaemonSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "ListDaemonSetsByLabel", Resource: "daemonsets", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.DaemonSet, error) {
+		return d.next.ListDaemonSetsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *daemonSetAPI) ListDaemonSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "ListDaemonSetsByField", Resource: "daemonsets", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.DaemonSet, error) {
+		return d.next.ListDaemonSetsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *daemonSetAPI) ListDaemonSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "ListDaemonSetsByQuery", Resource: "daemonsets", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.DaemonSet, error) {
+		return d.next.ListDaemonSetsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *daemonSetAPI) ListDaemonSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "ListDaemonSetsByLabelAllNamespaces", Resource: "daemonsets", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.DaemonSet, error) {
+		return d.next.ListDaemonSetsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *daemonSetAPI) ListDaemonSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.DaemonSet, error) {
+	call := Call{API: "DaemonSetAPI", Method: "ListDaemonSetsByFieldAllNamespaces", Resource: "daemonsets", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.DaemonSet, error) {
+		return d.next.ListDaemonSetsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// replicaSetAPI decorates an api.ReplicaSetAPI with Hooks.
+type replicaSetAPI struct {
+	next  api.ReplicaSetAPI
+	hooks Hooks
+}
+
+// NewReplicaSetAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewReplicaSetAPI(next api.ReplicaSetAPI, hooks Hooks) api.ReplicaSetAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &replicaSetAPI{next: next, hooks: hooks}
+}
+
+func (d *replicaSetAPI) GetReplicaSetByName(ctx context.Context, namespace, name string) (*appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "GetReplicaSetByName", Resource: "replicasets", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*appsv1.ReplicaSet, error) {
+		return d.next.GetReplicaSetByName(ctx, namespace, name)
+	})
+}
+
+func (d *replicaSetAPI) ListReplicaSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "ListReplicaSetsByLabel", Resource: "replicasets", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.ReplicaSet, error) {
+		return d.next.ListReplicaSetsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *replicaSetAPI) ListReplicaSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "ListReplicaSetsByField", Resource: "replicasets", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.ReplicaSet, error) {
+		return d.next.ListReplicaSetsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *replicaSetAPI) ListReplicaSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "ListReplicaSetsByQuery", Resource: "replicasets", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.ReplicaSet, error) {
+		return d.next.ListReplicaSetsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *replicaSetAPI) ListReplicaSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "ListReplicaSetsByLabelAllNamespaces", Resource: "replicasets", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.ReplicaSet, error) {
+		return d.next.ListReplicaSetsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *replicaSetAPI) ListReplicaSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.ReplicaSet, error) {
+	call := Call{API: "ReplicaSetAPI", Method: "ListReplicaSetsByFieldAllNamespaces", Resource: "replicasets", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]appsv1.ReplicaSet, error) {
+		return d.next.ListReplicaSetsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// jobAPI decorates an api.JobAPI with Hooks.
+type jobAPI struct {
+	next  api.JobAPI
+	hooks Hooks
+}
+
+// NewJobAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewJobAPI(next api.JobAPI, hooks Hooks) api.JobAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &jobAPI{next: next, hooks: hooks}
+}
+
+func (d *jobAPI) GetJobByName(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "GetJobByName", Resource: "jobs", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*batchv1.Job, error) {
+		return d.next.GetJobByName(ctx, namespace, name)
+	})
+}
+
+func (d *jobAPI) ListJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "ListJobsByLabel", Resource: "jobs", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *jobAPI) ListJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "ListJobsByField", Resource: "jobs", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *jobAPI) ListJobsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "ListJobsByQuery", Resource: "jobs", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *jobAPI) ListJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "ListJobsByLabelAllNamespaces", Resource: "jobs", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *jobAPI) ListJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]batchv1.Job, error) {
+	call := Call{API: "JobAPI", Method: "ListJobsByFieldAllNamespaces", Resource: "jobs", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *jobAPI) ListPodsForJob(ctx context.Context, namespace, name string) ([]corev1.Pod, error) {
+	call := Call{API: "JobAPI", Method: "ListPodsForJob", Resource: "pods", Verb: "list", Namespace: namespace, Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsForJob(ctx, namespace, name)
+	})
+}
+
+// cronJobAPI decorates an api.CronJobAPI with Hooks.
+type cronJobAPI struct {
+	next  api.CronJobAPI
+	hooks Hooks
+}
+
+// NewCronJobAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewCronJobAPI(next api.CronJobAPI, hooks Hooks) api.CronJobAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &cronJobAPI{next: next, hooks: hooks}
+}
+
+func (d *cronJobAPI) GetCronJobByName(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "GetCronJobByName", Resource: "cronjobs", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*batchv1.CronJob, error) {
+		return d.next.GetCronJobByName(ctx, namespace, name)
+	})
+}
+
+func (d *cronJobAPI) ListCronJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "ListCronJobsByLabel", Resource: "cronjobs", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.CronJob, error) {
+		return d.next.ListCronJobsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *cronJobAPI) ListCronJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "ListCronJobsByField", Resource: "cronjobs", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.CronJob, error) {
+		return d.next.ListCronJobsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *cronJobAPI) ListCronJobsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "ListCronJobsByQuery", Resource: "cronjobs", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.CronJob, error) {
+		return d.next.ListCronJobsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *cronJobAPI) ListCronJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "ListCronJobsByLabelAllNamespaces", Resource: "cronjobs", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.CronJob, error) {
+		return d.next.ListCronJobsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *cronJobAPI) ListCronJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]batchv1.CronJob, error) {
+	call := Call{API: "CronJobAPI", Method: "ListCronJobsByFieldAllNamespaces", Resource: "cronjobs", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.CronJob, error) {
+		return d.next.ListCronJobsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *cronJobAPI) ListJobsForCronJob(ctx context.Context, namespace, name string) ([]batchv1.Job, error) {
+	call := Call{API: "CronJobAPI", Method: "ListJobsForCronJob", Resource: "jobs", Verb: "list", Namespace: namespace, Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]batchv1.Job, error) {
+		return d.next.ListJobsForCronJob(ctx, namespace, name)
+	})
+}
+
+func (d *cronJobAPI) ListRunsForCronJob(ctx context.Context, namespace, name string) ([]api.JobRun, error) {
+	call := Call{API: "CronJobAPI", Method: "ListRunsForCronJob", Resource: "jobs", Verb: "list", Namespace: namespace, Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.JobRun, error) {
+		return d.next.ListRunsForCronJob(ctx, namespace, name)
+	})
+}
+
+// configMapAPI decorates an api.ConfigMapAPI with Hooks.
+type configMapAPI struct {
+	next  api.ConfigMapAPI
+	hooks Hooks
+}
+
+// NewConfigMapAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewConfigMapAPI(next api.ConfigMapAPI, hooks Hooks) api.ConfigMapAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &configMapAPI{next: next, hooks: hooks}
+}
+
+func (d *configMapAPI) GetConfigMapByName(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "GetConfigMapByName", Resource: "configmaps", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.ConfigMap, error) {
+		return d.next.GetConfigMapByName(ctx, namespace, name)
+	})
+}
+
+func (d *configMapAPI) ListConfigMapsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "ListConfigMapsByLabel", Resource: "configmaps", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ConfigMap, error) {
+		return d.next.ListConfigMapsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *configMapAPI) ListConfigMapsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "ListConfigMapsByField", Resource: "configmaps", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ConfigMap, error) {
+		return d.next.ListConfigMapsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *configMapAPI) ListConfigMapsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "ListConfigMapsByQuery", Resource: "configmaps", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ConfigMap, error) {
+		return d.next.ListConfigMapsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *configMapAPI) ListConfigMapsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "ListConfigMapsByLabelAllNamespaces", Resource: "configmaps", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ConfigMap, error) {
+		return d.next.ListConfigMapsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *configMapAPI) ListConfigMapsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.ConfigMap, error) {
+	call := Call{API: "ConfigMapAPI", Method: "ListConfigMapsByFieldAllNamespaces", Resource: "configmaps", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ConfigMap, error) {
+		return d.next.ListConfigMapsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// secretAPI decorates an api.SecretAPI with Hooks.
+type secretAPI struct {
+	next  api.SecretAPI
+	hooks Hooks
+}
+
+// NewSecretAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewSecretAPI(next api.SecretAPI, hooks Hooks) api.SecretAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &secretAPI{next: next, hooks: hooks}
+}
+
+func (d *secretAPI) GetSecretByName(ctx context.Context, namespace, name string) (*api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "GetSecretByName", Resource: "secrets", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*api.SecretMetadata, error) {
+		return d.next.GetSecretByName(ctx, namespace, name)
+	})
+}
+
+func (d *secretAPI) ListSecretsByLabel(ctx context.Context, namespace string, labelSelector string) ([]api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "ListSecretsByLabel", Resource: "secrets", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.SecretMetadata, error) {
+		return d.next.ListSecretsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *secretAPI) ListSecretsByField(ctx context.Context, namespace string, fieldSelector string) ([]api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "ListSecretsByField", Resource: "secrets", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.SecretMetadata, error) {
+		return d.next.ListSecretsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *secretAPI) ListSecretsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "ListSecretsByQuery", Resource: "secrets", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.SecretMetadata, error) {
+		return d.next.ListSecretsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *secretAPI) ListSecretsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "ListSecretsByLabelAllNamespaces", Resource: "secrets", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.SecretMetadata, error) {
+		return d.next.ListSecretsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *secretAPI) ListSecretsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]api.SecretMetadata, error) {
+	call := Call{API: "SecretAPI", Method: "ListSecretsByFieldAllNamespaces", Resource: "secrets", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.SecretMetadata, error) {
+		return d.next.ListSecretsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// rbacAPI decorates an api.RBACAPI with Hooks.
+type rbacAPI struct {
+	next  api.RBACAPI
+	hooks Hooks
+}
+
+// NewRBACAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewRBACAPI(next api.RBACAPI, hooks Hooks) api.RBACAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &rbacAPI{next: next, hooks: hooks}
+}
+
+func (d *rbacAPI) GetRoleByName(ctx context.Context, namespace, name string) (*rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "GetRoleByName", Resource: "roles", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*rbacv1.Role, error) {
+		return d.next.GetRoleByName(ctx, namespace, name)
+	})
+}
+
+func (d *rbacAPI) ListRolesByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesByLabel", Resource: "roles", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.Role, error) {
+		return d.next.ListRolesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *rbacAPI) ListRolesByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesByField", Resource: "roles", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.Role, error) {
+		return d.next.ListRolesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *rbacAPI) ListRolesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesByQuery", Resource: "roles", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.Role, error) {
+		return d.next.ListRolesByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *rbacAPI) ListRolesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesByLabelAllNamespaces", Resource: "roles", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.Role, error) {
+		return d.next.ListRolesByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *rbacAPI) ListRolesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.Role, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesByFieldAllNamespaces", Resource: "roles", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.Role, error) {
+		return d.next.ListRolesByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *rbacAPI) GetClusterRoleByName(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+	call := Call{API: "RBACAPI", Method: "GetClusterRoleByName", Resource: "clusterroles", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*rbacv1.ClusterRole, error) {
+		return d.next.GetClusterRoleByName(ctx, name)
+	})
+}
+
+func (d *rbacAPI) ListClusterRolesByLabel(ctx context.Context, labelSelector string) ([]rbacv1.ClusterRole, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRolesByLabel", Resource: "clusterroles", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRole, error) {
+		return d.next.ListClusterRolesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *rbacAPI) ListClusterRolesByField(ctx context.Context, fieldSelector string) ([]rbacv1.ClusterRole, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRolesByField", Resource: "clusterroles", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRole, error) {
+		return d.next.ListClusterRolesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *rbacAPI) ListClusterRolesByQuery(ctx context.Context, query api.ListQuery) ([]rbacv1.ClusterRole, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRolesByQuery", Resource: "clusterroles", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRole, error) {
+		return d.next.ListClusterRolesByQuery(ctx, query)
+	})
+}
+
+func (d *rbacAPI) GetRoleBindingByName(ctx context.Context, namespace, name string) (*rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "GetRoleBindingByName", Resource: "rolebindings", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*rbacv1.RoleBinding, error) {
+		return d.next.GetRoleBindingByName(ctx, namespace, name)
+	})
+}
+
+func (d *rbacAPI) ListRoleBindingsByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListRoleBindingsByLabel", Resource: "rolebindings", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+		return d.next.ListRoleBindingsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *rbacAPI) ListRoleBindingsByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListRoleBindingsByField", Resource: "rolebindings", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+		return d.next.ListRoleBindingsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *rbacAPI) ListRoleBindingsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListRoleBindingsByQuery", Resource: "rolebindings", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+		return d.next.ListRoleBindingsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *rbacAPI) ListRoleBindingsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListRoleBindingsByLabelAllNamespaces", Resource: "rolebindings", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+		return d.next.ListRoleBindingsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *rbacAPI) ListRoleBindingsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.RoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListRoleBindingsByFieldAllNamespaces", Resource: "rolebindings", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.RoleBinding, error) {
+		return d.next.ListRoleBindingsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *rbacAPI) GetClusterRoleBindingByName(ctx context.Context, name string) (*rbacv1.ClusterRoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "GetClusterRoleBindingByName", Resource: "clusterrolebindings", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*rbacv1.ClusterRoleBinding, error) {
+		return d.next.GetClusterRoleBindingByName(ctx, name)
+	})
+}
+
+func (d *rbacAPI) ListClusterRoleBindingsByLabel(ctx context.Context, labelSelector string) ([]rbacv1.ClusterRoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRoleBindingsByLabel", Resource: "clusterrolebindings", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRoleBinding, error) {
+		return d.next.ListClusterRoleBindingsByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *rbacAPI) ListClusterRoleBindingsByField(ctx context.Context, fieldSelector string) ([]rbacv1.ClusterRoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRoleBindingsByField", Resource: "clusterrolebindings", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRoleBinding, error) {
+		return d.next.ListClusterRoleBindingsByField(ctx, fieldSelector)
+	})
+}
+
+func (d *rbacAPI) ListClusterRoleBindingsByQuery(ctx context.Context, query api.ListQuery) ([]rbacv1.ClusterRoleBinding, error) {
+	call := Call{API: "RBACAPI", Method: "ListClusterRoleBindingsByQuery", Resource: "clusterrolebindings", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]rbacv1.ClusterRoleBinding, error) {
+		return d.next.ListClusterRoleBindingsByQuery(ctx, query)
+	})
+}
+
+func (d *rbacAPI) ListSubjectsForClusterRole(ctx context.Context, name string) ([]api.BoundSubject, error) {
+	call := Call{API: "RBACAPI", Method: "ListSubjectsForClusterRole", Resource: "clusterroles", Verb: "list", Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.BoundSubject, error) {
+		return d.next.ListSubjectsForClusterRole(ctx, name)
+	})
+}
+
+func (d *rbacAPI) ListRolesGranting(ctx context.Context, verb, resource string) ([]api.RoleGrant, error) {
+	call := Call{API: "RBACAPI", Method: "ListRolesGranting", Resource: "roles", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.RoleGrant, error) {
+		return d.next.ListRolesGranting(ctx, verb, resource)
+	})
+}
+
+func (d *rbacAPI) ListEffectiveRulesForServiceAccount(ctx context.Context, namespace, name string) ([]api.EffectiveRule, error) {
+	call := Call{API: "RBACAPI", Method: "ListEffectiveRulesForServiceAccount", Resource: "serviceaccounts", Verb: "list", Namespace: namespace, Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.EffectiveRule, error) {
+		return d.next.ListEffectiveRulesForServiceAccount(ctx, namespace, name)
+	})
+}
+
+// serviceAccountAPI decorates an api.ServiceAccountAPI with Hooks.
+type serviceAccountAPI struct {
+	next  api.ServiceAccountAPI
+	hooks Hooks
+}
+
+// NewServiceAccountAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewServiceAccountAPI(next api.ServiceAccountAPI, hooks Hooks) api.ServiceAccountAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &serviceAccountAPI{next: next, hooks: hooks}
+}
+
+func (d *serviceAccountAPI) GetServiceAccountByName(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "GetServiceAccountByName", Resource: "serviceaccounts", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.ServiceAccount, error) {
+		return d.next.GetServiceAccountByName(ctx, namespace, name)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountsByLabel", Resource: "serviceaccounts", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+		return d.next.ListServiceAccountsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountsByField", Resource: "serviceaccounts", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+		return d.next.ListServiceAccountsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountsByQuery", Resource: "serviceaccounts", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+		return d.next.ListServiceAccountsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountsByLabelAllNamespaces", Resource: "serviceaccounts", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+		return d.next.ListServiceAccountsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.ServiceAccount, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountsByFieldAllNamespaces", Resource: "serviceaccounts", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+		return d.next.ListServiceAccountsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *serviceAccountAPI) ListServiceAccountUsage(ctx context.Context, namespace string) ([]api.ServiceAccountUsage, error) {
+	call := Call{API: "ServiceAccountAPI", Method: "ListServiceAccountUsage", Resource: "serviceaccounts", Verb: "list", Namespace: namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.ServiceAccountUsage, error) {
+		return d.next.ListServiceAccountUsage(ctx, namespace)
+	})
+}
+
+// nodeAPI decorates an api.NodeAPI with Hooks.
+type nodeAPI struct {
+	next  api.NodeAPI
+	hooks Hooks
+}
+
+// NewNodeAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewNodeAPI(next api.NodeAPI, hooks Hooks) api.NodeAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &nodeAPI{next: next, hooks: hooks}
+}
+
+func (d *nodeAPI) GetNodeByName(ctx context.Context, name string) (*corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "GetNodeByName", Resource: "nodes", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.Node, error) {
+		return d.next.GetNodeByName(ctx, name)
+	})
+}
+
+func (d *nodeAPI) ListNodesByLabel(ctx context.Context, labelSelector string) ([]corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "ListNodesByLabel", Resource: "nodes", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Node, error) {
+		return d.next.ListNodesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *nodeAPI) ListNodesByField(ctx context.Context, fieldSelector string) ([]corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "ListNodesByField", Resource: "nodes", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Node, error) {
+		return d.next.ListNodesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *nodeAPI) ListNodesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "ListNodesByQuery", Resource: "nodes", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Node, error) {
+		return d.next.ListNodesByQuery(ctx, query)
+	})
+}
+
+func (d *nodeAPI) ListNotReadyNodes(ctx context.Context) ([]corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "ListNotReadyNodes", Resource: "nodes", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Node, error) {
+		return d.next.ListNotReadyNodes(ctx)
+	})
+}
+
+func (d *nodeAPI) ListNodesWithTaint(ctx context.Context, key string, effect corev1.TaintEffect) ([]corev1.Node, error) {
+	call := Call{API: "NodeAPI", Method: "ListNodesWithTaint", Resource: "nodes", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Node, error) {
+		return d.next.ListNodesWithTaint(ctx, key, effect)
+	})
+}
+
+func (d *nodeAPI) ListPodsOnNode(ctx context.Context, name string) ([]corev1.Pod, error) {
+	call := Call{API: "NodeAPI", Method: "ListPodsOnNode", Resource: "pods", Verb: "list", Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsOnNode(ctx, name)
+	})
+}
+
+// eventAPI decorates an api.EventAPI with Hooks.
+type eventAPI struct {
+	next  api.EventAPI
+	hooks Hooks
+}
+
+// NewEventAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewEventAPI(next api.EventAPI, hooks Hooks) api.EventAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &eventAPI{next: next, hooks: hooks}
+}
+
+func (d *eventAPI) GetEventByName(ctx context.Context, namespace, name string) (*eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "GetEventByName", Resource: "events", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*eventsv1.Event, error) {
+		return d.next.GetEventByName(ctx, namespace, name)
+	})
+}
+
+func (d *eventAPI) ListEventsByLabel(ctx context.Context, namespace string, labelSelector string) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEventsByLabel", Resource: "events", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *eventAPI) ListEventsByField(ctx context.Context, namespace string, fieldSelector string) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEventsByField", Resource: "events", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *eventAPI) ListEventsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEventsByQuery", Resource: "events", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *eventAPI) ListEventsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEventsByLabelAllNamespaces", Resource: "events", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *eventAPI) ListEventsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEventsByFieldAllNamespaces", Resource: "events", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *eventAPI) ListEvents(ctx context.Context, filter api.EventFilter) ([]eventsv1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListEvents", Resource: "events", Verb: "list", Namespace: filter.Namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEvents(ctx, filter)
+	})
+}
+
+func (d *eventAPI) ListCoreEvents(ctx context.Context, filter api.EventFilter) ([]corev1.Event, error) {
+	call := Call{API: "EventAPI", Method: "ListCoreEvents", Resource: "events", Verb: "list", Namespace: filter.Namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Event, error) {
+		return d.next.ListCoreEvents(ctx, filter)
+	})
+}
+
+func (d *eventAPI) ListEventsForPod(ctx context.Context, pod *corev1.Pod) ([]eventsv1.Event, error) {
+	var namespace string
+	if pod != nil {
+		namespace = pod.Namespace
+	}
+	call := Call{API: "EventAPI", Method: "ListEventsForPod", Resource: "events", Verb: "list", Namespace: namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsForPod(ctx, pod)
+	})
+}
+
+func (d *eventAPI) ListEventsForDeployment(ctx context.Context, deployment *appsv1.Deployment) ([]eventsv1.Event, error) {
+	var namespace string
+	if deployment != nil {
+		namespace = deployment.Namespace
+	}
+	call := Call{API: "EventAPI", Method: "ListEventsForDeployment", Resource: "events", Verb: "list", Namespace: namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]eventsv1.Event, error) {
+		return d.next.ListEventsForDeployment(ctx, deployment)
+	})
+}
+
+// networkingAPI decorates an api.NetworkingAPI with Hooks.
+type networkingAPI struct {
+	next  api.NetworkingAPI
+	hooks Hooks
+}
+
+// NewNetworkingAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewNetworkingAPI(next api.NetworkingAPI, hooks Hooks) api.NetworkingAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &networkingAPI{next: next, hooks: hooks}
+}
+
+func (d *networkingAPI) GetIngressByName(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "GetIngressByName", Resource: "ingresses", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*networkingv1.Ingress, error) {
+		return d.next.GetIngressByName(ctx, namespace, name)
+	})
+}
+
+func (d *networkingAPI) ListIngressesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressesByLabel", Resource: "ingresses", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.Ingress, error) {
+		return d.next.ListIngressesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *networkingAPI) ListIngressesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressesByField", Resource: "ingresses", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.Ingress, error) {
+		return d.next.ListIngressesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *networkingAPI) ListIngressesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressesByQuery", Resource: "ingresses", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.Ingress, error) {
+		return d.next.ListIngressesByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *networkingAPI) ListIngressesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressesByLabelAllNamespaces", Resource: "ingresses", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.Ingress, error) {
+		return d.next.ListIngressesByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *networkingAPI) ListIngressesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.Ingress, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressesByFieldAllNamespaces", Resource: "ingresses", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.Ingress, error) {
+		return d.next.ListIngressesByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *networkingAPI) GetIngressClassByName(ctx context.Context, name string) (*networkingv1.IngressClass, error) {
+	call := Call{API: "NetworkingAPI", Method: "GetIngressClassByName", Resource: "ingressclasses", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*networkingv1.IngressClass, error) {
+		return d.next.GetIngressClassByName(ctx, name)
+	})
+}
+
+func (d *networkingAPI) ListIngressClassesByLabel(ctx context.Context, labelSelector string) ([]networkingv1.IngressClass, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressClassesByLabel", Resource: "ingressclasses", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.IngressClass, error) {
+		return d.next.ListIngressClassesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *networkingAPI) ListIngressClassesByField(ctx context.Context, fieldSelector string) ([]networkingv1.IngressClass, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressClassesByField", Resource: "ingressclasses", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.IngressClass, error) {
+		return d.next.ListIngressClassesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *networkingAPI) ListIngressClassesByQuery(ctx context.Context, query api.ListQuery) ([]networkingv1.IngressClass, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListIngressClassesByQuery", Resource: "ingressclasses", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.IngressClass, error) {
+		return d.next.ListIngressClassesByQuery(ctx, query)
+	})
+}
+
+func (d *networkingAPI) GetNetworkPolicyByName(ctx context.Context, namespace, name string) (*networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "GetNetworkPolicyByName", Resource: "networkpolicies", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*networkingv1.NetworkPolicy, error) {
+		return d.next.GetNetworkPolicyByName(ctx, namespace, name)
+	})
+}
+
+func (d *networkingAPI) ListNetworkPoliciesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListNetworkPoliciesByLabel", Resource: "networkpolicies", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+		return d.next.ListNetworkPoliciesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *networkingAPI) ListNetworkPoliciesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListNetworkPoliciesByField", Resource: "networkpolicies", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+		return d.next.ListNetworkPoliciesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *networkingAPI) ListNetworkPoliciesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListNetworkPoliciesByQuery", Resource: "networkpolicies", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+		return d.next.ListNetworkPoliciesByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *networkingAPI) ListNetworkPoliciesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListNetworkPoliciesByLabelAllNamespaces", Resource: "networkpolicies", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+		return d.next.ListNetworkPoliciesByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *networkingAPI) ListNetworkPoliciesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.NetworkPolicy, error) {
+	call := Call{API: "NetworkingAPI", Method: "ListNetworkPoliciesByFieldAllNamespaces", Resource: "networkpolicies", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]networkingv1.NetworkPolicy, error) {
+		return d.next.ListNetworkPoliciesByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// endpointSliceAPI decorates an api.EndpointSliceAPI with Hooks.
+type endpointSliceAPI struct {
+	next  api.EndpointSliceAPI
+	hooks Hooks
+}
+
+// NewEndpointSliceAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewEndpointSliceAPI(next api.EndpointSliceAPI, hooks Hooks) api.EndpointSliceAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &endpointSliceAPI{next: next, hooks: hooks}
+}
+
+func (d *endpointSliceAPI) GetEndpointSliceByName(ctx context.Context, namespace, name string) (*discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "GetEndpointSliceByName", Resource: "endpointslices", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*discoveryv1.EndpointSlice, error) {
+		return d.next.GetEndpointSliceByName(ctx, namespace, name)
+	})
+}
+
+func (d *endpointSliceAPI) ListEndpointSlicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "ListEndpointSlicesByLabel", Resource: "endpointslices", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+		return d.next.ListEndpointSlicesByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *endpointSliceAPI) ListEndpointSlicesByField(ctx context.Context, namespace string, fieldSelector string) ([]discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "ListEndpointSlicesByField", Resource: "endpointslices", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+		return d.next.ListEndpointSlicesByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *endpointSliceAPI) ListEndpointSlicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "ListEndpointSlicesByQuery", Resource: "endpointslices", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+		return d.next.ListEndpointSlicesByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *endpointSliceAPI) ListEndpointSlicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "ListEndpointSlicesByLabelAllNamespaces", Resource: "endpointslices", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+		return d.next.ListEndpointSlicesByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *endpointSliceAPI) ListEndpointSlicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]discoveryv1.EndpointSlice, error) {
+	call := Call{API: "EndpointSliceAPI", Method: "ListEndpointSlicesByFieldAllNamespaces", Resource: "endpointslices", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+		return d.next.ListEndpointSlicesByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+// storageAPI decorates an api.StorageAPI with Hooks.
+type storageAPI struct {
+	next  api.StorageAPI
+	hooks Hooks
+}
+
+// NewStorageAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewStorageAPI(next api.StorageAPI, hooks Hooks) api.StorageAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &storageAPI{next: next, hooks: hooks}
+}
+
+func (d *storageAPI) GetPersistentVolumeByName(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
+	call := Call{API: "StorageAPI", Method: "GetPersistentVolumeByName", Resource: "persistentvolumes", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.PersistentVolume, error) {
+		return d.next.GetPersistentVolumeByName(ctx, name)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumesByLabel(ctx context.Context, labelSelector string) ([]corev1.PersistentVolume, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumesByLabel", Resource: "persistentvolumes", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolume, error) {
+		return d.next.ListPersistentVolumesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumesByField(ctx context.Context, fieldSelector string) ([]corev1.PersistentVolume, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumesByField", Resource: "persistentvolumes", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolume, error) {
+		return d.next.ListPersistentVolumesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.PersistentVolume, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumesByQuery", Resource: "persistentvolumes", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolume, error) {
+		return d.next.ListPersistentVolumesByQuery(ctx, query)
+	})
+}
+
+func (d *storageAPI) GetPersistentVolumeClaimByName(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "GetPersistentVolumeClaimByName", Resource: "persistentvolumeclaims", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+		return d.next.GetPersistentVolumeClaimByName(ctx, namespace, name)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumeClaimsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumeClaimsByLabel", Resource: "persistentvolumeclaims", Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+		return d.next.ListPersistentVolumeClaimsByLabel(ctx, namespace, labelSelector)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumeClaimsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumeClaimsByField", Resource: "persistentvolumeclaims", Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+		return d.next.ListPersistentVolumeClaimsByField(ctx, namespace, fieldSelector)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumeClaimsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumeClaimsByQuery", Resource: "persistentvolumeclaims", Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+		return d.next.ListPersistentVolumeClaimsByQuery(ctx, namespace, query)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumeClaimsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumeClaimsByLabelAllNamespaces", Resource: "persistentvolumeclaims", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+		return d.next.ListPersistentVolumeClaimsByLabelAllNamespaces(ctx, labelSelector, namespaces)
+	})
+}
+
+func (d *storageAPI) ListPersistentVolumeClaimsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.PersistentVolumeClaim, error) {
+	call := Call{API: "StorageAPI", Method: "ListPersistentVolumeClaimsByFieldAllNamespaces", Resource: "persistentvolumeclaims", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+		return d.next.ListPersistentVolumeClaimsByFieldAllNamespaces(ctx, fieldSelector, namespaces)
+	})
+}
+
+func (d *storageAPI) GetStorageClassByName(ctx context.Context, name string) (*storagev1.StorageClass, error) {
+	call := Call{API: "StorageAPI", Method: "GetStorageClassByName", Resource: "storageclasses", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*storagev1.StorageClass, error) {
+		return d.next.GetStorageClassByName(ctx, name)
+	})
+}
+
+func (d *storageAPI) ListStorageClassesByLabel(ctx context.Context, labelSelector string) ([]storagev1.StorageClass, error) {
+	call := Call{API: "StorageAPI", Method: "ListStorageClassesByLabel", Resource: "storageclasses", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.StorageClass, error) {
+		return d.next.ListStorageClassesByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *storageAPI) ListStorageClassesByField(ctx context.Context, fieldSelector string) ([]storagev1.StorageClass, error) {
+	call := Call{API: "StorageAPI", Method: "ListStorageClassesByField", Resource: "storageclasses", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.StorageClass, error) {
+		return d.next.ListStorageClassesByField(ctx, fieldSelector)
+	})
+}
+
+func (d *storageAPI) ListStorageClassesByQuery(ctx context.Context, query api.ListQuery) ([]storagev1.StorageClass, error) {
+	call := Call{API: "StorageAPI", Method: "ListStorageClassesByQuery", Resource: "storageclasses", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.StorageClass, error) {
+		return d.next.ListStorageClassesByQuery(ctx, query)
+	})
+}
+
+func (d *storageAPI) GetVolumeAttachmentByName(ctx context.Context, name string) (*storagev1.VolumeAttachment, error) {
+	call := Call{API: "StorageAPI", Method: "GetVolumeAttachmentByName", Resource: "volumeattachments", Verb: "get", Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*storagev1.VolumeAttachment, error) {
+		return d.next.GetVolumeAttachmentByName(ctx, name)
+	})
+}
+
+func (d *storageAPI) ListVolumeAttachmentsByLabel(ctx context.Context, labelSelector string) ([]storagev1.VolumeAttachment, error) {
+	call := Call{API: "StorageAPI", Method: "ListVolumeAttachmentsByLabel", Resource: "volumeattachments", Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.VolumeAttachment, error) {
+		return d.next.ListVolumeAttachmentsByLabel(ctx, labelSelector)
+	})
+}
+
+func (d *storageAPI) ListVolumeAttachmentsByField(ctx context.Context, fieldSelector string) ([]storagev1.VolumeAttachment, error) {
+	call := Call{API: "StorageAPI", Method: "ListVolumeAttachmentsByField", Resource: "volumeattachments", Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.VolumeAttachment, error) {
+		return d.next.ListVolumeAttachmentsByField(ctx, fieldSelector)
+	})
+}
+
+func (d *storageAPI) ListVolumeAttachmentsByQuery(ctx context.Context, query api.ListQuery) ([]storagev1.VolumeAttachment, error) {
+	call := Call{API: "StorageAPI", Method: "ListVolumeAttachmentsByQuery", Resource: "volumeattachments", Verb: "list", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]storagev1.VolumeAttachment, error) {
+		return d.next.ListVolumeAttachmentsByQuery(ctx, query)
+	})
+}
+
+func (d *storageAPI) GetVolumeForClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolume, error) {
+	call := Call{API: "StorageAPI", Method: "GetVolumeForClaim", Resource: "persistentvolumes", Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*corev1.PersistentVolume, error) {
+		return d.next.GetVolumeForClaim(ctx, namespace, name)
+	})
+}
+
+func (d *storageAPI) ListPodsForClaim(ctx context.Context, namespace, name string) ([]corev1.Pod, error) {
+	call := Call{API: "StorageAPI", Method: "ListPodsForClaim", Resource: "pods", Verb: "list", Namespace: namespace, Name: name}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]corev1.Pod, error) {
+		return d.next.ListPodsForClaim(ctx, namespace, name)
+	})
+}
+
+func (d *storageAPI) ListClaimBindings(ctx context.Context, namespace string) ([]api.ClaimBinding, error) {
+	call := Call{API: "StorageAPI", Method: "ListClaimBindings", Resource: "persistentvolumeclaims", Verb: "list", Namespace: namespace}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.ClaimBinding, error) {
+		return d.next.ListClaimBindings(ctx, namespace)
+	})
+}
+
+// customResourceAPI decorates an api.CustomResourceAPI with Hooks.
+type customResourceAPI struct {
+	next  api.CustomResourceAPI
+	hooks Hooks
+}
+
+// NewCustomResourceAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewCustomResourceAPI(next api.CustomResourceAPI, hooks Hooks) api.CustomResourceAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &customResourceAPI{next: next, hooks: hooks}
+}
+
+func (d *customResourceAPI) GetCustomResourceByName(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "GetCustomResourceByName", Resource: gvr.Resource, Verb: "get", Namespace: namespace, Name: name}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (*unstructured.Unstructured, error) {
+		return d.next.GetCustomResourceByName(ctx, gvr, namespace, name)
+	})
+}
+
+func (d *customResourceAPI) ListCustomResourcesByLabel(ctx context.Context, gvr schema.GroupVersionResource, namespace string, labelSelector string) ([]unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "ListCustomResourcesByLabel", Resource: gvr.Resource, Verb: "list", Namespace: namespace, LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]unstructured.Unstructured, error) {
+		return d.next.ListCustomResourcesByLabel(ctx, gvr, namespace, labelSelector)
+	})
+}
+
+func (d *customResourceAPI) ListCustomResourcesByField(ctx context.Context, gvr schema.GroupVersionResource, namespace string, fieldSelector string) ([]unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "ListCustomResourcesByField", Resource: gvr.Resource, Verb: "list", Namespace: namespace, FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]unstructured.Unstructured, error) {
+		return d.next.ListCustomResourcesByField(ctx, gvr, namespace, fieldSelector)
+	})
+}
+
+func (d *customResourceAPI) ListCustomResourcesByQuery(ctx context.Context, gvr schema.GroupVersionResource, namespace string, query api.ListQuery) ([]unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "ListCustomResourcesByQuery", Resource: gvr.Resource, Verb: "list", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]unstructured.Unstructured, error) {
+		return d.next.ListCustomResourcesByQuery(ctx, gvr, namespace, query)
+	})
+}
+
+func (d *customResourceAPI) ListCustomResourcesByLabelAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, labelSelector string, namespaces *api.NamespaceFilter) ([]unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "ListCustomResourcesByLabelAllNamespaces", Resource: gvr.Resource, Verb: "list", LabelSelector: labelSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]unstructured.Unstructured, error) {
+		return d.next.ListCustomResourcesByLabelAllNamespaces(ctx, gvr, labelSelector, namespaces)
+	})
+}
+
+func (d *customResourceAPI) ListCustomResourcesByFieldAllNamespaces(ctx context.Context, gvr schema.GroupVersionResource, fieldSelector string, namespaces *api.NamespaceFilter) ([]unstructured.Unstructured, error) {
+	call := Call{API: "CustomResourceAPI", Method: "ListCustomResourcesByFieldAllNamespaces", Resource: gvr.Resource, Verb: "list", FieldSelector: fieldSelector}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]unstructured.Unstructured, error) {
+		return d.next.ListCustomResourcesByFieldAllNamespaces(ctx, gvr, fieldSelector, namespaces)
+	})
+}
+
+// discoveryAPI decorates an api.DiscoveryAPI with Hooks.
+type discoveryAPI struct {
+	next  api.DiscoveryAPI
+	hooks Hooks
+}
+
+// NewDiscoveryAPI returns next decorated with hooks, or next itself when hooks are not
+// enabled.
+func NewDiscoveryAPI(next api.DiscoveryAPI, hooks Hooks) api.DiscoveryAPI {
+	if !hooks.Enabled() {
+		return next
+	}
+	return &discoveryAPI{next: next, hooks: hooks}
+}
+
+func (d *discoveryAPI) ListServerResources(ctx context.Context) ([]api.APIResource, error) {
+	call := Call{API: "DiscoveryAPI", Method: "ListServerResources", Resource: "apiresources", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.APIResource, error) {
+		return d.next.ListServerResources(ctx)
+	})
+}
+
+func (d *discoveryAPI) ListPreferredResources(ctx context.Context) ([]api.APIResource, error) {
+	call := Call{API: "DiscoveryAPI", Method: "ListPreferredResources", Resource: "apiresources", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.APIResource, error) {
+		return d.next.ListPreferredResources(ctx)
+	})
+}
+
+func (d *discoveryAPI) IsServed(ctx context.Context, gvr schema.GroupVersionResource) (bool, error) {
+	call := Call{API: "DiscoveryAPI", Method: "IsServed", Resource: "apiresources", Verb: "get"}
+	return get(ctx, d.hooks, call, func(ctx context.Context) (bool, error) {
+		return d.next.IsServed(ctx, gvr)
+	})
+}
+
+func (d *discoveryAPI) ListDeprecatedResources(ctx context.Context, targetVersion string) ([]api.DeprecatedResource, error) {
+	call := Call{API: "DiscoveryAPI", Method: "ListDeprecatedResources", Resource: "apiresources", Verb: "list"}
+	return list(ctx, d.hooks, call, func(ctx context.Context) ([]api.DeprecatedResource, error) {
+		return d.next.ListDeprecatedResources(ctx, targetVersion)
+	})
+}
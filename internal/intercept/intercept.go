@@ -0,0 +1,267 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+// Call describes a call to a method of a resource API.
+type Call struct {
+	// API is the name of the interface, e.g. "PodAPI".
+	API string
+	// Method is the name of the method, e.g. "GetPodByName".
+	Method string
+	// Resource is the plural, lowercase name of the Kubernetes resource the method is
+	// about, e.g. "pods", the resource of the GroupVersionResource for custom resources
+	// and "apiresources" for discovery.
+	Resource string
+	// Verb is "get", "list" or "watch".
+	Verb string
+	// Namespace is the namespace the call is restricted to, empty when it is not.
+	// For NamespaceAPI.GetNamespaceByName it is the requested namespace.
+	Namespace string
+	// Name is the name of the requested object, empty when the call is not about a
+	// single object.
+	Name string
+	// LabelSelector and FieldSelector are the selectors the call lists or watches by,
+	// empty when it takes none.
+	LabelSelector string
+	FieldSelector string
+	// Stream is set for Paged and Watch methods, whose results keep flowing after the
+	// method returned and must not have their context cancelled early.
+	Stream bool
+}
+
+// Invoker performs a call with ctx and returns its result.
+//
+// The result is the value returned by the method, except for Paged methods where it is
+// the number of items yielded, and Watch methods where it is the event channel.
+type Invoker func(ctx context.Context) (any, error)
+
+// Interceptor runs around a call. It may inspect or replace ctx, the result and the
+// error, or return without calling invoke to reject the call.
+type Interceptor func(ctx context.Context, call Call, invoke Invoker) (any, error)
+
+// Chain returns an Interceptor running interceptors in order, the first one outermost.
+// Nil interceptors are skipped; when all are nil, Chain returns nil.
+func Chain(interceptors ...Interceptor) Interceptor {
+	if !slices.ContainsFunc(interceptors, func(i Interceptor) bool { return i != nil }) {
+		return nil
+	}
+	return func(ctx context.Context, call Call, invoke Invoker) (any, error) {
+		next := invoke
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			if interceptors[i] == nil {
+				continue
+			}
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context) (any, error) {
+				return interceptor(ctx, call, inner)
+			}
+		}
+		return next(ctx)
+	}
+}
+
+// Filter reports whether obj, an item of the collection returned by call, is passed on
+// to the caller. obj is a pointer to a slice element, a Paged item or the object of a
+// watch event.
+type Filter func(call Call, obj any) bool
+
+// Hooks configures the decorators of this package.
+type Hooks struct {
+	// Intercept runs around every call. Nil performs calls as is.
+	Intercept Interceptor
+	// Filter removes items from the collections returned by List, Paged and Watch
+	// methods. Nil keeps every item. Single objects are never filtered.
+	Filter Filter
+}
+
+// Enabled reports whether h changes anything about a call.
+func (h Hooks) Enabled() bool {
+	return h.Intercept != nil || h.Filter != nil
+}
+
+// call runs invoke through the interceptor of h.
+func (h Hooks) call(ctx context.Context, call Call, invoke Invoker) (any, error) {
+	if h.Intercept == nil {
+		return invoke(ctx)
+	}
+	return h.Intercept(ctx, call, invoke)
+}
+
+// keep returns the items of h that pass its filter.
+func keep[T any](h Hooks, call Call, items []T) []T {
+	if h.Filter == nil || items == nil {
+		return items
+	}
+	kept := make([]T, 0, len(items))
+	for i := range items {
+		if h.Filter(call, &items[i]) {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept
+}
+
+// list runs a method returning a slice through h.
+func list[T any](ctx context.Context, h Hooks, call Call, fn func(ctx context.Context) ([]T, error)) ([]T, error) {
+	result, err := h.call(ctx, call, func(ctx context.Context) (any, error) {
+		items, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return keep(h, call, items), nil
+	})
+	items, _ := result.([]T)
+	return items, err
+}
+
+// get runs a method returning a single value through h.
+func get[T any](ctx context.Context, h Hooks, call Call, fn func(ctx context.Context) (T, error)) (T, error) {
+	result, err := h.call(ctx, call, func(ctx context.Context) (any, error) {
+		return fn(ctx)
+	})
+	value, _ := result.(T)
+	return value, err
+}
+
+// paged runs a Paged method through h. The call spans the whole iteration; an error
+// returned by the interceptor without invoking the method is yielded as the only item.
+func paged[T any](ctx context.Context, h Hooks, call Call, fn func(ctx context.Context) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		invoked := false
+		_, err := h.call(ctx, call, func(ctx context.Context) (any, error) {
+			invoked = true
+			count := 0
+			for item, err := range fn(ctx) {
+				if err != nil {
+					yield(item, err)
+					return count, err
+				}
+				if h.Filter != nil && !h.Filter(call, &item) {
+					continue
+				}
+				count++
+				if !yield(item, nil) {
+					break
+				}
+			}
+			return count, nil
+		})
+		if err != nil && !invoked {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// watch runs a Watch method through h, relaying only the events whose object passes
+// the filter of h. Bookmarks are always relayed.
+func watch[T any](ctx context.Context, h Hooks, call Call, fn func(ctx context.Context) (<-chan api.WatchEvent[T], error)) (<-chan api.WatchEvent[T], error) {
+	result, err := h.call(ctx, call, func(ctx context.Context) (any, error) {
+		events, err := fn(ctx)
+		if err != nil || h.Filter == nil {
+			return events, err
+		}
+
+		out := make(chan api.WatchEvent[T])
+		go func() {
+			defer close(out)
+			for event := range events {
+				if event.Type != api.EventBookmark && !h.Filter(call, event.Object) {
+					continue
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return (<-chan api.WatchEvent[T])(out), nil
+	})
+	events, _ := result.(<-chan api.WatchEvent[T])
+	return events, err
+}
+
+// Count returns the number of objects in result, as returned by an Invoker: the length
+// of a slice, the count of a Paged call or one for a single object. It reports false
+// for failed calls and watches.
+func Count(result any, err error) (int, bool) {
+	if err != nil || result == nil {
+		return 0, false
+	}
+	if count, ok := result.(int); ok {
+		return count, true
+	}
+	switch v := reflect.ValueOf(result); v.Kind() {
+	case reflect.Slice:
+		return v.Len(), true
+	case reflect.Chan:
+		return 0, false
+	default:
+		return 1, true
+	}
+}
+
+// ErrorClass returns the category of err: "not_found", "forbidden", "validation",
+// "timeout", "conflict", "canceled" or "error" for any other failure. It returns an
+// empty string for a nil err.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, api.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, api.ErrForbidden):
+		return "forbidden"
+	case errors.Is(err, api.ErrValidation):
+		return "validation"
+	case errors.Is(err, api.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, api.ErrConflict):
+		return "conflict"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
+
+// NamespaceOf returns the namespace obj, as passed to a Filter, belongs to. It reports
+// false for cluster-scoped objects and values not tied to a namespace.
+//
+// A Namespace belongs to itself. Results of queries spanning kinds belong to the
+// namespace of their main object: the claim of a ClaimBinding, the binding of a
+// BoundSubject or an EffectiveRule.
+func NamespaceOf(obj any) (string, bool) {
+	var namespace string
+	switch o := obj.(type) {
+	case *corev1.Namespace:
+		namespace = o.Name
+	case metav1.Object:
+		namespace = o.GetNamespace()
+	case *api.RBACRef:
+		namespace = o.Namespace
+	case *api.BoundSubject:
+		namespace = o.Binding.Namespace
+	case *api.EffectiveRule:
+		namespace = o.Binding.Namespace
+	case *api.ClaimBinding:
+		namespace = o.Claim.Namespace
+	case *api.JobRun:
+		namespace = o.Job.Namespace
+	case *api.ServiceAccountUsage:
+		namespace = o.ServiceAccount.Namespace
+	}
+	return namespace, namespace != ""
+}
@@ -0,0 +1,166 @@
+package intercept
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call Call, invoke Invoker) (any, error) {
+			order = append(order, name+":"+call.Method)
+			return invoke(ctx)
+		}
+	}
+
+	chained := Chain(record("outer"), nil, record("inner"))
+	result, err := chained(context.Background(), Call{Method: "GetPodByName"}, func(context.Context) (any, error) {
+		order = append(order, "invoke")
+		return "pod", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "pod", result)
+	assert.Equal(t, []string{"outer:GetPodByName", "inner:GetPodByName", "invoke"}, order)
+	assert.Nil(t, Chain(nil, nil))
+}
+
+func TestPaged(t *testing.T) {
+	pods := func(context.Context) iter.Seq2[corev1.Pod, error] {
+		return func(yield func(corev1.Pod, error) bool) {
+			for _, namespace := range []string{"default", "kube-system", "default"} {
+				if !yield(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}, nil) {
+					return
+				}
+			}
+		}
+	}
+	onlyDefault := func(_ Call, obj any) bool {
+		namespace, _ := NamespaceOf(obj)
+		return namespace == "default"
+	}
+
+	t.Run("Filtered", func(t *testing.T) {
+		var count any
+		hooks := Hooks{
+			Intercept: func(ctx context.Context, _ Call, invoke Invoker) (any, error) {
+				result, err := invoke(ctx)
+				count = result
+				return result, err
+			},
+			Filter: onlyDefault,
+		}
+
+		var namespaces []string
+		for pod, err := range paged(context.Background(), hooks, Call{}, pods) {
+			require.NoError(t, err)
+			namespaces = append(namespaces, pod.Namespace)
+		}
+
+		assert.Equal(t, []string{"default", "default"}, namespaces)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		rejected := errors.New("rejected")
+		hooks := Hooks{
+			Intercept: func(context.Context, Call, Invoker) (any, error) {
+				return nil, rejected
+			},
+		}
+
+		var errs []error
+		for _, err := range paged(context.Background(), hooks, Call{}, pods) {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], rejected)
+	})
+}
+
+func TestNamespaceOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		obj       any
+		namespace string
+		ok        bool
+	}{
+		{name: "Namespaced", obj: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, namespace: "default", ok: true},
+		{name: "Namespace", obj: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}}, namespace: "monitoring", ok: true},
+		{name: "ClusterScoped", obj: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}},
+		{name: "BoundSubject", obj: &api.BoundSubject{Binding: api.RBACRef{Namespace: "default"}}, namespace: "default", ok: true},
+		{name: "ClusterBinding", obj: &api.BoundSubject{Binding: api.RBACRef{Kind: "ClusterRoleBinding"}}},
+		{name: "Unknown", obj: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, ok := NamespaceOf(tt.obj)
+
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	events := make(<-chan api.WatchEvent[*corev1.Pod])
+
+	tests := []struct {
+		name     string
+		result   any
+		err      error
+		expected int
+		ok       bool
+	}{
+		{name: "Slice", result: []corev1.Pod{{}, {}}, expected: 2, ok: true},
+		{name: "Empty slice", result: []corev1.Pod{}, expected: 0, ok: true},
+		{name: "Paged", result: 5, expected: 5, ok: true},
+		{name: "Object", result: &corev1.Pod{}, expected: 1, ok: true},
+		{name: "Watch", result: events},
+		{name: "Failed", result: []corev1.Pod{{}}, err: errors.New("failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, ok := Count(tt.result, tt.err)
+
+			assert.Equal(t, tt.expected, count)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{err: nil, expected: ""},
+		{err: fmt.Errorf("failed to get pod: %w", api.ErrNotFound), expected: "not_found"},
+		{err: api.ErrNamespaceNotAllowed, expected: "forbidden"},
+		{err: api.ErrValidation, expected: "validation"},
+		{err: context.DeadlineExceeded, expected: "timeout"},
+		{err: api.ErrConflict, expected: "conflict"},
+		{err: context.Canceled, expected: "canceled"},
+		{err: errors.New("connection refused"), expected: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ErrorClass(tt.err))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package nsfilter
+
+import (
+	"github.com/kaudit/api"
+)
+
+// Keep returns the items of items belonging to a namespace matched by filter, reusing
+// the backing array of items. A nil filter keeps every item.
+func Keep[T any, P interface {
+	*T
+	GetNamespace() string
+}](items []T, filter *api.NamespaceFilter) []T {
+	if filter == nil {
+		return items
+	}
+
+	kept := items[:0]
+	for i := range items {
+		if filter.Match(P(&items[i]).GetNamespace()) {
+			kept = append(kept, items[i])
+		}
+	}
+	clear(items[len(kept):])
+	return kept
+}
@@ -0,0 +1,64 @@
+package nsfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+func pods(namespaces ...string) []corev1.Pod {
+	items := make([]corev1.Pod, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		items = append(items, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: namespace}})
+	}
+	return items
+}
+
+func namespacesOf(items []corev1.Pod) []string {
+	namespaces := make([]string, 0, len(items))
+	for _, item := range items {
+		namespaces = append(namespaces, item.Namespace)
+	}
+	return namespaces
+}
+
+func TestKeep(t *testing.T) {
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, []string{"team-b"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filter   *api.NamespaceFilter
+		expected []string
+	}{
+		{
+			name:     "Nil filter",
+			expected: []string{"default", "kube-system", "team-a", "team-b", "kube-public"},
+		},
+		{
+			name:     "Exclude",
+			filter:   excludeSystem,
+			expected: []string{"default", "team-a", "team-b"},
+		},
+		{
+			name:     "Include and exclude",
+			filter:   onlyTeams,
+			expected: []string{"team-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := Keep(pods("default", "kube-system", "team-a", "team-b", "kube-public"), tt.filter)
+
+			assert.Equal(t, tt.expected, namespacesOf(items))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package pager
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+// PageFunc fetches a single page of objects using the provided list options.
+//
+// It returns the objects of the page together with the continue token of the
+// list response. An empty token signals that the last page has been reached.
+type PageFunc[T any] func(ctx context.Context, opts metav1.ListOptions) ([]T, string, error)
+
+// Items returns an iterator over every object produced by fetch, following the
+// continue token from one page to the next.
+//
+// The opts.Limit value controls the page size. Each page is requested lazily, so
+// at most one page is held in memory at a time and breaking out of the loop stops
+// further requests.
+//
+// When a page cannot be fetched, the error is yielded once as the second value and
+// iteration stops. If the apiserver reports that the continue token expired
+// (410 Gone), the yielded error wraps api.ErrContinueExpired.
+func Items[T any](ctx context.Context, opts metav1.ListOptions, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, next, err := fetch(ctx, opts)
+			if err != nil {
+				if opts.Continue != "" && (apierrors.IsResourceExpired(err) || apierrors.IsGone(err)) {
+					err = fmt.Errorf("%w: %w", api.ErrContinueExpired, err)
+				}
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if next == "" {
+				return
+			}
+			opts.Continue = next
+		}
+	}
+}
+
+// Err returns an iterator that yields err once and stops.
+//
+// It is used to report validation failures from methods returning an iterator.
+func Err[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
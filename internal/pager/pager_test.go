@@ -0,0 +1,192 @@
+package pager
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+// pagesOf returns a PageFunc serving items in pages of opts.Limit elements,
+// encoding the offset of the next page in the continue token.
+func pagesOf(items []string, calls *[]metav1.ListOptions) PageFunc[string] {
+	return func(_ context.Context, opts metav1.ListOptions) ([]string, string, error) {
+		*calls = append(*calls, opts)
+
+		offset := 0
+		if opts.Continue != "" {
+			var err error
+			offset, err = strconv.Atoi(opts.Continue)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		end := min(offset+int(opts.Limit), len(items))
+		next := ""
+		if end < len(items) {
+			next = strconv.Itoa(end)
+		}
+		return items[offset:end], next, nil
+	}
+}
+
+func TestItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     []string
+		limit     int64
+		wantCalls int
+	}{
+		{
+			name:      "Single page",
+			items:     []string{"a", "b"},
+			limit:     5,
+			wantCalls: 1,
+		},
+		{
+			name:      "Exact multiple of page size",
+			items:     []string{"a", "b", "c", "d"},
+			limit:     2,
+			wantCalls: 2,
+		},
+		{
+			name:      "Partial last page",
+			items:     []string{"a", "b", "c", "d", "e"},
+			limit:     2,
+			wantCalls: 3,
+		},
+		{
+			name:      "Empty result",
+			items:     nil,
+			limit:     2,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []metav1.ListOptions
+			opts := metav1.ListOptions{LabelSelector: "app=test", Limit: tt.limit}
+
+			var got []string
+			for item, err := range Items(context.Background(), opts, pagesOf(tt.items, &calls)) {
+				require.NoError(t, err)
+				got = append(got, item)
+			}
+
+			assert.Equal(t, tt.items, got)
+			assert.Len(t, calls, tt.wantCalls)
+			for _, call := range calls {
+				assert.Equal(t, "app=test", call.LabelSelector)
+				assert.Equal(t, tt.limit, call.Limit)
+			}
+		})
+	}
+}
+
+func TestItems_StopsOnBreak(t *testing.T) {
+	var calls []metav1.ListOptions
+	opts := metav1.ListOptions{Limit: 2}
+
+	var got []string
+	for item, err := range Items(context.Background(), opts, pagesOf([]string{"a", "b", "c", "d"}, &calls)) {
+		require.NoError(t, err)
+		got = append(got, item)
+		if len(got) == 1 {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"a"}, got)
+	assert.Len(t, calls, 1)
+}
+
+func TestItems_Errors(t *testing.T) {
+	gone := apierrors.NewResourceExpired("too old resource version")
+
+	tests := []struct {
+		name        string
+		failOnPage  int
+		err         error
+		wantItems   int
+		wantExpired bool
+	}{
+		{
+			name:        "First page fails",
+			failOnPage:  1,
+			err:         errors.New("connection refused"),
+			wantItems:   0,
+			wantExpired: false,
+		},
+		{
+			name:        "Expired token on first page is not a continue error",
+			failOnPage:  1,
+			err:         gone,
+			wantItems:   0,
+			wantExpired: false,
+		},
+		{
+			name:        "Expired token on later page",
+			failOnPage:  2,
+			err:         gone,
+			wantItems:   2,
+			wantExpired: true,
+		},
+		{
+			name:        "Wrapped expired token on later page",
+			failOnPage:  2,
+			err:         errors.Join(errors.New("failed to list"), gone),
+			wantItems:   2,
+			wantExpired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := 0
+			fetch := func(_ context.Context, _ metav1.ListOptions) ([]string, string, error) {
+				page++
+				if page == tt.failOnPage {
+					return nil, "", tt.err
+				}
+				return []string{"x", "y"}, "next", nil
+			}
+
+			var items int
+			var gotErr error
+			for _, err := range Items(context.Background(), metav1.ListOptions{Limit: 2}, fetch) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				items++
+			}
+
+			require.Error(t, gotErr)
+			require.ErrorIs(t, gotErr, tt.err)
+			assert.Equal(t, tt.wantExpired, errors.Is(gotErr, api.ErrContinueExpired))
+			assert.Equal(t, tt.wantItems, items)
+		})
+	}
+}
+
+func TestErr(t *testing.T) {
+	wantErr := errors.New("invalid input")
+
+	var yielded int
+	for item, err := range Err[string](wantErr) {
+		yielded++
+		assert.Empty(t, item)
+		assert.Equal(t, wantErr, err)
+	}
+
+	assert.Equal(t, 1, yielded)
+}
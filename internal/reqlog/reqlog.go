@@ -0,0 +1,67 @@
+package reqlog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Request describes a request sent to the apiserver, or a read served from a cache.
+type Request struct {
+	// Verb is "get", "list" or "watch".
+	Verb string
+	// Resource is the plural, lowercase name of the resource, e.g. "pods".
+	Resource      string
+	Namespace     string
+	Name          string
+	LabelSelector string
+	FieldSelector string
+	// Cached marks reads served from an informer cache instead of the apiserver.
+	Cached bool
+}
+
+// Succeeded logs req, sent at start, at debug level together with the number of items
+// it returned. The count is left out for watches.
+func Succeeded(ctx context.Context, logger *slog.Logger, req Request, start time.Time, items int) {
+	if logger == nil || !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := req.attrs(start)
+	if req.Verb != "watch" {
+		attrs = append(attrs, slog.Int("items", items))
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "kubernetes request", attrs...)
+}
+
+// Failed logs req, sent at start, at warn level together with err.
+func Failed(ctx context.Context, logger *slog.Logger, req Request, start time.Time, err error) {
+	if logger == nil || !logger.Enabled(ctx, slog.LevelWarn) {
+		return
+	}
+
+	attrs := append(req.attrs(start), slog.Any("error", err))
+	logger.LogAttrs(ctx, slog.LevelWarn, "kubernetes request failed", attrs...)
+}
+
+// attrs returns the attributes describing r, leaving out empty ones.
+func (r Request) attrs(start time.Time) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("verb", r.Verb),
+		slog.String("resource", r.Resource),
+	}
+	for _, attr := range []slog.Attr{
+		slog.String("namespace", r.Namespace),
+		slog.String("name", r.Name),
+		slog.String("labelSelector", r.LabelSelector),
+		slog.String("fieldSelector", r.FieldSelector),
+	} {
+		if attr.Value.String() != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	if r.Cached {
+		attrs = append(attrs, slog.Bool("cached", true))
+	}
+	return append(attrs, slog.Duration("duration", time.Since(start)))
+}
@@ -0,0 +1,100 @@
+package reqlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// records returns a logger at level writing into the returned buffer, without time.
+func records(level slog.Level) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	return slog.New(handler), &buf
+}
+
+func TestSucceeded(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		expected string
+	}{
+		{
+			name:     "Get",
+			req:      Request{Verb: "get", Resource: "pods", Namespace: "default", Name: "web"},
+			expected: "level=DEBUG msg=\"kubernetes request\" verb=get resource=pods namespace=default name=web items=1\n",
+		},
+		{
+			name:     "List",
+			req:      Request{Verb: "list", Resource: "namespaces", LabelSelector: "team=payments"},
+			expected: "level=DEBUG msg=\"kubernetes request\" verb=list resource=namespaces labelSelector=\"team=payments\" items=1\n",
+		},
+		{
+			name:     "Watch",
+			req:      Request{Verb: "watch", Resource: "pods", Namespace: "default", FieldSelector: "status.phase=Running"},
+			expected: "level=DEBUG msg=\"kubernetes request\" verb=watch resource=pods namespace=default fieldSelector=\"status.phase=Running\"\n",
+		},
+		{
+			name:     "Cached",
+			req:      Request{Verb: "get", Resource: "pods", Namespace: "default", Name: "web", Cached: true},
+			expected: "level=DEBUG msg=\"kubernetes request\" verb=get resource=pods namespace=default name=web cached=true items=1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, buf := records(slog.LevelDebug)
+
+			Succeeded(context.Background(), logger, tt.req, time.Now(), 1)
+
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	logger, buf := records(slog.LevelDebug)
+
+	Failed(context.Background(), logger, Request{Verb: "get", Resource: "pods", Namespace: "default", Name: "web"}, time.Now(), errors.New("pods \"web\" not found"))
+
+	assert.Equal(t, "level=WARN msg=\"kubernetes request failed\" verb=get resource=pods namespace=default name=web error=\"pods \\\"web\\\" not found\"\n", buf.String())
+}
+
+func TestDuration(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	Succeeded(context.Background(), logger, Request{Verb: "get", Resource: "pods"}, time.Now().Add(-time.Second), 1)
+
+	require.Contains(t, buf.String(), `"duration":`)
+	assert.NotContains(t, buf.String(), `"duration":0`)
+}
+
+func TestDisabled(t *testing.T) {
+	req := Request{Verb: "get", Resource: "pods"}
+
+	// A nil logger logs nothing.
+	Succeeded(context.Background(), nil, req, time.Now(), 1)
+	Failed(context.Background(), nil, req, time.Now(), errors.New("failed"))
+
+	// Records below the level of the handler are dropped.
+	logger, buf := records(slog.LevelInfo)
+	Succeeded(context.Background(), logger, req, time.Now(), 1)
+	assert.Empty(t, buf.String())
+	Failed(context.Background(), logger, req, time.Now(), errors.New("failed"))
+	assert.Contains(t, buf.String(), "level=WARN")
+}
@@ -0,0 +1,83 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+)
+
+// sleep waits for d or until ctx is done. It is replaced in tests.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error policy does not retry, or
+// policy.MaxAttempts attempts have been made, waiting between attempts as policy
+// prescribes.
+//
+// fn must be an idempotent read. When ctx is done while waiting, the error of the last
+// attempt is returned. Responses client-go has already retried on its own, those with a
+// Retry-After header, are returned without further attempts.
+func Do[T any](ctx context.Context, policy api.RetryPolicy, fn func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(policy, err) {
+			return result, err
+		}
+
+		if sleep(ctx, delay(policy, attempt)) != nil {
+			return result, err
+		}
+	}
+}
+
+// retryable reports whether err is an apiserver response with one of the retryable
+// status codes of policy that the client has not retried already.
+func retryable(policy api.RetryPolicy, err error) bool {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return false
+	}
+	return slices.Contains(policy.RetryableStatusCodes, int(status.Status().Code)) && !retriedByClient(status.Status())
+}
+
+// retriedByClient reports whether client-go has already retried the response of status:
+// its REST client waits for and retries the 429 and 5xx responses carrying a
+// Retry-After header, up to 10 times by default, and only returns the last one.
+func retriedByClient(status metav1.Status) bool {
+	if status.Details == nil || status.Details.RetryAfterSeconds <= 0 {
+		return false
+	}
+	return status.Code == http.StatusTooManyRequests || status.Code >= http.StatusInternalServerError
+}
+
+// delay returns how long to wait after the given failed attempt: the exponential
+// backoff with jitter.
+func delay(policy api.RetryPolicy, attempt int) time.Duration {
+	d := float64(policy.InitialBackoff)
+	for range attempt - 1 {
+		d *= max(policy.Multiplier, 1)
+	}
+	if policy.MaxBackoff > 0 {
+		d = min(d, float64(policy.MaxBackoff))
+	}
+	d += d * policy.Jitter * rand.Float64()
+
+	return time.Duration(d)
+}
@@ -0,0 +1,189 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kaudit/api"
+)
+
+// recordSleeps replaces sleep for the duration of the test and records the requested
+// delays instead of waiting.
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+
+	var delays []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+
+	return &delays
+}
+
+// failing returns a function failing with errs in turn before returning "ok", and the
+// number of times it was called.
+func failing(errs ...error) (func() (string, error), *int) {
+	calls := 0
+	return func() (string, error) {
+		calls++
+		if calls <= len(errs) {
+			return "", errs[calls-1]
+		}
+		return "ok", nil
+	}, &calls
+}
+
+func TestDo(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	throttled := apierrors.NewTooManyRequests("slow down", 0)
+	unavailable := apierrors.NewServiceUnavailable("apiserver restarting")
+
+	policy := api.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       100 * time.Millisecond,
+		MaxBackoff:           time.Second,
+		Multiplier:           2,
+		RetryableStatusCodes: []int{429, 503},
+	}
+
+	tests := []struct {
+		name          string
+		policy        api.RetryPolicy
+		errs          []error
+		expectedCalls int
+		expectedDelay []time.Duration
+		wantErr       error
+	}{
+		{
+			name:          "Succeeds at once",
+			policy:        policy,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Succeeds after retries",
+			policy:        policy,
+			errs:          []error{throttled, unavailable},
+			expectedCalls: 3,
+			expectedDelay: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:          "Gives up after max attempts",
+			policy:        policy,
+			errs:          []error{unavailable, unavailable, unavailable, unavailable},
+			expectedCalls: 3,
+			expectedDelay: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			wantErr:       unavailable,
+		},
+		{
+			name:          "Status code not retryable",
+			policy:        policy,
+			errs:          []error{apierrors.NewNotFound(pods, "web")},
+			expectedCalls: 1,
+			wantErr:       apierrors.NewNotFound(pods, "web"),
+		},
+		{
+			name:          "Not an apiserver response",
+			policy:        policy,
+			errs:          []error{errors.New("connection refused")},
+			expectedCalls: 1,
+			wantErr:       errors.New("connection refused"),
+		},
+		{
+			name:          "Zero policy does not retry",
+			policy:        api.RetryPolicy{},
+			errs:          []error{throttled},
+			expectedCalls: 1,
+			wantErr:       throttled,
+		},
+		{
+			name:          "Retry-After was retried by client-go",
+			policy:        policy,
+			errs:          []error{apierrors.NewTooManyRequests("slow down", 2)},
+			expectedCalls: 1,
+			wantErr:       apierrors.NewTooManyRequests("slow down", 2),
+		},
+		{
+			name: "Backoff is capped",
+			policy: api.RetryPolicy{
+				MaxAttempts:          4,
+				InitialBackoff:       time.Second,
+				MaxBackoff:           3 * time.Second,
+				Multiplier:           4,
+				RetryableStatusCodes: []int{503},
+			},
+			errs:          []error{unavailable, unavailable, unavailable},
+			expectedCalls: 4,
+			expectedDelay: []time.Duration{time.Second, 3 * time.Second, 3 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays := recordSleeps(t)
+			fn, calls := failing(tt.errs...)
+
+			result, err := Do(context.Background(), tt.policy, fn)
+
+			assert.Equal(t, tt.expectedCalls, *calls)
+			assert.Equal(t, tt.expectedDelay, *delays)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", result)
+		})
+	}
+}
+
+func TestDo_Jitter(t *testing.T) {
+	delays := recordSleeps(t)
+	policy := api.RetryPolicy{
+		MaxAttempts:          50,
+		InitialBackoff:       100 * time.Millisecond,
+		Multiplier:           1,
+		Jitter:               0.5,
+		RetryableStatusCodes: []int{503},
+	}
+	errs := make([]error, 49)
+	for i := range errs {
+		errs[i] = apierrors.NewServiceUnavailable("apiserver restarting")
+	}
+	fn, _ := failing(errs...)
+
+	_, err := Do(context.Background(), policy, fn)
+	require.NoError(t, err)
+
+	require.Len(t, *delays, 49)
+	distinct := make(map[time.Duration]bool)
+	for _, d := range *delays {
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+		distinct[d] = true
+	}
+	assert.Greater(t, len(distinct), 1)
+}
+
+func TestDo_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	unavailable := apierrors.NewServiceUnavailable("apiserver restarting")
+	fn, calls := failing(unavailable, unavailable)
+
+	_, err := Do(ctx, api.DefaultRetryPolicy(), fn)
+
+	require.Error(t, err)
+	assert.True(t, apierrors.IsServiceUnavailable(err))
+	assert.Equal(t, 1, *calls)
+}
@@ -0,0 +1,34 @@
+package throttle
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kaudit/api"
+)
+
+// Do sends the request fn once limiter admits it, holding an in-flight slot until fn
+// returns. A nil limiter admits the request immediately.
+func Do[T any](ctx context.Context, limiter *api.RateLimiter, fn func() (T, error)) (T, error) {
+	release, err := limiter.Acquire(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer release()
+
+	return fn()
+}
+
+// Watch wraps open so that every watch it opens, re-opened ones included, is admitted
+// by limiter first. The in-flight slot is freed once the watch is established rather
+// than held for its lifetime.
+func Watch(limiter *api.RateLimiter, open func(context.Context, metav1.ListOptions) (watch.Interface, error)) func(context.Context, metav1.ListOptions) (watch.Interface, error) {
+	return func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		return Do(ctx, limiter, func() (watch.Interface, error) {
+			return open(ctx, opts)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kaudit/api"
+)
+
+func TestDo(t *testing.T) {
+	failure := errors.New("apiserver unreachable")
+
+	tests := []struct {
+		name        string
+		limiter     *api.RateLimiter
+		result      string
+		err         error
+		expectedErr error
+	}{
+		{
+			name:   "Nil limiter",
+			result: "ok",
+		},
+		{
+			name:    "Limited",
+			limiter: api.NewRateLimiter(api.RateLimit{QPS: 100, Burst: 1, MaxInFlight: 1}),
+			result:  "ok",
+		},
+		{
+			name:        "Request error",
+			limiter:     api.NewRateLimiter(api.RateLimit{MaxInFlight: 1}),
+			err:         failure,
+			expectedErr: failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 3 {
+				result, err := Do(context.Background(), tt.limiter, func() (string, error) {
+					return tt.result, tt.err
+				})
+
+				if tt.expectedErr != nil {
+					require.ErrorIs(t, err, tt.expectedErr)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.result, result)
+			}
+		})
+	}
+}
+
+func TestDo_NotAdmitted(t *testing.T) {
+	limiter := api.NewRateLimiter(api.RateLimit{MaxInFlight: 1})
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	called := false
+	_, err = Do(ctx, limiter, func() (string, error) {
+		called = true
+		return "ok", nil
+	})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called)
+}
+
+func TestWatch(t *testing.T) {
+	limiter := api.NewRateLimiter(api.RateLimit{MaxInFlight: 1})
+	fake := watch.NewFake()
+
+	opened := 0
+	open := Watch(limiter, func(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		opened++
+		assert.Equal(t, "app=web", opts.LabelSelector)
+		return fake, nil
+	})
+
+	ctx, stats := api.WithRequestStats(context.Background())
+	for range 2 {
+		// The slot is freed once the watch is open, so the second one is admitted too.
+		w, err := open(ctx, metav1.ListOptions{LabelSelector: "app=web"})
+		require.NoError(t, err)
+		assert.Equal(t, fake, w)
+	}
+
+	assert.Equal(t, 2, opened)
+	assert.Equal(t, 2, stats.Requests())
+}
@@ -0,0 +1,156 @@
+package watcher
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kaudit/api"
+)
+
+const (
+	// initialRetryDelay is the delay before the first attempt to re-open a watch
+	// after the apiserver refused or failed the previous one.
+	initialRetryDelay = 250 * time.Millisecond
+	// maxRetryDelay caps the exponential backoff between failed re-open attempts,
+	// and between watches closed or failed by the apiserver.
+	maxRetryDelay = 30 * time.Second
+)
+
+// OpenFunc opens a watch using the provided list options.
+type OpenFunc func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+
+// Watch opens a watch with open and returns a channel of typed events.
+//
+// The first watch is opened synchronously and its error, if any, is returned to the
+// caller. Afterwards the watch is kept alive in the background: whenever the result
+// channel closes or the apiserver reports an error, the watch is re-opened from the
+// resourceVersion of the last observed event. If that resourceVersion is too old
+// (410 Gone), the watch restarts from the current state, which replays every
+// existing object as an EventAdded. Otherwise, whether the apiserver closed the watch
+// or reported an error such as a 429 or a 500, the watch is re-opened after an
+// exponential backoff, reset once an event is observed again, so that a watch the
+// apiserver keeps closing at once is not re-opened in a tight loop.
+//
+// The returned channel is closed once ctx is done.
+func Watch[T runtime.Object](ctx context.Context, opts metav1.ListOptions, open OpenFunc) (<-chan api.WatchEvent[T], error) {
+	opts.AllowWatchBookmarks = true
+
+	w, err := open(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan api.WatchEvent[T])
+	go func() {
+		defer close(out)
+
+		delay := initialRetryDelay
+		for {
+			var observed bool
+			var err error
+			opts.ResourceVersion, observed, err = forward(ctx, w, out, opts.ResourceVersion)
+			w.Stop()
+			if observed {
+				delay = initialRetryDelay
+			}
+
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				opts.ResourceVersion = ""
+			} else {
+				// The new watch may be closed or fail the same way at once, so back off
+				if !sleep(ctx, delay) {
+					return
+				}
+				delay = min(delay*2, maxRetryDelay)
+			}
+
+			w = reopen(ctx, &opts, open)
+			if w == nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// forward relays events from w to out until the result channel closes, the apiserver
+// reports an error or ctx is done.
+//
+// It returns the resourceVersion of the last observed event, falling back to
+// resourceVersion when no event carried one, whether any event was observed, and the
+// error the apiserver reported, if any.
+func forward[T runtime.Object](ctx context.Context, w watch.Interface, out chan<- api.WatchEvent[T], resourceVersion string) (string, bool, error) {
+	observed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, observed, nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, observed, nil
+			}
+
+			if event.Type == watch.Error {
+				return resourceVersion, observed, apierrors.FromObject(event.Object)
+			}
+
+			obj, ok := event.Object.(T)
+			if !ok {
+				continue
+			}
+			observed = true
+			if accessor, err := meta.Accessor(obj); err == nil && accessor.GetResourceVersion() != "" {
+				resourceVersion = accessor.GetResourceVersion()
+			}
+
+			select {
+			case out <- api.WatchEvent[T]{Type: api.EventType(event.Type), Object: obj}:
+			case <-ctx.Done():
+				return resourceVersion, observed, nil
+			}
+		}
+	}
+}
+
+// reopen re-establishes the watch, backing off exponentially while the apiserver
+// refuses it. A resourceVersion that has become too old is dropped so the next
+// attempt starts from the current state.
+//
+// It returns nil once ctx is done.
+func reopen(ctx context.Context, opts *metav1.ListOptions, open OpenFunc) watch.Interface {
+	delay := initialRetryDelay
+	for ctx.Err() == nil {
+		w, err := open(ctx, *opts)
+		if err == nil {
+			return w
+		}
+		if opts.ResourceVersion != "" && (apierrors.IsResourceExpired(err) || apierrors.IsGone(err)) {
+			opts.ResourceVersion = ""
+			continue
+		}
+
+		sleep(ctx, delay)
+		delay = min(delay*2, maxRetryDelay)
+	}
+	return nil
+}
+
+// sleep waits for d, and reports false if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,272 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/kaudit/api"
+)
+
+// opener hands out one fake watcher per call to open and records the options
+// each watch was opened with. When failWith is set, every watcher reports it as an
+// Error event right away; when closeAtOnce is set, every watcher is closed right away.
+type opener struct {
+	mu          sync.Mutex
+	watchers    []*watch.FakeWatcher
+	calls       []metav1.ListOptions
+	errs        []error
+	failWith    *metav1.Status
+	closeAtOnce bool
+}
+
+func (o *opener) open(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.calls = append(o.calls, opts)
+	if len(o.errs) > 0 {
+		err := o.errs[0]
+		o.errs = o.errs[1:]
+		return nil, err
+	}
+
+	w := watch.NewFakeWithChanSize(16, false)
+	if o.failWith != nil {
+		w.Error(o.failWith)
+	}
+	if o.closeAtOnce {
+		w.Stop()
+	}
+	o.watchers = append(o.watchers, w)
+	return w, nil
+}
+
+func (o *opener) callCount() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.calls)
+}
+
+func (o *opener) watcher(t *testing.T, i int) *watch.FakeWatcher {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		return len(o.watchers) > i
+	}, 5*time.Second, 10*time.Millisecond)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.watchers[i]
+}
+
+func (o *opener) call(i int) metav1.ListOptions {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.calls[i]
+}
+
+func pod(name, resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: resourceVersion,
+		},
+	}
+}
+
+func receive(t *testing.T, events <-chan api.WatchEvent[*corev1.Pod]) api.WatchEvent[*corev1.Pod] {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "event channel closed unexpectedly")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for event")
+	}
+	return api.WatchEvent[*corev1.Pod]{}
+}
+
+func TestWatch_ForwardsTypedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	o := &opener{}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{LabelSelector: "app=test"}, o.open)
+	require.NoError(t, err)
+
+	w := o.watcher(t, 0)
+	w.Add(pod("pod-1", "1"))
+	w.Modify(pod("pod-1", "2"))
+	w.Action(watch.Bookmark, pod("", "3"))
+	w.Delete(pod("pod-1", "4"))
+
+	tests := []struct {
+		wantType api.EventType
+		wantRV   string
+	}{
+		{api.EventAdded, "1"},
+		{api.EventModified, "2"},
+		{api.EventBookmark, "3"},
+		{api.EventDeleted, "4"},
+	}
+	for _, tt := range tests {
+		event := receive(t, events)
+		assert.Equal(t, tt.wantType, event.Type)
+		assert.Equal(t, tt.wantRV, event.Object.ResourceVersion)
+	}
+
+	first := o.call(0)
+	assert.Equal(t, "app=test", first.LabelSelector)
+	assert.True(t, first.AllowWatchBookmarks)
+}
+
+func TestWatch_ReconnectsFromLastResourceVersion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	o := &opener{}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{}, o.open)
+	require.NoError(t, err)
+
+	w := o.watcher(t, 0)
+	w.Add(pod("pod-1", "10"))
+	receive(t, events)
+
+	// Simulate a dropped connection
+	w.Stop()
+
+	w = o.watcher(t, 1)
+	assert.Equal(t, "10", o.call(1).ResourceVersion)
+
+	w.Add(pod("pod-2", "11"))
+	event := receive(t, events)
+	assert.Equal(t, "pod-2", event.Object.Name)
+}
+
+func TestWatch_RestartsWhenResourceVersionExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	o := &opener{}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{}, o.open)
+	require.NoError(t, err)
+
+	w := o.watcher(t, 0)
+	w.Add(pod("pod-1", "10"))
+	receive(t, events)
+
+	// The apiserver compacted the history past resourceVersion 10
+	status := apierrors.NewResourceExpired("too old resource version").Status()
+	w.Error(&status)
+
+	o.watcher(t, 1)
+	assert.Empty(t, o.call(1).ResourceVersion)
+}
+
+func TestWatch_RetriesFailedReopen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	o := &opener{}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{}, o.open)
+	require.NoError(t, err)
+
+	w := o.watcher(t, 0)
+	w.Add(pod("pod-1", "5"))
+	receive(t, events)
+
+	o.mu.Lock()
+	o.errs = []error{errors.New("connection refused")}
+	o.mu.Unlock()
+	w.Stop()
+
+	o.watcher(t, 1)
+	assert.Equal(t, "5", o.call(1).ResourceVersion)
+	assert.Equal(t, "5", o.call(2).ResourceVersion)
+}
+
+func TestWatch_BacksOffAfterErrorEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Every watch fails at once with a server error
+	status := apierrors.NewInternalError(errors.New("etcd unavailable")).Status()
+	o := &opener{failWith: &status}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{ResourceVersion: "7"}, o.open)
+	require.NoError(t, err)
+
+	// Re-opened after 250ms, then 500ms: three opens at most within 700ms
+	time.Sleep(700 * time.Millisecond)
+	calls := o.callCount()
+	assert.GreaterOrEqual(t, calls, 2)
+	assert.LessOrEqual(t, calls, 3)
+	assert.Equal(t, "7", o.call(calls-1).ResourceVersion)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatch_BacksOffAfterCleanCloses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Every watch is closed by the server without an event
+	o := &opener{closeAtOnce: true}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{ResourceVersion: "7"}, o.open)
+	require.NoError(t, err)
+
+	// Re-opened after 250ms, then 500ms: three opens at most within 700ms
+	time.Sleep(700 * time.Millisecond)
+	calls := o.callCount()
+	assert.GreaterOrEqual(t, calls, 2)
+	assert.LessOrEqual(t, calls, 3)
+	assert.Equal(t, "7", o.call(calls-1).ResourceVersion)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatch_InitialError(t *testing.T) {
+	wantErr := errors.New("forbidden")
+	o := &opener{errs: []error{wantErr}}
+
+	events, err := Watch[*corev1.Pod](context.Background(), metav1.ListOptions{}, o.open)
+
+	require.ErrorIs(t, err, wantErr)
+	assert.Nil(t, events)
+}
+
+func TestWatch_ClosesOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	o := &opener{}
+	events, err := Watch[*corev1.Pod](ctx, metav1.ListOptions{}, o.open)
+	require.NoError(t, err)
+
+	w := o.watcher(t, 0)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "event channel was not closed")
+	}
+	assert.True(t, w.IsStopped())
+}
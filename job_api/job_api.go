@@ -0,0 +1,290 @@
+package jobapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// jobNameLabel is the label the job controller sets on every pod it creates,
+// holding the name of the owning Job. Unlike batch.kubernetes.io/job-name it is
+// also set by clusters older than Kubernetes 1.27.
+const jobNameLabel = "job-name"
+
+// JobAPI provides high-level methods for retrieving Kubernetes jobs.
+type JobAPI struct {
+	client  kubernetes.Interface
+	pods    api.PodAPI
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a JobAPI.
+type Option func(*JobAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(j *JobAPI) {
+		j.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(j *JobAPI) {
+		j.logger = logger
+	}
+}
+
+// NewJobAPI creates a new JobAPI instance using the provided client.
+//
+// The pods API is used to resolve the pods created by a Job, see ListPodsForJob.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewJobAPI(client kubernetes.Interface, pods api.PodAPI, opts ...Option) *JobAPI {
+	j := &JobAPI{
+		client: client,
+		pods:   pods,
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// GetJobByName retrieves a specific Job by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the job (must be non-empty).
+//   - name: Name of the job (must be non-empty).
+//
+// Returns the matched *batchv1.Job or an error if not found or invalid.
+func (j *JobAPI) GetJobByName(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, name, "name", "invalid job name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "jobs", Namespace: namespace, Name: name}
+	start := time.Now()
+	job, err := throttle.Do(ctx, j.limiter, func() (*batchv1.Job, error) {
+		return j.client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", namespace, name, fmt.Sprintf("failed to get job %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, 1)
+
+	return job, nil
+}
+
+// ListJobsByLabel lists jobs by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching jobs or an error.
+func (j *JobAPI) ListJobsByLabel(ctx context.Context, namespace string, labelSelector string) ([]batchv1.Job, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, j.limiter, func() (*batchv1.JobList, error) {
+		return j.client.BatchV1().Jobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", namespace, "", fmt.Sprintf("failed to list jobs by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListJobsByField lists jobs by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching jobs or an error.
+func (j *JobAPI) ListJobsByField(ctx context.Context, namespace string, fieldSelector string) ([]batchv1.Job, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, j.limiter, func() (*batchv1.JobList, error) {
+		return j.client.BatchV1().Jobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", namespace, "", fmt.Sprintf("failed to list jobs by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListJobsByLabelAllNamespaces lists jobs by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching jobs or an error.
+func (j *JobAPI) ListJobsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]batchv1.Job, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Job", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, j.limiter, func() (*batchv1.JobList, error) {
+		return j.client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", "", "", "failed to list jobs by label in all namespaces", err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListJobsByFieldAllNamespaces lists jobs by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching jobs or an error.
+func (j *JobAPI) ListJobsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]batchv1.Job, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Job", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, j.limiter, func() (*batchv1.JobList, error) {
+		return j.client.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", "", "", "failed to list jobs by field in all namespaces", err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListJobsByQuery lists jobs by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching jobs, at most query.Limit when set, or an error.
+func (j *JobAPI) ListJobsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]batchv1.Job, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Job", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "jobs", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, j.limiter, func() (*batchv1.JobList, error) {
+		return j.client.BatchV1().Jobs(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Job", namespace, "", fmt.Sprintf("failed to list jobs by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, j.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, j.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPodsForJob lists the pods created by a Job.
+//
+// Pods are matched on the job-name label set by the job controller, through the
+// PodAPI the JobAPI was created with.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the job (must be non-empty).
+//   - name: Name of the job (must be non-empty).
+//
+// Returns the pods of the job, which may be empty once they have been cleaned up, or an error.
+func (j *JobAPI) ListPodsForJob(ctx context.Context, namespace, name string) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Job", namespace, name, "name", "invalid job name", err)
+	}
+
+	pods, err := j.pods.ListPodsByLabel(ctx, namespace, jobNameLabel+"="+name)
+	if err != nil {
+		return nil, api.NewResourceError("Job", namespace, name, fmt.Sprintf("failed to list pods for job %q in namespace %q", name, namespace), err)
+	}
+
+	return pods, nil
+}
@@ -0,0 +1,121 @@
+package k8sapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/intercept"
+)
+
+// instrumentationName names the tracer of the resource APIs.
+const instrumentationName = "github.com/kaudit/api"
+
+// hooks returns the hooks decorating the resource APIs as configured by c.
+func (c config) hooks() intercept.Hooks {
+	hooks := intercept.Hooks{
+		Intercept: intercept.Chain(
+			tracingInterceptor(c.tracerProvider),
+			allowlistInterceptor(c.allowlist, c.logger),
+			timeoutInterceptor(c.timeout),
+		),
+	}
+	if c.allowlist != nil {
+		hooks.Filter = allowlistFilter(c.allowlist)
+	}
+	return hooks
+}
+
+// allowlistInterceptor rejects calls addressing a namespace missing from allowlist. It
+// returns nil when allowlist is nil.
+func allowlistInterceptor(allowlist map[string]bool, logger *slog.Logger) intercept.Interceptor {
+	if allowlist == nil {
+		return nil
+	}
+	return func(ctx context.Context, call intercept.Call, invoke intercept.Invoker) (any, error) {
+		if call.Namespace != "" && !allowlist[call.Namespace] {
+			logger.WarnContext(ctx, "rejected call outside the namespace allowlist",
+				"api", call.API, "method", call.Method, "namespace", call.Namespace)
+			return nil, fmt.Errorf("failed to call %s in namespace %q: %w", call.Method, call.Namespace, api.ErrNamespaceNotAllowed)
+		}
+		return invoke(ctx)
+	}
+}
+
+// allowlistFilter keeps the items belonging to a namespace of allowlist, and those not
+// belonging to any namespace.
+func allowlistFilter(allowlist map[string]bool) intercept.Filter {
+	return func(_ intercept.Call, obj any) bool {
+		namespace, ok := intercept.NamespaceOf(obj)
+		return !ok || allowlist[namespace]
+	}
+}
+
+// timeoutInterceptor bounds calls whose context has no deadline to timeout. It returns
+// nil when timeout is not positive.
+func timeoutInterceptor(timeout time.Duration) intercept.Interceptor {
+	if timeout <= 0 {
+		return nil
+	}
+	return func(ctx context.Context, call intercept.Call, invoke intercept.Invoker) (any, error) {
+		if _, ok := ctx.Deadline(); ok || call.Stream {
+			return invoke(ctx)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoke(ctx)
+	}
+}
+
+// tracingInterceptor starts a span from the context of every call, named after the
+// method, e.g. "PodAPI.GetPodByName". It returns nil when tp is nil.
+//
+// The span records what the call is about, the number of objects it returned and, when
+// it fails, the error and its class. Paged calls end their span once the iteration
+// stops, Watch calls once the watch is open.
+func tracingInterceptor(tp trace.TracerProvider) intercept.Interceptor {
+	if tp == nil {
+		return nil
+	}
+	tracer := tp.Tracer(instrumentationName)
+	return func(ctx context.Context, call intercept.Call, invoke intercept.Invoker) (any, error) {
+		attrs := []attribute.KeyValue{
+			attribute.String("k8s.api", call.API),
+			attribute.String("k8s.resource", call.Resource),
+			attribute.String("k8s.verb", call.Verb),
+		}
+		if call.Namespace != "" {
+			attrs = append(attrs, attribute.String("k8s.namespace.name", call.Namespace))
+		}
+		if call.Name != "" {
+			attrs = append(attrs, attribute.String("k8s.object.name", call.Name))
+		}
+		if call.LabelSelector != "" {
+			attrs = append(attrs, attribute.String("k8s.label_selector", call.LabelSelector))
+		}
+		if call.FieldSelector != "" {
+			attrs = append(attrs, attribute.String("k8s.field_selector", call.FieldSelector))
+		}
+
+		ctx, span := tracer.Start(ctx, call.API+"."+call.Method,
+			trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		result, err := invoke(ctx)
+		if count, ok := intercept.Count(result, err); ok {
+			span.SetAttributes(attribute.Int("k8s.result.count", count))
+		}
+		if err != nil {
+			span.SetAttributes(attribute.String("error.type", intercept.ErrorClass(err)))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return result, err
+	}
+}
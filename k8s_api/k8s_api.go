@@ -1,48 +1,279 @@
 package k8sapi
 
 import (
+	"cmp"
+	"context"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
 
 	"github.com/kaudit/auth"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/kaudit/api"
+	"github.com/kaudit/api/cache_api"
+	"github.com/kaudit/api/configmap_api"
+	"github.com/kaudit/api/cronjob_api"
+	"github.com/kaudit/api/custom_resource_api"
+	"github.com/kaudit/api/daemonset_api"
 	"github.com/kaudit/api/deployment_api"
+	"github.com/kaudit/api/discovery_api"
+	"github.com/kaudit/api/event_api"
+	"github.com/kaudit/api/internal/intercept"
+	"github.com/kaudit/api/job_api"
+	"github.com/kaudit/api/metrics_api"
 	"github.com/kaudit/api/namespace_api"
+	"github.com/kaudit/api/networking_api"
+	"github.com/kaudit/api/node_api"
 	"github.com/kaudit/api/pod_api"
+	"github.com/kaudit/api/rbac_api"
+	"github.com/kaudit/api/replicaset_api"
+	"github.com/kaudit/api/secret_api"
 	"github.com/kaudit/api/service_api"
+	"github.com/kaudit/api/serviceaccount_api"
+	"github.com/kaudit/api/statefulset_api"
+	"github.com/kaudit/api/storage_api"
 )
 
 // K8sAPI provides a centralized access point to high-level Kubernetes API abstractions.
 //
-// It encapsulates typed interfaces for interacting with Pods, Services, Deployments,
-// and Namespaces — each exposed through domain-specific interface contracts.
+// It encapsulates typed interfaces for interacting with workloads such as Pods,
+// Deployments and Jobs, with configuration such as ConfigMaps and Secrets, and with
+// cluster resources such as Namespaces — each exposed through domain-specific
+// interface contracts and obtained through the Get*API methods.
 //
-// All API implementations are stateless, thread-safe, and validated via typed input contracts.
+// All API implementations are thread-safe and validated via typed input contracts. The
+// implementations wired by NewK8sAPI are stateless; those wired by NewCachedK8sAPI read
+// from shared informer caches whose lifecycle is controlled with Start,
+// WaitForCacheSync and Stop.
 type K8sAPI struct {
-	pods        api.PodAPI
-	services    api.ServiceAPI
-	deployments api.DeploymentAPI
-	namespaces  api.NamespaceAPI
+	pods            api.PodAPI
+	services        api.ServiceAPI
+	deployments     api.DeploymentAPI
+	namespaces      api.NamespaceAPI
+	statefulSets    api.StatefulSetAPI
+	daemonSets      api.DaemonSetAPI
+	replicaSets     api.ReplicaSetAPI
+	jobs            api.JobAPI
+	cronJobs        api.CronJobAPI
+	configMaps      api.ConfigMapAPI
+	secrets         api.SecretAPI
+	rbac            api.RBACAPI
+	accounts        api.ServiceAccountAPI
+	nodes           api.NodeAPI
+	events          api.EventAPI
+	networking      api.NetworkingAPI
+	endpointSlices  api.EndpointSliceAPI
+	storage         api.StorageAPI
+	customResources api.CustomResourceAPI
+	discovery       api.DiscoveryAPI
+
+	cache  *cacheapi.Cache
+	logger *slog.Logger
 }
 
 // NewK8sAPI initializes a K8sApi facade by constructing all typed clients behind interface boundaries.
 //
 // This function:
 //   - Initializes a client using the provided auth.Authenticator (via NativeAPI()).
-//   - Injects the client into each module's constructor (e.g., pod_api.NewPodAPI).
+//   - Initializes a dynamic client for custom resources (via DynamicAPI()).
+//   - Injects the clients into each module's constructor (e.g., pod_api.NewPodAPI).
 //   - Assembles a fully wired K8sApi instance.
-func NewK8sAPI(auth auth.Authenticator) (*K8sAPI, error) {
+//
+// Transient apiserver failures of pod, service, deployment and namespace reads are
+// retried according to api.DefaultRetryPolicy unless WithRetryPolicy says otherwise.
+// Further options keep the instance within a request budget (WithRateLimit), bound
+// calls in time (WithTimeout) or namespaces (WithNamespaceAllowlist), serve reads from
+// a cache (WithCache), record Prometheus metrics (WithMetrics), trace calls
+// (WithTracerProvider) or replace resource APIs with custom implementations, e.g.
+// WithPodAPI.
+func NewK8sAPI(auth auth.Authenticator, opts ...Option) (*K8sAPI, error) {
+	client, dynamicClient, err := clients(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := newConfig(opts...)
+	if cfg.registerer != nil {
+		if cfg.metrics, err = metricsapi.NewMetrics(cfg.registerer); err != nil {
+			return nil, err
+		}
+	}
+
+	return newK8sAPI(client, dynamicClient, cfg), nil
+}
+
+// NewCachedK8sAPI initializes a K8sAPI facade whose resource APIs are served from shared
+// informer caches instead of querying the apiserver on every call. It is a shorthand
+// for NewK8sAPI with WithCache.
+//
+// This function:
+//   - Initializes the clients like NewK8sAPI.
+//   - Registers pod, service, deployment and namespace informers (see cache_api.NewCache).
+//   - Assembles a K8sApi instance exposing the cache-backed implementations.
+//
+// Resource APIs without a cache-backed implementation, such as StatefulSetAPI, query the
+// apiserver directly just like with NewK8sAPI.
+//
+// The informers are not running yet: call Start, then WaitForCacheSync before issuing
+// queries, and Stop once the instance is no longer needed. Options such as
+// cacheapi.WithNamespace restrict what is cached.
+func NewCachedK8sAPI(auth auth.Authenticator, opts ...cacheapi.Option) (*K8sAPI, error) {
+	return NewK8sAPI(auth, WithCache(opts...))
+}
+
+// clients initializes the typed and dynamic clients from auth.
+func clients(auth auth.Authenticator) (kubernetes.Interface, dynamic.Interface, error) {
 	client, err := auth.NativeAPI()
 	if err != nil {
-		return nil, fmt.Errorf("failed to init k8s client: %w", err)
+		return nil, nil, fmt.Errorf("failed to init k8s client: %w", err)
+	}
+	dynamicClient, err := auth.DynamicAPI()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to init k8s dynamic client: %w", err)
+	}
+
+	return client, dynamicClient, nil
+}
+
+// newK8sAPI wires every resource API around client, and CustomResourceAPI around
+// dynamicClient, as configured by cfg. When cfg enables the cache, the resources
+// it covers are served from it; all others query the apiserver directly. Custom
+// implementations from cfg take precedence over both.
+//
+// APIs built on top of other APIs, such as JobAPI resolving pods, are wired after the
+// cache-backed, custom and instrumented implementations so they benefit from them as
+// well. They are given the implementations before decoration, so that timeouts and the
+// namespace allowlist apply once per call to the facade.
+func newK8sAPI(client kubernetes.Interface, dynamicClient dynamic.Interface, cfg config) *K8sAPI {
+	limiter := api.NewRateLimiter(cfg.limit)
+	secretOpts := []secretapi.Option{secretapi.WithRateLimiter(limiter), secretapi.WithLogger(cfg.logger)}
+	if cfg.secretMetadata != nil {
+		secretOpts = append(secretOpts, secretapi.WithMetadataClient(cfg.secretMetadata))
+	}
+
+	k := &K8sAPI{
+		pods:            podapi.NewPodAPI(client, podapi.WithRetryPolicy(cfg.retry), podapi.WithRateLimiter(limiter), podapi.WithLogger(cfg.logger)),
+		services:        serviceapi.NewServiceAPI(client, serviceapi.WithRetryPolicy(cfg.retry), serviceapi.WithRateLimiter(limiter), serviceapi.WithLogger(cfg.logger)),
+		deployments:     deploymentapi.NewDeploymentAPI(client, deploymentapi.WithRetryPolicy(cfg.retry), deploymentapi.WithRateLimiter(limiter), deploymentapi.WithLogger(cfg.logger)),
+		namespaces:      namespaceapi.NewNamespaceAPI(client, namespaceapi.WithRetryPolicy(cfg.retry), namespaceapi.WithRateLimiter(limiter), namespaceapi.WithLogger(cfg.logger)),
+		statefulSets:    statefulsetapi.NewStatefulSetAPI(client, statefulsetapi.WithRateLimiter(limiter), statefulsetapi.WithLogger(cfg.logger)),
+		daemonSets:      daemonsetapi.NewDaemonSetAPI(client, daemonsetapi.WithRateLimiter(limiter), daemonsetapi.WithLogger(cfg.logger)),
+		replicaSets:     replicasetapi.NewReplicaSetAPI(client, replicasetapi.WithRateLimiter(limiter), replicasetapi.WithLogger(cfg.logger)),
+		configMaps:      configmapapi.NewConfigMapAPI(client, configmapapi.WithRateLimiter(limiter), configmapapi.WithLogger(cfg.logger)),
+		secrets:         secretapi.NewSecretAPI(client, secretOpts...),
+		rbac:            rbacapi.NewRBACAPI(client, rbacapi.WithRateLimiter(limiter), rbacapi.WithLogger(cfg.logger)),
+		events:          eventapi.NewEventAPI(client, eventapi.WithRateLimiter(limiter), eventapi.WithLogger(cfg.logger)),
+		networking:      networkingapi.NewNetworkingAPI(client, networkingapi.WithRateLimiter(limiter), networkingapi.WithLogger(cfg.logger)),
+		endpointSlices:  discoveryapi.NewEndpointSliceAPI(client, discoveryapi.WithRateLimiter(limiter), discoveryapi.WithLogger(cfg.logger)),
+		customResources: customresourceapi.NewCustomResourceAPI(dynamicClient, customresourceapi.WithRateLimiter(limiter), customresourceapi.WithLogger(cfg.logger)),
+		discovery:       discoveryapi.NewDiscoveryAPI(client, discoveryapi.WithRateLimiter(limiter), discoveryapi.WithLogger(cfg.logger)),
+		logger:          cfg.logger,
+	}
+
+	if cfg.cached {
+		k.cache = cacheapi.NewCache(client, append([]cacheapi.Option{cacheapi.WithLogger(cfg.logger), cacheapi.WithRateLimiter(limiter)}, cfg.cacheOpts...)...)
+		k.pods = k.cache.PodAPI()
+		k.services = k.cache.ServiceAPI()
+		k.deployments = k.cache.DeploymentAPI()
+		k.namespaces = k.cache.NamespaceAPI()
+	}
+
+	custom := cfg.apis
+	k.pods = cmp.Or(custom.pods, k.pods)
+	k.services = cmp.Or(custom.services, k.services)
+	k.deployments = cmp.Or(custom.deployments, k.deployments)
+	k.namespaces = cmp.Or(custom.namespaces, k.namespaces)
+	k.statefulSets = cmp.Or(custom.statefulSets, k.statefulSets)
+	k.daemonSets = cmp.Or(custom.daemonSets, k.daemonSets)
+	k.replicaSets = cmp.Or(custom.replicaSets, k.replicaSets)
+	k.configMaps = cmp.Or(custom.configMaps, k.configMaps)
+	k.secrets = cmp.Or(custom.secrets, k.secrets)
+	k.rbac = cmp.Or(custom.rbac, k.rbac)
+	k.events = cmp.Or(custom.events, k.events)
+	k.networking = cmp.Or(custom.networking, k.networking)
+	k.endpointSlices = cmp.Or(custom.endpointSlices, k.endpointSlices)
+	k.customResources = cmp.Or(custom.customResources, k.customResources)
+	k.discovery = cmp.Or(custom.discovery, k.discovery)
+
+	if cfg.metrics != nil {
+		k.pods = metricsapi.NewPodAPI(k.pods, cfg.metrics, cfg.metricsSource(custom.pods != nil))
+		k.services = metricsapi.NewServiceAPI(k.services, cfg.metrics, cfg.metricsSource(custom.services != nil))
+		k.deployments = metricsapi.NewDeploymentAPI(k.deployments, cfg.metrics, cfg.metricsSource(custom.deployments != nil))
+		k.namespaces = metricsapi.NewNamespaceAPI(k.namespaces, cfg.metrics, cfg.metricsSource(custom.namespaces != nil))
+	}
+
+	k.jobs = cmp.Or(custom.jobs, api.JobAPI(jobapi.NewJobAPI(client, k.pods, jobapi.WithRateLimiter(limiter), jobapi.WithLogger(cfg.logger))))
+	k.cronJobs = cmp.Or(custom.cronJobs, api.CronJobAPI(cronjobapi.NewCronJobAPI(client, k.jobs, cronjobapi.WithRateLimiter(limiter), cronjobapi.WithLogger(cfg.logger))))
+	k.accounts = cmp.Or(custom.accounts, api.ServiceAccountAPI(serviceaccountapi.NewServiceAccountAPI(client, k.pods, serviceaccountapi.WithRateLimiter(limiter), serviceaccountapi.WithLogger(cfg.logger))))
+	k.nodes = cmp.Or(custom.nodes, api.NodeAPI(nodeapi.NewNodeAPI(client, k.pods, nodeapi.WithRateLimiter(limiter), nodeapi.WithLogger(cfg.logger))))
+	k.storage = cmp.Or(custom.storage, api.StorageAPI(storageapi.NewStorageAPI(client, k.pods, storageapi.WithRateLimiter(limiter), storageapi.WithLogger(cfg.logger))))
+
+	k.decorate(cfg.hooks())
+
+	cfg.logger.Debug("initialized k8s api",
+		"cached", cfg.cached, "timeout", cfg.timeout, "namespaceAllowlist", slices.Sorted(maps.Keys(cfg.allowlist)))
+
+	return k
+}
+
+// decorate wraps every resource API with hooks.
+func (k *K8sAPI) decorate(hooks intercept.Hooks) {
+	k.pods = intercept.NewPodAPI(k.pods, hooks)
+	k.services = intercept.NewServiceAPI(k.services, hooks)
+	k.deployments = intercept.NewDeploymentAPI(k.deployments, hooks)
+	k.namespaces = intercept.NewNamespaceAPI(k.namespaces, hooks)
+	k.statefulSets = intercept.NewStatefulSetAPI(k.statefulSets, hooks)
+	k.daemonSets = intercept.NewDaemonSetAPI(k.daemonSets, hooks)
+	k.replicaSets = intercept.NewReplicaSetAPI(k.replicaSets, hooks)
+	k.jobs = intercept.NewJobAPI(k.jobs, hooks)
+	k.cronJobs = intercept.NewCronJobAPI(k.cronJobs, hooks)
+	k.configMaps = intercept.NewConfigMapAPI(k.configMaps, hooks)
+	k.secrets = intercept.NewSecretAPI(k.secrets, hooks)
+	k.rbac = intercept.NewRBACAPI(k.rbac, hooks)
+	k.accounts = intercept.NewServiceAccountAPI(k.accounts, hooks)
+	k.nodes = intercept.NewNodeAPI(k.nodes, hooks)
+	k.events = intercept.NewEventAPI(k.events, hooks)
+	k.networking = intercept.NewNetworkingAPI(k.networking, hooks)
+	k.endpointSlices = intercept.NewEndpointSliceAPI(k.endpointSlices, hooks)
+	k.storage = intercept.NewStorageAPI(k.storage, hooks)
+	k.customResources = intercept.NewCustomResourceAPI(k.customResources, hooks)
+	k.discovery = intercept.NewDiscoveryAPI(k.discovery, hooks)
+}
+
+// Start launches the informers backing a K8sAPI created with NewCachedK8sAPI.
+// It is a no-op for instances created with NewK8sAPI.
+func (k *K8sAPI) Start() {
+	if k.cache != nil {
+		k.logger.Debug("starting informers")
+		k.cache.Start()
 	}
+}
+
+// WaitForCacheSync blocks until the informers backing a K8sAPI created with
+// NewCachedK8sAPI have completed their initial listing, or ctx is done.
+// It returns immediately for instances created with NewK8sAPI.
+func (k *K8sAPI) WaitForCacheSync(ctx context.Context) error {
+	if k.cache == nil {
+		return nil
+	}
+	if err := k.cache.WaitForCacheSync(ctx); err != nil {
+		k.logger.WarnContext(ctx, "informer caches did not sync", "error", err)
+		return err
+	}
+	k.logger.DebugContext(ctx, "informer caches synced")
+	return nil
+}
 
-	return &K8sAPI{
-		pods:        podapi.NewPodAPI(client),
-		services:    serviceapi.NewServiceAPI(client),
-		deployments: deploymentapi.NewDeploymentAPI(client),
-		namespaces:  namespaceapi.NewNamespaceAPI(client),
-	}, nil
+// Stop shuts down the informers backing a K8sAPI created with NewCachedK8sAPI.
+// It is a no-op for instances created with NewK8sAPI.
+func (k *K8sAPI) Stop() {
+	if k.cache != nil {
+		k.logger.Debug("stopping informers")
+		k.cache.Stop()
+	}
 }
 
 // GetPodAPI exposes the PodAPI interface, allowing access to pod-specific operations.
@@ -64,3 +295,83 @@ func (k *K8sAPI) GetDeploymentAPI() api.DeploymentAPI {
 func (k *K8sAPI) GetNamespaceAPI() api.NamespaceAPI {
 	return k.namespaces
 }
+
+// GetStatefulSetAPI exposes the StatefulSetAPI interface for stateful workloads.
+func (k *K8sAPI) GetStatefulSetAPI() api.StatefulSetAPI {
+	return k.statefulSets
+}
+
+// GetDaemonSetAPI exposes the DaemonSetAPI interface for node-level workloads.
+func (k *K8sAPI) GetDaemonSetAPI() api.DaemonSetAPI {
+	return k.daemonSets
+}
+
+// GetReplicaSetAPI exposes the ReplicaSetAPI interface for replica sets.
+func (k *K8sAPI) GetReplicaSetAPI() api.ReplicaSetAPI {
+	return k.replicaSets
+}
+
+// GetJobAPI exposes the JobAPI interface for batch jobs.
+func (k *K8sAPI) GetJobAPI() api.JobAPI {
+	return k.jobs
+}
+
+// GetCronJobAPI exposes the CronJobAPI interface for scheduled jobs and their run history.
+func (k *K8sAPI) GetCronJobAPI() api.CronJobAPI {
+	return k.cronJobs
+}
+
+// GetConfigMapAPI exposes the ConfigMapAPI interface for configuration data.
+func (k *K8sAPI) GetConfigMapAPI() api.ConfigMapAPI {
+	return k.configMaps
+}
+
+// GetSecretAPI exposes the SecretAPI interface, which reports secret metadata without values.
+func (k *K8sAPI) GetSecretAPI() api.SecretAPI {
+	return k.secrets
+}
+
+// GetRBACAPI exposes the RBACAPI interface for inspecting roles, bindings and the access they grant.
+func (k *K8sAPI) GetRBACAPI() api.RBACAPI {
+	return k.rbac
+}
+
+// GetServiceAccountAPI exposes the ServiceAccountAPI interface for workload identities.
+func (k *K8sAPI) GetServiceAccountAPI() api.ServiceAccountAPI {
+	return k.accounts
+}
+
+// GetNodeAPI exposes the NodeAPI interface for node posture and scheduling.
+func (k *K8sAPI) GetNodeAPI() api.NodeAPI {
+	return k.nodes
+}
+
+// GetEventAPI exposes the EventAPI interface for events related to other objects.
+func (k *K8sAPI) GetEventAPI() api.EventAPI {
+	return k.events
+}
+
+// GetNetworkingAPI exposes the NetworkingAPI interface for Ingresses, IngressClasses and NetworkPolicies.
+func (k *K8sAPI) GetNetworkingAPI() api.NetworkingAPI {
+	return k.networking
+}
+
+// GetEndpointSliceAPI exposes the EndpointSliceAPI interface for the endpoints backing Services.
+func (k *K8sAPI) GetEndpointSliceAPI() api.EndpointSliceAPI {
+	return k.endpointSlices
+}
+
+// GetStorageAPI exposes the StorageAPI interface for volumes, claims and their storage classes.
+func (k *K8sAPI) GetStorageAPI() api.StorageAPI {
+	return k.storage
+}
+
+// GetCustomResourceAPI exposes the CustomResourceAPI interface for resources without a typed client, such as CRDs.
+func (k *K8sAPI) GetCustomResourceAPI() api.CustomResourceAPI {
+	return k.customResources
+}
+
+// GetDiscoveryAPI exposes the DiscoveryAPI interface for the API groups, versions and resources served by the cluster.
+func (k *K8sAPI) GetDiscoveryAPI() api.DiscoveryAPI {
+	return k.discovery
+}
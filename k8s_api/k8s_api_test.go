@@ -1,20 +1,43 @@
 package k8sapi
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"iter"
+	"log/slog"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kaudit/api"
+	"github.com/kaudit/api/cache_api"
 	mockauth "github.com/kaudit/api/mocks/Authenticator"
 )
 
@@ -26,6 +49,7 @@ func TestNewK8sApi_Success(t *testing.T) {
 
 	// Expect the authenticator to be called and return a fake client
 	mockAuthenticator.EXPECT().NativeAPI().Return(&fakeClient, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	// Call the function under test
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
@@ -85,6 +109,19 @@ func TestNewK8sApi_AuthFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to init k8s client")
 }
 
+// TestNewK8sApi_DynamicAuthFailure tests the case when the dynamic client cannot be created
+func TestNewK8sApi_DynamicAuthFailure(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(nil, errors.New("auth error"))
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator)
+
+	require.Error(t, err)
+	assert.Nil(t, k8sAPI)
+	assert.Contains(t, err.Error(), "failed to init k8s dynamic client")
+}
+
 // TestK8sAPI_GetAPIs tests each of the getter methods
 func TestK8sAPI_GetAPIs(t *testing.T) {
 	// Setup
@@ -93,6 +130,7 @@ func TestK8sAPI_GetAPIs(t *testing.T) {
 
 	// Expect the authenticator to be called
 	mockAuthenticator.EXPECT().NativeAPI().Return(&fakeClient, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	// Create the K8sAPI instance
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
@@ -122,6 +160,102 @@ func TestK8sAPI_GetAPIs(t *testing.T) {
 		assert.NotNil(t, namespaceAPI)
 		assert.Implements(t, (*api.NamespaceAPI)(nil), namespaceAPI)
 	})
+
+	t.Run("GetStatefulSetAPI", func(t *testing.T) {
+		statefulSetAPI := k8sAPI.GetStatefulSetAPI()
+		assert.NotNil(t, statefulSetAPI)
+		assert.Implements(t, (*api.StatefulSetAPI)(nil), statefulSetAPI)
+	})
+
+	t.Run("GetDaemonSetAPI", func(t *testing.T) {
+		daemonSetAPI := k8sAPI.GetDaemonSetAPI()
+		assert.NotNil(t, daemonSetAPI)
+		assert.Implements(t, (*api.DaemonSetAPI)(nil), daemonSetAPI)
+	})
+
+	t.Run("GetReplicaSetAPI", func(t *testing.T) {
+		replicaSetAPI := k8sAPI.GetReplicaSetAPI()
+		assert.NotNil(t, replicaSetAPI)
+		assert.Implements(t, (*api.ReplicaSetAPI)(nil), replicaSetAPI)
+	})
+
+	t.Run("GetJobAPI", func(t *testing.T) {
+		jobAPI := k8sAPI.GetJobAPI()
+		assert.NotNil(t, jobAPI)
+		assert.Implements(t, (*api.JobAPI)(nil), jobAPI)
+	})
+
+	t.Run("GetCronJobAPI", func(t *testing.T) {
+		cronJobAPI := k8sAPI.GetCronJobAPI()
+		assert.NotNil(t, cronJobAPI)
+		assert.Implements(t, (*api.CronJobAPI)(nil), cronJobAPI)
+	})
+
+	t.Run("GetConfigMapAPI", func(t *testing.T) {
+		configMapAPI := k8sAPI.GetConfigMapAPI()
+		assert.NotNil(t, configMapAPI)
+		assert.Implements(t, (*api.ConfigMapAPI)(nil), configMapAPI)
+	})
+
+	t.Run("GetSecretAPI", func(t *testing.T) {
+		secretAPI := k8sAPI.GetSecretAPI()
+		assert.NotNil(t, secretAPI)
+		assert.Implements(t, (*api.SecretAPI)(nil), secretAPI)
+	})
+
+	t.Run("GetRBACAPI", func(t *testing.T) {
+		rbacAPI := k8sAPI.GetRBACAPI()
+		assert.NotNil(t, rbacAPI)
+		assert.Implements(t, (*api.RBACAPI)(nil), rbacAPI)
+	})
+
+	t.Run("GetServiceAccountAPI", func(t *testing.T) {
+		serviceAccountAPI := k8sAPI.GetServiceAccountAPI()
+		assert.NotNil(t, serviceAccountAPI)
+		assert.Implements(t, (*api.ServiceAccountAPI)(nil), serviceAccountAPI)
+	})
+
+	t.Run("GetNodeAPI", func(t *testing.T) {
+		nodeAPI := k8sAPI.GetNodeAPI()
+		assert.NotNil(t, nodeAPI)
+		assert.Implements(t, (*api.NodeAPI)(nil), nodeAPI)
+	})
+
+	t.Run("GetEventAPI", func(t *testing.T) {
+		eventAPI := k8sAPI.GetEventAPI()
+		assert.NotNil(t, eventAPI)
+		assert.Implements(t, (*api.EventAPI)(nil), eventAPI)
+	})
+
+	t.Run("GetNetworkingAPI", func(t *testing.T) {
+		networkingAPI := k8sAPI.GetNetworkingAPI()
+		assert.NotNil(t, networkingAPI)
+		assert.Implements(t, (*api.NetworkingAPI)(nil), networkingAPI)
+	})
+
+	t.Run("GetEndpointSliceAPI", func(t *testing.T) {
+		endpointSliceAPI := k8sAPI.GetEndpointSliceAPI()
+		assert.NotNil(t, endpointSliceAPI)
+		assert.Implements(t, (*api.EndpointSliceAPI)(nil), endpointSliceAPI)
+	})
+
+	t.Run("GetStorageAPI", func(t *testing.T) {
+		storageAPI := k8sAPI.GetStorageAPI()
+		assert.NotNil(t, storageAPI)
+		assert.Implements(t, (*api.StorageAPI)(nil), storageAPI)
+	})
+
+	t.Run("GetCustomResourceAPI", func(t *testing.T) {
+		customResourceAPI := k8sAPI.GetCustomResourceAPI()
+		assert.NotNil(t, customResourceAPI)
+		assert.Implements(t, (*api.CustomResourceAPI)(nil), customResourceAPI)
+	})
+
+	t.Run("GetDiscoveryAPI", func(t *testing.T) {
+		discoveryAPI := k8sAPI.GetDiscoveryAPI()
+		assert.NotNil(t, discoveryAPI)
+		assert.Implements(t, (*api.DiscoveryAPI)(nil), discoveryAPI)
+	})
 }
 
 // Test PodAPI Implementation
@@ -141,6 +275,7 @@ func TestPodAPIImpl_GetPodByName(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -205,6 +340,7 @@ func TestPodAPIImpl_ListPodsByLabel(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -258,6 +394,7 @@ func TestPodAPIImpl_ListPodsByField(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -308,6 +445,7 @@ func TestServiceAPIImpl_GetServiceByName(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -360,6 +498,7 @@ func TestDeploymentAPIImpl_GetDeploymentByName(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -409,6 +548,7 @@ func TestNamespaceAPIImpl_GetNamespaceByName(t *testing.T) {
 	)
 
 	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
 
 	k8sAPI, err := NewK8sAPI(mockAuthenticator)
 	require.NoError(t, err)
@@ -437,3 +577,820 @@ func TestNamespaceAPIImpl_GetNamespaceByName(t *testing.T) {
 		assert.Nil(t, namespace)
 	})
 }
+
+// Test DaemonSetAPI Implementation
+func TestDaemonSetAPIImpl_ListDaemonSetsByLabel(t *testing.T) {
+	// Setup
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "node-agent",
+				Namespace: "kube-system",
+				Labels: map[string]string{
+					"app": "node-agent",
+				},
+			},
+		},
+	)
+
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator)
+	require.NoError(t, err)
+
+	daemonSetAPI := k8sAPI.GetDaemonSetAPI()
+	require.NotNil(t, daemonSetAPI)
+
+	// Test listing daemonsets by label
+	t.Run("ListDaemonSetsByLabel_Success", func(t *testing.T) {
+		ctx := context.Background()
+		daemonSets, err := daemonSetAPI.ListDaemonSetsByLabel(ctx, "kube-system", "app=node-agent")
+
+		require.NoError(t, err)
+		require.Len(t, daemonSets, 1)
+		assert.Equal(t, "node-agent", daemonSets[0].Name)
+	})
+}
+
+func TestCustomResourceAPIImpl_GetCustomResourceByName(t *testing.T) {
+	// Setup
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	gvr := schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      "web-tls",
+			"namespace": "default",
+		},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "CertificateList"}, certificate)
+
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicClient, nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator)
+	require.NoError(t, err)
+
+	// Test that the custom resource API is served by the dynamic client
+	t.Run("GetCustomResourceByName_Success", func(t *testing.T) {
+		obj, err := k8sAPI.GetCustomResourceAPI().GetCustomResourceByName(context.Background(), gvr, "default", "web-tls")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Certificate", obj.GetKind())
+	})
+}
+
+// TestNewCachedK8sApi tests the cache-backed K8sAPI and its lifecycle
+func TestNewCachedK8sApi(t *testing.T) {
+	// Setup
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "default",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-pod",
+				Namespace: "kube-system",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+	)
+
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewCachedK8sAPI(mockAuthenticator, cacheapi.WithNamespace("default"))
+	require.NoError(t, err)
+	require.NotNil(t, k8sAPI)
+
+	k8sAPI.Start()
+	defer k8sAPI.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, k8sAPI.WaitForCacheSync(ctx))
+
+	// Test reads served from the cache
+	t.Run("ListPodsByLabel_FromCache", func(t *testing.T) {
+		pods, err := k8sAPI.GetPodAPI().ListPodsByLabel(ctx, "default", "app=test-app")
+
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.Equal(t, "test-pod", pods[0].Name)
+	})
+
+	// Test namespace scoping
+	t.Run("ListPodsByLabel_OutsideScope", func(t *testing.T) {
+		pods, err := k8sAPI.GetPodAPI().ListPodsByLabel(ctx, "kube-system", "app=test-app")
+
+		require.Error(t, err)
+		assert.Nil(t, pods)
+	})
+
+	// Test that APIs built on PodAPI share the cache scope
+	t.Run("ListPodsForJob_OutsideScope", func(t *testing.T) {
+		pods, err := k8sAPI.GetJobAPI().ListPodsForJob(ctx, "kube-system", "test-job")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "outside the cache scope")
+		assert.Nil(t, pods)
+	})
+}
+
+// TestNewCachedK8sApi_AuthFailure tests the case when authentication fails
+func TestNewCachedK8sApi_AuthFailure(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(nil, errors.New("auth error"))
+
+	k8sAPI, err := NewCachedK8sAPI(mockAuthenticator)
+
+	require.Error(t, err)
+	assert.Nil(t, k8sAPI)
+	assert.Contains(t, err.Error(), "failed to init k8s client")
+}
+
+// TestK8sAPI_LifecycleWithoutCache tests that lifecycle methods are no-ops for NewK8sAPI
+func TestK8sAPI_LifecycleWithoutCache(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator)
+	require.NoError(t, err)
+
+	k8sAPI.Start()
+	require.NoError(t, k8sAPI.WaitForCacheSync(context.Background()))
+	k8sAPI.Stop()
+}
+
+func TestNewK8sApi_WithRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		failures      int
+		expectedCalls int
+		wantErr       bool
+	}{
+		{
+			name:          "Default policy retries",
+			failures:      1,
+			expectedCalls: 2,
+		},
+		{
+			name: "Custom policy",
+			opts: []Option{WithRetryPolicy(api.RetryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       time.Millisecond,
+				RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+			})},
+			failures:      2,
+			expectedCalls: 3,
+		},
+		{
+			name:          "Zero policy disables retries",
+			opts:          []Option{WithRetryPolicy(api.RetryPolicy{})},
+			failures:      1,
+			expectedCalls: 1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthenticator := mockauth.NewMockAuthenticator(t)
+			fakeClientset := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			calls := 0
+			fakeClientset.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= tt.failures {
+					return true, nil, apierrors.NewServiceUnavailable("apiserver restarting")
+				}
+				return false, nil, nil
+			})
+
+			mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+			mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+			k8sAPI, err := NewK8sAPI(mockAuthenticator, tt.opts...)
+			require.NoError(t, err)
+
+			_, err = k8sAPI.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, apierrors.IsServiceUnavailable(err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewK8sApi_WithRateLimit(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}},
+	)
+
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithRateLimit(api.RateLimit{QPS: 50, Burst: 1, MaxInFlight: 4}))
+	require.NoError(t, err)
+
+	ctx, stats := api.WithRequestStats(context.Background())
+	_, err = k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+	require.NoError(t, err)
+	_, err = k8sAPI.GetConfigMapAPI().GetConfigMapByName(ctx, "default", "settings")
+	require.NoError(t, err)
+	_, err = k8sAPI.GetStatefulSetAPI().GetStatefulSetByName(ctx, "default", "db")
+	require.NoError(t, err)
+
+	// The resource APIs draw from one token bucket, so the second and third requests
+	// wait for a token even though they go through other APIs.
+	assert.Equal(t, 3, stats.Requests())
+	assert.GreaterOrEqual(t, stats.Wait(), 30*time.Millisecond)
+}
+
+func TestNewK8sApi_WithSecretMetadataClient(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset()
+	scheme := metadatafake.NewTestScheme()
+	scheme.AddKnownTypeWithName(corev1.SchemeGroupVersion.WithKind("Secret"), &metav1.PartialObjectMetadata{})
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"},
+	})
+
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithSecretMetadataClient(metadataClient))
+	require.NoError(t, err)
+
+	secret, err := k8sAPI.GetSecretAPI().GetSecretByName(context.Background(), "default", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "token", secret.Name)
+
+	// Secrets are not fetched through the typed client
+	assert.Empty(t, fakeClientset.Actions())
+}
+
+func TestNewK8sApi_WithLogger(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithLogger(logger), WithNamespaceAllowlist("default"))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "initialized k8s api")
+
+	_, err = k8sAPI.GetSecretAPI().GetSecretByName(context.Background(), "kube-system", "token")
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "method=GetSecretByName namespace=kube-system")
+
+	// The core resource APIs log their requests to the same logger.
+	_, err = k8sAPI.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	assert.Contains(t, buf.String(), `level=WARN msg="kubernetes request failed" verb=get resource=pods namespace=default name=web`)
+
+	// So do the other resource APIs
+	_, err = k8sAPI.GetConfigMapAPI().ListConfigMapsByLabel(context.Background(), "default", "app=web")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=list resource=configmaps namespace=default labelSelector="app=web"`)
+	_, err = k8sAPI.GetNodeAPI().GetNodeByName(context.Background(), "node-1")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	assert.Contains(t, buf.String(), `level=WARN msg="kubernetes request failed" verb=get resource=nodes name=node-1`)
+}
+
+func TestNewK8sApi_WithLoggerAndCache(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithLogger(logger), WithCache())
+	require.NoError(t, err)
+	k8sAPI.Start()
+	t.Cleanup(k8sAPI.Stop)
+	require.NoError(t, k8sAPI.WaitForCacheSync(context.Background()))
+
+	// Reads served from the cache are logged like requests
+	_, err = k8sAPI.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	assert.Contains(t, buf.String(), `level=WARN msg="kubernetes request failed" verb=get resource=pods namespace=default name=web cached=true`)
+}
+
+func TestNewK8sApi_WithLoggerNil(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithLogger(nil))
+	require.NoError(t, err)
+
+	// The default logger discards records instead of dereferencing nil.
+	k8sAPI.Start()
+	require.NoError(t, k8sAPI.WaitForCacheSync(context.Background()))
+	k8sAPI.Stop()
+}
+
+// ctxPodAPI is a PodAPI recording the context of its calls. GetPodByName blocks until
+// that context is done.
+type ctxPodAPI struct {
+	api.PodAPI
+	ctx context.Context
+}
+
+func (p *ctxPodAPI) GetPodByName(ctx context.Context, _, _ string) (*corev1.Pod, error) {
+	p.ctx = ctx
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (p *ctxPodAPI) ListPodsByLabelPaged(ctx context.Context, _ string, _ string, _ int64) iter.Seq2[corev1.Pod, error] {
+	return func(func(corev1.Pod, error) bool) {
+		p.ctx = ctx
+	}
+}
+
+func TestNewK8sApi_WithTimeout(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	pods := &ctxPodAPI{}
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithPodAPI(pods), WithTimeout(20*time.Millisecond))
+	require.NoError(t, err)
+
+	t.Run("Bounded", func(t *testing.T) {
+		start := time.Now()
+		_, err := k8sAPI.GetPodAPI().GetPodByName(context.Background(), "default", "web")
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("CallerDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		_, err := k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		// The caller's context is passed on as is.
+		assert.Equal(t, ctx, pods.ctx)
+	})
+
+	t.Run("PagedExempt", func(t *testing.T) {
+		for range k8sAPI.GetPodAPI().ListPodsByLabelPaged(context.Background(), "default", "app=web", 10) {
+		}
+
+		_, ok := pods.ctx.Deadline()
+		assert.False(t, ok)
+	})
+}
+
+func TestNewK8sApi_WithNamespaceAllowlist(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	labels := map[string]string{"tier": "prod"}
+	fakeClientset := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: labels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: labels}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system", Labels: map[string]string{"app": "dns"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: labels}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithNamespaceAllowlist("default"), WithNamespaceAllowlist("monitoring"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("Allowed", func(t *testing.T) {
+		pod, err := k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+
+		require.NoError(t, err)
+		assert.Equal(t, "web", pod.Name)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		actions := len(fakeClientset.Actions())
+		pod, err := k8sAPI.GetPodAPI().GetPodByName(ctx, "kube-system", "dns")
+
+		require.ErrorIs(t, err, api.ErrNamespaceNotAllowed)
+		require.ErrorIs(t, err, api.ErrForbidden)
+		assert.Nil(t, pod)
+		assert.Len(t, fakeClientset.Actions(), actions)
+	})
+
+	t.Run("RejectedNamespace", func(t *testing.T) {
+		_, err := k8sAPI.GetNamespaceAPI().GetNamespaceByName(ctx, "kube-system")
+
+		require.ErrorIs(t, err, api.ErrNamespaceNotAllowed)
+	})
+
+	t.Run("NamespacesFiltered", func(t *testing.T) {
+		namespaces, err := k8sAPI.GetNamespaceAPI().ListNamespacesByLabel(ctx, "tier=prod")
+
+		require.NoError(t, err)
+		require.Len(t, namespaces, 1)
+		assert.Equal(t, "default", namespaces[0].Name)
+	})
+
+	t.Run("PagedFiltered", func(t *testing.T) {
+		var names []string
+		for namespace, err := range k8sAPI.GetNamespaceAPI().ListNamespacesByLabelPaged(ctx, "tier=prod", 10) {
+			require.NoError(t, err)
+			names = append(names, namespace.Name)
+		}
+
+		assert.Equal(t, []string{"default"}, names)
+	})
+
+	t.Run("PagedRejected", func(t *testing.T) {
+		var errs []error
+		for _, err := range k8sAPI.GetPodAPI().ListPodsByLabelPaged(ctx, "kube-system", "app=dns", 10) {
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], api.ErrNamespaceNotAllowed)
+	})
+
+	t.Run("AllNamespacesFiltered", func(t *testing.T) {
+		pods, err := k8sAPI.GetPodAPI().ListPodsByFieldAllNamespaces(ctx, "status.phase!=Failed", nil)
+
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.Equal(t, "default", pods[0].Namespace)
+	})
+
+	t.Run("ClusterScopedKept", func(t *testing.T) {
+		nodes, err := k8sAPI.GetNodeAPI().ListNodesByLabel(ctx, "tier=prod")
+
+		require.NoError(t, err)
+		assert.Len(t, nodes, 1)
+	})
+}
+
+func TestNewK8sApi_WithNamespaceAllowlistWatch(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset()
+	watcher := watch.NewFake()
+	fakeClientset.PrependWatchReactor("namespaces", k8stesting.DefaultWatchReactor(watcher, nil))
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithNamespaceAllowlist("default"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := k8sAPI.GetNamespaceAPI().WatchNamespacesByLabel(ctx, "tier=prod")
+	require.NoError(t, err)
+
+	go func() {
+		watcher.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})
+		watcher.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	}()
+
+	select {
+	case event := <-events:
+		assert.Equal(t, api.EventAdded, event.Type)
+		assert.Equal(t, "default", event.Object.Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event relayed")
+	}
+}
+
+func TestNewK8sApi_WithCache(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithCache(cacheapi.WithNamespace("default")), WithTimeout(time.Second))
+	require.NoError(t, err)
+	require.NotNil(t, k8sAPI.cache)
+
+	k8sAPI.Start()
+	defer k8sAPI.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, k8sAPI.WaitForCacheSync(ctx))
+
+	// Once synced, reads no longer reach the apiserver.
+	actions := len(fakeClientset.Actions())
+	pod, err := k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", pod.Name)
+	assert.Len(t, fakeClientset.Actions(), actions)
+}
+
+func TestNewK8sApi_WithCustomAPIs(t *testing.T) {
+	type custom struct {
+		api.PodAPI
+		api.ServiceAPI
+		api.DeploymentAPI
+		api.NamespaceAPI
+		api.StatefulSetAPI
+		api.DaemonSetAPI
+		api.ReplicaSetAPI
+		api.JobAPI
+		api.CronJobAPI
+		api.ConfigMapAPI
+		api.SecretAPI
+		api.RBACAPI
+		api.ServiceAccountAPI
+		api.NodeAPI
+		api.EventAPI
+		api.NetworkingAPI
+		api.EndpointSliceAPI
+		api.StorageAPI
+		api.CustomResourceAPI
+		api.DiscoveryAPI
+	}
+	impl := &custom{}
+
+	tests := []struct {
+		name   string
+		option Option
+		get    func(k *K8sAPI) any
+	}{
+		{name: "PodAPI", option: WithPodAPI(impl), get: func(k *K8sAPI) any { return k.GetPodAPI() }},
+		{name: "ServiceAPI", option: WithServiceAPI(impl), get: func(k *K8sAPI) any { return k.GetServiceAPI() }},
+		{name: "DeploymentAPI", option: WithDeploymentAPI(impl), get: func(k *K8sAPI) any { return k.GetDeploymentAPI() }},
+		{name: "NamespaceAPI", option: WithNamespaceAPI(impl), get: func(k *K8sAPI) any { return k.GetNamespaceAPI() }},
+		{name: "StatefulSetAPI", option: WithStatefulSetAPI(impl), get: func(k *K8sAPI) any { return k.GetStatefulSetAPI() }},
+		{name: "DaemonSetAPI", option: WithDaemonSetAPI(impl), get: func(k *K8sAPI) any { return k.GetDaemonSetAPI() }},
+		{name: "ReplicaSetAPI", option: WithReplicaSetAPI(impl), get: func(k *K8sAPI) any { return k.GetReplicaSetAPI() }},
+		{name: "JobAPI", option: WithJobAPI(impl), get: func(k *K8sAPI) any { return k.GetJobAPI() }},
+		{name: "CronJobAPI", option: WithCronJobAPI(impl), get: func(k *K8sAPI) any { return k.GetCronJobAPI() }},
+		{name: "ConfigMapAPI", option: WithConfigMapAPI(impl), get: func(k *K8sAPI) any { return k.GetConfigMapAPI() }},
+		{name: "SecretAPI", option: WithSecretAPI(impl), get: func(k *K8sAPI) any { return k.GetSecretAPI() }},
+		{name: "RBACAPI", option: WithRBACAPI(impl), get: func(k *K8sAPI) any { return k.GetRBACAPI() }},
+		{name: "ServiceAccountAPI", option: WithServiceAccountAPI(impl), get: func(k *K8sAPI) any { return k.GetServiceAccountAPI() }},
+		{name: "NodeAPI", option: WithNodeAPI(impl), get: func(k *K8sAPI) any { return k.GetNodeAPI() }},
+		{name: "EventAPI", option: WithEventAPI(impl), get: func(k *K8sAPI) any { return k.GetEventAPI() }},
+		{name: "NetworkingAPI", option: WithNetworkingAPI(impl), get: func(k *K8sAPI) any { return k.GetNetworkingAPI() }},
+		{name: "EndpointSliceAPI", option: WithEndpointSliceAPI(impl), get: func(k *K8sAPI) any { return k.GetEndpointSliceAPI() }},
+		{name: "StorageAPI", option: WithStorageAPI(impl), get: func(k *K8sAPI) any { return k.GetStorageAPI() }},
+		{name: "CustomResourceAPI", option: WithCustomResourceAPI(impl), get: func(k *K8sAPI) any { return k.GetCustomResourceAPI() }},
+		{name: "DiscoveryAPI", option: WithDiscoveryAPI(impl), get: func(k *K8sAPI) any { return k.GetDiscoveryAPI() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthenticator := mockauth.NewMockAuthenticator(t)
+			mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+			mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+			k8sAPI, err := NewK8sAPI(mockAuthenticator, tt.option)
+			require.NoError(t, err)
+
+			assert.Same(t, impl, tt.get(k8sAPI))
+		})
+	}
+}
+
+// stubPodAPI is a PodAPI listing a fixed set of pods.
+type stubPodAPI struct {
+	api.PodAPI
+	pods []corev1.Pod
+}
+
+func (p stubPodAPI) ListPodsByLabel(context.Context, string, string) ([]corev1.Pod, error) {
+	return p.pods, nil
+}
+
+func TestNewK8sApi_WithPodAPIDependents(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	pods := stubPodAPI{pods: []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "backup-x7k2p", Namespace: "default"}}}}
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithPodAPI(pods))
+	require.NoError(t, err)
+
+	// JobAPI resolves the pods of a job through the custom PodAPI.
+	result, err := k8sAPI.GetJobAPI().ListPodsForJob(context.Background(), "default", "backup")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "backup-x7k2p", result[0].Name)
+}
+
+func TestNewK8sApi_WithMetrics(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	reg := prometheus.NewPedanticRegistry()
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithMetrics(reg), WithNamespaceAllowlist("default"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+	require.NoError(t, err)
+	_, err = k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "missing")
+	require.Error(t, err)
+	// Pods listed on behalf of JobAPI are recorded too.
+	_, err = k8sAPI.GetJobAPI().ListPodsForJob(ctx, "default", "backup")
+	require.NoError(t, err)
+	// Calls rejected by the allowlist never reach the apiserver and are not recorded.
+	_, err = k8sAPI.GetPodAPI().GetPodByName(ctx, "kube-system", "dns")
+	require.ErrorIs(t, err, api.ErrNamespaceNotAllowed)
+
+	expected := `
+		# HELP kaudit_api_calls_total Number of calls made through the resource APIs.
+		# TYPE kaudit_api_calls_total counter
+		kaudit_api_calls_total{outcome="not_found",resource="pods",source="apiserver",verb="get"} 1
+		kaudit_api_calls_total{outcome="success",resource="pods",source="apiserver",verb="get"} 1
+		kaudit_api_calls_total{outcome="success",resource="pods",source="apiserver",verb="list"} 1
+	`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "kaudit_api_calls_total"))
+}
+
+func TestNewK8sApi_WithMetricsAndCache(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	reg := prometheus.NewPedanticRegistry()
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithMetrics(reg), WithCache())
+	require.NoError(t, err)
+	k8sAPI.Start()
+	t.Cleanup(k8sAPI.Stop)
+	ctx := context.Background()
+	require.NoError(t, k8sAPI.WaitForCacheSync(ctx))
+
+	_, err = k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+	require.NoError(t, err)
+	// Watches are served by the apiserver even when reads are cached.
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, err = k8sAPI.GetPodAPI().WatchPodsByLabel(watchCtx, "default", "app=web")
+	require.NoError(t, err)
+
+	expected := `
+		# HELP kaudit_api_calls_total Number of calls made through the resource APIs.
+		# TYPE kaudit_api_calls_total counter
+		kaudit_api_calls_total{outcome="success",resource="pods",source="apiserver",verb="watch"} 1
+		kaudit_api_calls_total{outcome="success",resource="pods",source="cache",verb="get"} 1
+	`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "kaudit_api_calls_total"))
+}
+
+func TestNewK8sApi_WithMetricsConflict(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fake.NewClientset(), nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "kaudit_api_calls_total", Help: "Taken."}))
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithMetrics(reg))
+
+	require.Error(t, err)
+	assert.Nil(t, k8sAPI)
+	assert.Contains(t, err.Error(), "failed to register metrics")
+}
+
+func TestNewK8sApi_WithTracerProvider(t *testing.T) {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	labels := map[string]string{"app": "web"}
+	fakeClientset := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: labels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: labels}},
+	)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	k8sAPI, err := NewK8sAPI(mockAuthenticator, WithTracerProvider(tp), WithNamespaceAllowlist("default"))
+	require.NoError(t, err)
+
+	attributes := func(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+		attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+		for _, kv := range span.Attributes {
+			attrs[kv.Key] = kv.Value
+		}
+		return attrs
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		exporter.Reset()
+		ctx, parent := tp.Tracer("test").Start(context.Background(), "audit")
+		_, err := k8sAPI.GetPodAPI().GetPodByName(ctx, "default", "web")
+		parent.End()
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		span := spans[0]
+		assert.Equal(t, "PodAPI.GetPodByName", span.Name)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Equal(t, codes.Unset, span.Status.Code)
+
+		attrs := attributes(span)
+		assert.Equal(t, "pods", attrs["k8s.resource"].AsString())
+		assert.Equal(t, "get", attrs["k8s.verb"].AsString())
+		assert.Equal(t, "default", attrs["k8s.namespace.name"].AsString())
+		assert.Equal(t, "web", attrs["k8s.object.name"].AsString())
+		assert.Equal(t, int64(1), attrs["k8s.result.count"].AsInt64())
+		assert.NotContains(t, attrs, attribute.Key("error.type"))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		exporter.Reset()
+		_, err := k8sAPI.GetDeploymentAPI().ListDeploymentsByLabel(context.Background(), "default", "app=web")
+		require.NoError(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "DeploymentAPI.ListDeploymentsByLabel", spans[0].Name)
+
+		attrs := attributes(spans[0])
+		assert.Equal(t, "deployments", attrs["k8s.resource"].AsString())
+		assert.Equal(t, "app=web", attrs["k8s.label_selector"].AsString())
+		assert.Equal(t, int64(2), attrs["k8s.result.count"].AsInt64())
+	})
+
+	t.Run("Paged", func(t *testing.T) {
+		exporter.Reset()
+		for _, err := range k8sAPI.GetDeploymentAPI().ListDeploymentsByLabelPaged(context.Background(), "default", "app=web", 1) {
+			require.NoError(t, err)
+		}
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, int64(2), attributes(spans[0])["k8s.result.count"].AsInt64())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		exporter.Reset()
+		_, err := k8sAPI.GetPodAPI().GetPodByName(context.Background(), "default", "missing")
+		require.Error(t, err)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Equal(t, "not_found", attributes(span)["error.type"].AsString())
+		assert.NotContains(t, attributes(span), attribute.Key("k8s.result.count"))
+		require.Len(t, span.Events, 1)
+		assert.Equal(t, "exception", span.Events[0].Name)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		exporter.Reset()
+		_, err := k8sAPI.GetSecretAPI().ListSecretsByField(context.Background(), "kube-system", "type=Opaque")
+		require.ErrorIs(t, err, api.ErrNamespaceNotAllowed)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		attrs := attributes(spans[0])
+		assert.Equal(t, "type=Opaque", attrs["k8s.field_selector"].AsString())
+		assert.Equal(t, "forbidden", attrs["error.type"].AsString())
+	})
+}
@@ -0,0 +1,262 @@
+package k8sapi
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kaudit/auth"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultParallelism is the number of clusters a MultiClusterAPI queries at once unless
+// WithParallelism says otherwise.
+const DefaultParallelism = 8
+
+// MultiClusterOption configures a MultiClusterAPI created with NewMultiClusterAPI.
+type MultiClusterOption func(*multiClusterConfig)
+
+type multiClusterConfig struct {
+	parallelism int
+	opts        []Option
+}
+
+// WithParallelism bounds the number of clusters queried at once to n. It defaults to
+// DefaultParallelism; values below one are ignored.
+func WithParallelism(n int) MultiClusterOption {
+	return func(c *multiClusterConfig) {
+		if n > 0 {
+			c.parallelism = n
+		}
+	}
+}
+
+// WithClusterOptions applies opts to the K8sAPI of every cluster, e.g. WithTimeout. A
+// rate limit given with WithRateLimit applies to each cluster separately, and so does a
+// cache given with WithCache, whose informers are controlled with MultiClusterAPI.Start,
+// WaitForCacheSync and Stop.
+func WithClusterOptions(opts ...Option) MultiClusterOption {
+	return func(c *multiClusterConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// MultiClusterAPI holds one K8sAPI per cluster, keyed by cluster name, and runs queries
+// against several of them concurrently.
+//
+// A MultiClusterAPI is safe for concurrent use.
+type MultiClusterAPI struct {
+	clusters    map[string]*K8sAPI
+	parallelism int
+}
+
+// NewMultiClusterAPI initializes a K8sAPI, as NewK8sAPI does, for every cluster of auths,
+// keyed by cluster name.
+//
+// Clusters that cannot be initialized are left out: the MultiClusterAPI of the others is
+// returned together with a ClusterErrors error naming them, so that one unreachable
+// cluster does not take down the registry. It returns nil only when no cluster could be
+// initialized.
+func NewMultiClusterAPI(auths map[string]auth.Authenticator, opts ...MultiClusterOption) (*MultiClusterAPI, error) {
+	cfg := multiClusterConfig{parallelism: DefaultParallelism}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	m := &MultiClusterAPI{
+		clusters:    make(map[string]*K8sAPI, len(auths)),
+		parallelism: cfg.parallelism,
+	}
+	errs := make(ClusterErrors)
+	for name, authenticator := range auths {
+		k, err := NewK8sAPI(authenticator, cfg.opts...)
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		m.clusters[name] = k
+	}
+	if len(errs) == 0 {
+		return m, nil
+	}
+	err := fmt.Errorf("failed to init clusters: %w", errs)
+	if len(m.clusters) == 0 {
+		return nil, err
+	}
+	return m, err
+}
+
+// Clusters returns the names of the clusters, sorted.
+func (m *MultiClusterAPI) Clusters() []string {
+	return slices.Sorted(maps.Keys(m.clusters))
+}
+
+// Start launches the informers of every cluster, see K8sAPI.Start.
+func (m *MultiClusterAPI) Start() {
+	for _, k := range m.clusters {
+		k.Start()
+	}
+}
+
+// WaitForCacheSync blocks until the informers of every cluster have completed their
+// initial listing, or ctx is done, see K8sAPI.WaitForCacheSync. The clusters are waited
+// for concurrently, with the configured parallelism; the returned ClusterErrors names
+// every cluster whose caches did not sync.
+func (m *MultiClusterAPI) WaitForCacheSync(ctx context.Context) error {
+	results, err := FanOut(ctx, m, nil, func(ctx context.Context, k *K8sAPI) (struct{}, error) {
+		return struct{}{}, k.WaitForCacheSync(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	errs := make(ClusterErrors)
+	for _, result := range results {
+		if result.Err != nil {
+			errs[result.Cluster] = result.Err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Stop shuts down the informers of every cluster, see K8sAPI.Stop.
+func (m *MultiClusterAPI) Stop() {
+	for _, k := range m.clusters {
+		k.Stop()
+	}
+}
+
+// Cluster returns the K8sAPI of the named cluster, and whether there is one.
+func (m *MultiClusterAPI) Cluster(name string) (*K8sAPI, bool) {
+	k, ok := m.clusters[name]
+	return k, ok
+}
+
+// ListPodsByLabel lists pods by namespace and label selector in the given clusters, or
+// in all clusters when none are given, see PodAPI.ListPodsByLabel.
+//
+// Returns the pods of every cluster that answered, tagged with the cluster name and in
+// cluster order. When some clusters failed, the pods of the others are returned together
+// with a ClusterErrors error.
+func (m *MultiClusterAPI) ListPodsByLabel(ctx context.Context, clusters []string, namespace, labelSelector string) ([]ClusterItem[corev1.Pod], error) {
+	results, err := FanOut(ctx, m, clusters, func(ctx context.Context, k *K8sAPI) ([]corev1.Pod, error) {
+		return k.GetPodAPI().ListPodsByLabel(ctx, namespace, labelSelector)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Items(results)
+}
+
+// ClusterResult is the outcome of a query against one cluster.
+type ClusterResult[T any] struct {
+	Cluster string
+	Value   T
+	Err     error
+}
+
+// ClusterItem is an item returned by a cluster, tagged with the cluster name.
+type ClusterItem[T any] struct {
+	Cluster string
+	Item    T
+}
+
+// FanOut runs query against the given clusters of m, or against all clusters when none
+// are given, with at most the configured number of queries running at once.
+//
+// Returns one result per cluster, in the order the clusters were given or sorted by name.
+// The failure of a cluster is reported in its result and does not affect the others;
+// clusters still waiting for their turn once ctx is done fail with its error. An error is
+// returned, without running any query, only when a cluster is unknown or given twice.
+func FanOut[T any](ctx context.Context, m *MultiClusterAPI, clusters []string, query func(ctx context.Context, k *K8sAPI) (T, error)) ([]ClusterResult[T], error) {
+	if len(clusters) == 0 {
+		clusters = m.Clusters()
+	}
+	seen := make(map[string]bool, len(clusters))
+	for _, name := range clusters {
+		if _, ok := m.clusters[name]; !ok {
+			return nil, fmt.Errorf("failed to query clusters: unknown cluster %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("failed to query clusters: duplicate cluster %q", name)
+		}
+		seen[name] = true
+	}
+
+	results := make([]ClusterResult[T], len(clusters))
+	slots := make(chan struct{}, m.parallelism)
+	var wg sync.WaitGroup
+	for i, name := range clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].Cluster = name
+
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+			}
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Value, results[i].Err = query(ctx, m.clusters[name])
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// Items flattens the results of a query returning slices into the items of all
+// clusters, tagged with their cluster name. When some clusters failed, the items of the
+// others are returned together with a ClusterErrors error.
+func Items[T any](results []ClusterResult[[]T]) ([]ClusterItem[T], error) {
+	var items []ClusterItem[T]
+	errs := make(ClusterErrors)
+	for _, result := range results {
+		if result.Err != nil {
+			errs[result.Cluster] = result.Err
+			continue
+		}
+		for _, item := range result.Value {
+			items = append(items, ClusterItem[T]{Cluster: result.Cluster, Item: item})
+		}
+	}
+	if len(errs) > 0 {
+		return items, errs
+	}
+
+	return items, nil
+}
+
+// ClusterErrors reports the clusters a query failed for, keyed by cluster name. It
+// matches every error it holds with errors.Is and errors.As.
+type ClusterErrors map[string]error
+
+func (e ClusterErrors) Error() string {
+	var b strings.Builder
+	for i, name := range slices.Sorted(maps.Keys(e)) {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(&b, "cluster %q: %v", name, e[name])
+	}
+	return b.String()
+}
+
+func (e ClusterErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, name := range slices.Sorted(maps.Keys(e)) {
+		errs = append(errs, e[name])
+	}
+	return errs
+}
@@ -0,0 +1,260 @@
+package k8sapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaudit/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+	mockauth "github.com/kaudit/api/mocks/Authenticator"
+)
+
+// clusterAuth returns an authenticator serving fakeClientset.
+func clusterAuth(t *testing.T, fakeClientset *fake.Clientset) auth.Authenticator {
+	mockAuthenticator := mockauth.NewMockAuthenticator(t)
+	mockAuthenticator.EXPECT().NativeAPI().Return(fakeClientset, nil)
+	mockAuthenticator.EXPECT().DynamicAPI().Return(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), nil)
+	return mockAuthenticator
+}
+
+// webPod returns a pod labeled app=web.
+func webPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}}}
+}
+
+func TestNewMultiClusterAPI(t *testing.T) {
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset()),
+		"prod-us": clusterAuth(t, fake.NewClientset()),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prod-eu", "prod-us"}, m.Clusters())
+	k, ok := m.Cluster("prod-eu")
+	assert.True(t, ok)
+	assert.NotNil(t, k)
+	_, ok = m.Cluster("staging")
+	assert.False(t, ok)
+}
+
+func TestNewMultiClusterAPI_AuthFailure(t *testing.T) {
+	failing := mockauth.NewMockAuthenticator(t)
+	failing.EXPECT().NativeAPI().Return(nil, errors.New("expired token"))
+
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset()),
+		"prod-us": failing,
+	})
+
+	require.Error(t, err)
+	var clusterErrs ClusterErrors
+	require.ErrorAs(t, err, &clusterErrs)
+	assert.Contains(t, clusterErrs, "prod-us")
+	assert.NotContains(t, clusterErrs, "prod-eu")
+	assert.Contains(t, err.Error(), `cluster "prod-us": failed to init k8s client`)
+
+	// The clusters that could be initialized remain usable
+	require.NotNil(t, m)
+	assert.Equal(t, []string{"prod-eu"}, m.Clusters())
+	pods, err := m.ListPodsByLabel(context.Background(), nil, "default", "app=web")
+	require.NoError(t, err)
+	assert.Empty(t, pods)
+}
+
+func TestNewMultiClusterAPI_NoCluster(t *testing.T) {
+	failing := mockauth.NewMockAuthenticator(t)
+	failing.EXPECT().NativeAPI().Return(nil, errors.New("expired token"))
+
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{"prod-us": failing})
+
+	var clusterErrs ClusterErrors
+	require.ErrorAs(t, err, &clusterErrs)
+	assert.Contains(t, clusterErrs, "prod-us")
+	assert.Nil(t, m)
+}
+
+func TestMultiClusterAPI_ListPodsByLabel(t *testing.T) {
+	failing := fake.NewClientset()
+	failing.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("no access"))
+	})
+
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset(webPod("web-1"), webPod("web-2"))),
+		"prod-us": clusterAuth(t, fake.NewClientset(webPod("web-3"))),
+		"staging": clusterAuth(t, failing),
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("AllClusters", func(t *testing.T) {
+		items, err := m.ListPodsByLabel(ctx, nil, "default", "app=web")
+
+		var clusterErrs ClusterErrors
+		require.ErrorAs(t, err, &clusterErrs)
+		assert.Len(t, clusterErrs, 1)
+		assert.ErrorIs(t, clusterErrs["staging"], api.ErrForbidden)
+		assert.ErrorIs(t, err, api.ErrForbidden)
+
+		// The clusters that answered still return their pods.
+		require.Len(t, items, 3)
+		assert.Equal(t, "prod-eu", items[0].Cluster)
+		assert.Equal(t, "web-1", items[0].Item.Name)
+		assert.Equal(t, "prod-eu", items[1].Cluster)
+		assert.Equal(t, "web-2", items[1].Item.Name)
+		assert.Equal(t, "prod-us", items[2].Cluster)
+		assert.Equal(t, "web-3", items[2].Item.Name)
+	})
+
+	t.Run("Subset", func(t *testing.T) {
+		items, err := m.ListPodsByLabel(ctx, []string{"prod-us", "prod-eu"}, "default", "app=web")
+
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		// Items follow the order of the requested clusters.
+		assert.Equal(t, "prod-us", items[0].Cluster)
+		assert.Equal(t, "prod-eu", items[1].Cluster)
+	})
+
+	t.Run("UnknownCluster", func(t *testing.T) {
+		items, err := m.ListPodsByLabel(ctx, []string{"prod-eu", "dev"}, "default", "app=web")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown cluster "dev"`)
+		assert.Nil(t, items)
+	})
+
+	t.Run("DuplicateCluster", func(t *testing.T) {
+		items, err := m.ListPodsByLabel(ctx, []string{"prod-eu", "prod-us", "prod-eu"}, "default", "app=web")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `duplicate cluster "prod-eu"`)
+		assert.Nil(t, items)
+	})
+}
+
+func TestFanOut_Parallelism(t *testing.T) {
+	auths := make(map[string]auth.Authenticator)
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		auths[name] = clusterAuth(t, fake.NewClientset())
+	}
+	m, err := NewMultiClusterAPI(auths, WithParallelism(2))
+	require.NoError(t, err)
+
+	var running, peak atomic.Int32
+	results, err := FanOut(context.Background(), m, nil, func(ctx context.Context, k *K8sAPI) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := peak.Load()
+			if n <= current || peak.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return int(n), nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+	for i, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		assert.Equal(t, name, results[i].Cluster)
+		assert.NoError(t, results[i].Err)
+	}
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestFanOut_Canceled(t *testing.T) {
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"a": clusterAuth(t, fake.NewClientset()),
+		"b": clusterAuth(t, fake.NewClientset()),
+	}, WithParallelism(1))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := FanOut(ctx, m, nil, func(ctx context.Context, k *K8sAPI) (string, error) {
+		// The first cluster to run cancels the query; the other one never gets a slot.
+		cancel()
+		return "done", nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	var done, canceled int
+	for _, result := range results {
+		if result.Err != nil {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+			canceled++
+		} else {
+			assert.Equal(t, "done", result.Value)
+			done++
+		}
+	}
+	assert.Equal(t, 1, done)
+	assert.Equal(t, 1, canceled)
+}
+
+func TestNewMultiClusterAPI_WithClusterOptions(t *testing.T) {
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset(webPod("web-1"))),
+		"prod-us": clusterAuth(t, fake.NewClientset(webPod("web-2"))),
+	}, WithClusterOptions(WithNamespaceAllowlist("payments")))
+	require.NoError(t, err)
+
+	items, err := m.ListPodsByLabel(context.Background(), nil, "default", "app=web")
+
+	require.ErrorIs(t, err, api.ErrNamespaceNotAllowed)
+	assert.Empty(t, items)
+	var clusterErrs ClusterErrors
+	require.ErrorAs(t, err, &clusterErrs)
+	assert.Len(t, clusterErrs, 2)
+}
+
+func TestMultiClusterAPI_WithCache(t *testing.T) {
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset(webPod("web-1"))),
+		"prod-us": clusterAuth(t, fake.NewClientset(webPod("web-2"))),
+	}, WithClusterOptions(WithCache()))
+	require.NoError(t, err)
+
+	m.Start()
+	defer m.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.WaitForCacheSync(ctx))
+
+	items, err := m.ListPodsByLabel(ctx, nil, "default", "app=web")
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "prod-eu", items[0].Cluster)
+	assert.Equal(t, "prod-us", items[1].Cluster)
+}
+
+func TestMultiClusterAPI_WaitForCacheSync_NotStarted(t *testing.T) {
+	m, err := NewMultiClusterAPI(map[string]auth.Authenticator{
+		"prod-eu": clusterAuth(t, fake.NewClientset()),
+	}, WithClusterOptions(WithCache()))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = m.WaitForCacheSync(ctx)
+
+	var clusterErrs ClusterErrors
+	require.ErrorAs(t, err, &clusterErrs)
+	assert.Contains(t, clusterErrs, "prod-eu")
+}
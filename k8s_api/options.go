@@ -0,0 +1,310 @@
+package k8sapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/metadata"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/cache_api"
+	"github.com/kaudit/api/metrics_api"
+)
+
+// Option configures a K8sAPI created with NewK8sAPI.
+type Option func(*config)
+
+type config struct {
+	retry     api.RetryPolicy
+	limit     api.RateLimit
+	logger    *slog.Logger
+	timeout   time.Duration
+	allowlist map[string]bool
+	cached    bool
+	cacheOpts []cacheapi.Option
+	// registerer receives the metrics, which are created by NewK8sAPI.
+	registerer prometheus.Registerer
+	metrics    *metricsapi.Metrics
+	// tracerProvider creates the spans of resource API calls.
+	tracerProvider trace.TracerProvider
+	// secretMetadata fetches the metadata of Secrets when set.
+	secretMetadata metadata.Interface
+	// apis holds the implementations replacing the default ones.
+	apis K8sAPI
+}
+
+// newConfig applies opts on top of the defaults.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		retry:  api.DefaultRetryPolicy(),
+		logger: slog.New(discardHandler{}),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// metricsSource returns the source recorded by the metrics of a core resource API,
+// which is served from the cache unless replaced by a custom implementation.
+func (c config) metricsSource(custom bool) metricsapi.Option {
+	if c.cached && !custom {
+		return metricsapi.WithSource(metricsapi.SourceCache)
+	}
+	return metricsapi.WithSource(metricsapi.SourceAPIServer)
+}
+
+// WithRetryPolicy sets the policy retrying the Get and List requests of PodAPI,
+// ServiceAPI, DeploymentAPI and NamespaceAPI that fail with a transient apiserver
+// error. It defaults to api.DefaultRetryPolicy; the zero api.RetryPolicy disables
+// retries.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(c *config) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimit bounds the requests sent to the apiserver by all resource APIs
+// together: they share a single token bucket of limit.QPS and limit.Burst, and at most
+// limit.MaxInFlight of their requests are pending at once. Requests are not limited by
+// default.
+//
+// Calls made with a context from api.WithRequestStats report the time their requests
+// spent waiting to be admitted.
+func WithRateLimit(limit api.RateLimit) Option {
+	return func(c *config) {
+		c.limit = limit
+	}
+}
+
+// WithLogger sets the logger reporting the lifecycle of the instance, such as cache
+// synchronization, and the calls rejected by WithNamespaceAllowlist. Every resource API
+// also logs its requests to it, see podapi.WithLogger, and the core ones their reads
+// from the cache when WithCache is set, see cacheapi.WithLogger. Custom implementations
+// given with options such as WithPodAPI are left as they are. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithTimeout bounds every call to a resource API whose context has no deadline yet to
+// timeout. Watch and Paged methods are exempt: their results keep flowing after the
+// call returns. Calls are not bounded by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// WithNamespaceAllowlist restricts the resource APIs to the given namespaces.
+//
+// Calls addressing another namespace fail with an error matching
+// api.ErrNamespaceNotAllowed without reaching the apiserver. Calls spanning namespaces,
+// such as listing Namespaces or RBAC queries, have the items of other namespaces
+// removed from their results; cluster-scoped objects are kept. Without namespaces,
+// every namespace is rejected. The option may be repeated to extend the allowlist.
+func WithNamespaceAllowlist(namespaces ...string) Option {
+	return func(c *config) {
+		if c.allowlist == nil {
+			c.allowlist = make(map[string]bool, len(namespaces))
+		}
+		for _, namespace := range namespaces {
+			c.allowlist[namespace] = true
+		}
+	}
+}
+
+// WithCache serves PodAPI, ServiceAPI, DeploymentAPI and NamespaceAPI from shared
+// informer caches configured by opts, see NewCachedK8sAPI.
+func WithCache(opts ...cacheapi.Option) Option {
+	return func(c *config) {
+		c.cached = true
+		c.cacheOpts = append(c.cacheOpts, opts...)
+	}
+}
+
+// WithMetrics instruments PodAPI, ServiceAPI, DeploymentAPI and NamespaceAPI with
+// Prometheus metrics registered with reg, see metricsapi.Metrics. Calls made on behalf
+// of other APIs, such as JobAPI resolving the pods of a job, are recorded as well, and
+// the calls served from the cache of WithCache are labeled as such. Nothing is recorded
+// by default.
+func WithMetrics(reg prometheus.Registerer) Option {
+	return func(c *config) {
+		c.registerer = reg
+	}
+}
+
+// WithTracerProvider traces every call to a resource API with a span started from the
+// context of the call. Spans are named after the method, e.g. "PodAPI.GetPodByName", and
+// carry the resource, namespace, selectors, result count and error class of the call.
+// Nothing is traced by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithPodAPI replaces the PodAPI with a custom implementation. JobAPI, NodeAPI,
+// ServiceAccountAPI and StorageAPI resolve pods through it.
+func WithPodAPI(pods api.PodAPI) Option {
+	return func(c *config) {
+		c.apis.pods = pods
+	}
+}
+
+// WithServiceAPI replaces the ServiceAPI with a custom implementation.
+func WithServiceAPI(services api.ServiceAPI) Option {
+	return func(c *config) {
+		c.apis.services = services
+	}
+}
+
+// WithDeploymentAPI replaces the DeploymentAPI with a custom implementation.
+func WithDeploymentAPI(deployments api.DeploymentAPI) Option {
+	return func(c *config) {
+		c.apis.deployments = deployments
+	}
+}
+
+// WithNamespaceAPI replaces the NamespaceAPI with a custom implementation.
+func WithNamespaceAPI(namespaces api.NamespaceAPI) Option {
+	return func(c *config) {
+		c.apis.namespaces = namespaces
+	}
+}
+
+// WithStatefulSetAPI replaces the StatefulSetAPI with a custom implementation.
+func WithStatefulSetAPI(statefulSets api.StatefulSetAPI) Option {
+	return func(c *config) {
+		c.apis.statefulSets = statefulSets
+	}
+}
+
+// WithDaemonSetAPI replaces the DaemonSetAPI with a custom implementation.
+func WithDaemonSetAPI(daemonSets api.DaemonSetAPI) Option {
+	return func(c *config) {
+		c.apis.daemonSets = daemonSets
+	}
+}
+
+// WithReplicaSetAPI replaces the ReplicaSetAPI with a custom implementation.
+func WithReplicaSetAPI(replicaSets api.ReplicaSetAPI) Option {
+	return func(c *config) {
+		c.apis.replicaSets = replicaSets
+	}
+}
+
+// WithJobAPI replaces the JobAPI with a custom implementation. CronJobAPI resolves jobs
+// through it.
+func WithJobAPI(jobs api.JobAPI) Option {
+	return func(c *config) {
+		c.apis.jobs = jobs
+	}
+}
+
+// WithCronJobAPI replaces the CronJobAPI with a custom implementation.
+func WithCronJobAPI(cronJobs api.CronJobAPI) Option {
+	return func(c *config) {
+		c.apis.cronJobs = cronJobs
+	}
+}
+
+// WithConfigMapAPI replaces the ConfigMapAPI with a custom implementation.
+func WithConfigMapAPI(configMaps api.ConfigMapAPI) Option {
+	return func(c *config) {
+		c.apis.configMaps = configMaps
+	}
+}
+
+// WithSecretAPI replaces the SecretAPI with a custom implementation.
+func WithSecretAPI(secrets api.SecretAPI) Option {
+	return func(c *config) {
+		c.apis.secrets = secrets
+	}
+}
+
+// WithSecretMetadataClient makes the SecretAPI fetch the metadata of Secrets through
+// client rather than the REST client of the core API group; see
+// secretapi.WithMetadataClient. Either way, Secret payloads are never requested.
+func WithSecretMetadataClient(client metadata.Interface) Option {
+	return func(c *config) {
+		c.secretMetadata = client
+	}
+}
+
+// WithRBACAPI replaces the RBACAPI with a custom implementation.
+func WithRBACAPI(rbac api.RBACAPI) Option {
+	return func(c *config) {
+		c.apis.rbac = rbac
+	}
+}
+
+// WithServiceAccountAPI replaces the ServiceAccountAPI with a custom implementation.
+func WithServiceAccountAPI(accounts api.ServiceAccountAPI) Option {
+	return func(c *config) {
+		c.apis.accounts = accounts
+	}
+}
+
+// WithNodeAPI replaces the NodeAPI with a custom implementation.
+func WithNodeAPI(nodes api.NodeAPI) Option {
+	return func(c *config) {
+		c.apis.nodes = nodes
+	}
+}
+
+// WithEventAPI replaces the EventAPI with a custom implementation.
+func WithEventAPI(events api.EventAPI) Option {
+	return func(c *config) {
+		c.apis.events = events
+	}
+}
+
+// WithNetworkingAPI replaces the NetworkingAPI with a custom implementation.
+func WithNetworkingAPI(networking api.NetworkingAPI) Option {
+	return func(c *config) {
+		c.apis.networking = networking
+	}
+}
+
+// WithEndpointSliceAPI replaces the EndpointSliceAPI with a custom implementation.
+func WithEndpointSliceAPI(endpointSlices api.EndpointSliceAPI) Option {
+	return func(c *config) {
+		c.apis.endpointSlices = endpointSlices
+	}
+}
+
+// WithStorageAPI replaces the StorageAPI with a custom implementation.
+func WithStorageAPI(storage api.StorageAPI) Option {
+	return func(c *config) {
+		c.apis.storage = storage
+	}
+}
+
+// WithCustomResourceAPI replaces the CustomResourceAPI with a custom implementation.
+func WithCustomResourceAPI(customResources api.CustomResourceAPI) Option {
+	return func(c *config) {
+		c.apis.customResources = customResources
+	}
+}
+
+// WithDiscoveryAPI replaces the DiscoveryAPI with a custom implementation.
+func WithDiscoveryAPI(discovery api.DiscoveryAPI) Option {
+	return func(c *config) {
+		c.apis.discovery = discovery
+	}
+}
+
+// discardHandler drops every record. It backs the default logger.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
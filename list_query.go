@@ -0,0 +1,43 @@
+package api
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListQuery selects the objects the List*ByQuery methods return. Unlike the ByLabel and
+// ByField methods, it can send a label and a field selector in the same request, and
+// controls the number of objects returned and the freshness of the result.
+//
+// Every field is optional: the zero ListQuery lists every object of the scope. The
+// namespaced List*ByQuery methods take an empty namespace as every namespace, listed in
+// a single request.
+type ListQuery struct {
+	// LabelSelector and FieldSelector restrict the objects returned, in Kubernetes
+	// selector syntax, e.g. "app=web,tier in (frontend)" and "status.phase=Running".
+	// When both are set an object must match both. LabelSelectorBuilder and
+	// FieldSelectorBuilder build them from typed requirements.
+	LabelSelector string `validate:"omitempty,k8s_label_selector"`
+	FieldSelector string `validate:"omitempty,k8s_field_selector"`
+	// Limit caps the number of objects returned by the single request sent; zero
+	// returns every object. The objects beyond Limit are not fetched, use the Paged
+	// methods to read a large result in full.
+	Limit int64 `validate:"gte=0"`
+	// ResourceVersion and ResourceVersionMatch set how fresh the result must be. An
+	// empty ResourceVersion requests the most recent data, "0" accepts any data held by
+	// the apiserver cache. ResourceVersionMatch, "NotOlderThan" or "Exact", requires a
+	// ResourceVersion. See
+	// https://kubernetes.io/docs/reference/using-api/api-concepts/#resource-versions.
+	ResourceVersion      string                      `validate:"required_with=ResourceVersionMatch"`
+	ResourceVersionMatch metav1.ResourceVersionMatch `validate:"omitempty,oneof=NotOlderThan Exact"`
+}
+
+// ListOptions returns the metav1.ListOptions of a request sending q.
+func (q ListQuery) ListOptions() metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector:        q.LabelSelector,
+		FieldSelector:        q.FieldSelector,
+		Limit:                q.Limit,
+		ResourceVersion:      q.ResourceVersion,
+		ResourceVersionMatch: q.ResourceVersionMatch,
+	}
+}
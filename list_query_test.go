@@ -0,0 +1,87 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/kaudit/val"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListQuery_ListOptions(t *testing.T) {
+	query := ListQuery{
+		LabelSelector:        "app=web",
+		FieldSelector:        "status.phase=Running",
+		Limit:                50,
+		ResourceVersion:      "1234",
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+	}
+
+	assert.Equal(t, metav1.ListOptions{
+		LabelSelector:        "app=web",
+		FieldSelector:        "status.phase=Running",
+		Limit:                50,
+		ResourceVersion:      "1234",
+		ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan,
+	}, query.ListOptions())
+}
+
+func TestListQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ListQuery
+		wantErr bool
+	}{
+		{
+			name:  "Zero query",
+			query: ListQuery{},
+		},
+		{
+			name: "All fields",
+			query: ListQuery{
+				LabelSelector:        "app=web,tier in (frontend)",
+				FieldSelector:        "status.phase=Running",
+				Limit:                10,
+				ResourceVersion:      "1234",
+				ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+			},
+		},
+		{
+			name:    "Invalid label selector",
+			query:   ListQuery{LabelSelector: "app==web=="},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid field selector",
+			query:   ListQuery{FieldSelector: "status.phase"},
+			wantErr: true,
+		},
+		{
+			name:    "Negative limit",
+			query:   ListQuery{Limit: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Match without resource version",
+			query:   ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown match",
+			query:   ListQuery{ResourceVersion: "1234", ResourceVersionMatch: "Newest"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := val.ValidateStruct(tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
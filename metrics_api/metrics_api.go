@@ -0,0 +1,198 @@
+package metricsapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/intercept"
+)
+
+// Outcomes of a call, as recorded in the outcome label.
+const (
+	OutcomeSuccess    = "success"
+	OutcomeNotFound   = "not_found"
+	OutcomeForbidden  = "forbidden"
+	OutcomeValidation = "validation"
+	OutcomeTimeout    = "timeout"
+	OutcomeConflict   = "conflict"
+	OutcomeCanceled   = "canceled"
+	OutcomeError      = "error"
+)
+
+// Sources of the results of a call, as recorded in the source label.
+const (
+	SourceAPIServer = "apiserver"
+	SourceCache     = "cache"
+)
+
+var labels = []string{"resource", "verb", "source", "outcome"}
+
+// Metrics records the calls made through the resource APIs it instruments:
+//
+//   - kaudit_api_calls_total counts calls.
+//   - kaudit_api_call_duration_seconds observes their latency.
+//   - kaudit_api_call_objects observes the number of objects they returned.
+//
+// They measure calls to the API methods, not the requests sent to the apiserver: a call
+// retried by the resource API or a Paged call reading several pages sends several
+// requests, and a call served from a cache sends none. api.WithRequestStats counts the
+// requests of a call.
+//
+// Every metric is labeled by resource ("pods"), verb ("get", "list" or "watch"), source
+// (SourceAPIServer or SourceCache, see WithSource) and outcome (see the Outcome
+// constants). A Paged call is measured over the whole iteration and reports the number
+// of items yielded; a Watch call is measured until the watch is open and does not
+// report objects.
+//
+// A Metrics is safe for concurrent use and may instrument any number of APIs.
+type Metrics struct {
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	objects  *prometheus.HistogramVec
+}
+
+// Option configures the instrumentation of a resource API.
+type Option func(*instrument)
+
+// WithSource sets the source label recorded for the calls of the instrumented API,
+// SourceAPIServer by default. Instrument cache-backed APIs with SourceCache; their
+// watches, which are served by the apiserver, are still recorded with SourceAPIServer.
+func WithSource(source string) Option {
+	return func(i *instrument) {
+		i.source = source
+	}
+}
+
+// instrument records the calls of one resource API into metrics.
+type instrument struct {
+	metrics *Metrics
+	source  string
+}
+
+// NewMetrics creates the metrics and registers them with reg. Registering with a reg
+// that already holds them, for example from another K8sAPI, reuses the registered
+// collectors so that both instances report into the same series.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	if reg == nil {
+		return nil, errors.New("failed to register metrics: nil registerer")
+	}
+
+	calls := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kaudit",
+		Subsystem: "api",
+		Name:      "calls_total",
+		Help:      "Number of calls made through the resource APIs.",
+	}, labels)
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kaudit",
+		Subsystem: "api",
+		Name:      "call_duration_seconds",
+		Help:      "Latency of the calls made through the resource APIs.",
+		Buckets:   prometheus.DefBuckets,
+	}, labels)
+	objects := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kaudit",
+		Subsystem: "api",
+		Name:      "call_objects",
+		Help:      "Number of objects returned by the calls made through the resource APIs.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+	}, labels)
+
+	var err error
+	m := &Metrics{}
+	if m.calls, err = register(reg, calls); err != nil {
+		return nil, err
+	}
+	if m.duration, err = register(reg, duration); err != nil {
+		return nil, err
+	}
+	if m.objects, err = register(reg, objects); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// register registers c with reg, or returns the equivalent collector reg already holds.
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	err := reg.Register(c)
+	if err == nil {
+		return c, nil
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(C); ok {
+			return existing, nil
+		}
+	}
+	return c, fmt.Errorf("failed to register metrics: %w", err)
+}
+
+// NewPodAPI returns a PodAPI recording the calls made to next into m.
+func NewPodAPI(next api.PodAPI, m *Metrics, opts ...Option) api.PodAPI {
+	return intercept.NewPodAPI(next, m.hooks(opts...))
+}
+
+// NewServiceAPI returns a ServiceAPI recording the calls made to next into m.
+func NewServiceAPI(next api.ServiceAPI, m *Metrics, opts ...Option) api.ServiceAPI {
+	return intercept.NewServiceAPI(next, m.hooks(opts...))
+}
+
+// NewDeploymentAPI returns a DeploymentAPI recording the calls made to next into m.
+func NewDeploymentAPI(next api.DeploymentAPI, m *Metrics, opts ...Option) api.DeploymentAPI {
+	return intercept.NewDeploymentAPI(next, m.hooks(opts...))
+}
+
+// NewNamespaceAPI returns a NamespaceAPI recording the calls made to next into m.
+func NewNamespaceAPI(next api.NamespaceAPI, m *Metrics, opts ...Option) api.NamespaceAPI {
+	return intercept.NewNamespaceAPI(next, m.hooks(opts...))
+}
+
+// hooks returns the hooks recording calls into m as configured by opts. A nil m records
+// nothing.
+func (m *Metrics) hooks(opts ...Option) intercept.Hooks {
+	if m == nil {
+		return intercept.Hooks{}
+	}
+	i := &instrument{metrics: m, source: SourceAPIServer}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return intercept.Hooks{Intercept: i.intercept}
+}
+
+// intercept records call into the metrics of i.
+func (i *instrument) intercept(ctx context.Context, call intercept.Call, invoke intercept.Invoker) (any, error) {
+	start := time.Now()
+	result, err := invoke(ctx)
+	elapsed := time.Since(start)
+
+	source := i.source
+	if call.Verb == "watch" {
+		source = SourceAPIServer
+	}
+
+	m := i.metrics
+	values := []string{call.Resource, call.Verb, source, Outcome(err)}
+	m.calls.WithLabelValues(values...).Inc()
+	m.duration.WithLabelValues(values...).Observe(elapsed.Seconds())
+	if count, ok := intercept.Count(result, err); ok {
+		m.objects.WithLabelValues(values...).Observe(float64(count))
+	}
+
+	return result, err
+}
+
+// Outcome returns the outcome label recorded for a call that returned err.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	return intercept.ErrorClass(err)
+}
@@ -0,0 +1,234 @@
+package metricsapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/deployment_api"
+	"github.com/kaudit/api/internal/intercept"
+	"github.com/kaudit/api/namespace_api"
+	"github.com/kaudit/api/pod_api"
+	"github.com/kaudit/api/service_api"
+)
+
+func TestNewMetrics(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+
+	first, err := NewMetrics(reg)
+	require.NoError(t, err)
+	// Registering again reuses the collectors instead of failing.
+	second, err := NewMetrics(reg)
+	require.NoError(t, err)
+	assert.Same(t, first.calls, second.calls)
+
+	_, err = NewMetrics(nil)
+	require.Error(t, err)
+}
+
+func TestNewMetrics_Conflict(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kaudit_api_calls_total",
+		Help: "Registered by someone else.",
+	}))
+
+	_, err := NewMetrics(reg)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to register metrics")
+}
+
+func TestNewPodAPI(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-3", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	)
+	reg := prometheus.NewPedanticRegistry()
+	metrics, err := NewMetrics(reg)
+	require.NoError(t, err)
+
+	pods := NewPodAPI(podapi.NewPodAPI(client), metrics)
+	ctx := context.Background()
+
+	_, err = pods.GetPodByName(ctx, "default", "web-1")
+	require.NoError(t, err)
+	_, err = pods.GetPodByName(ctx, "default", "missing")
+	require.Error(t, err)
+	_, err = pods.ListPodsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+	for _, err := range pods.ListPodsByLabelPaged(ctx, "default", "app=web", 2) {
+		require.NoError(t, err)
+	}
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	_, err = pods.WatchPodsByLabel(watchCtx, "default", "app=web")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "get", SourceAPIServer, OutcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "get", SourceAPIServer, OutcomeNotFound)))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "list", SourceAPIServer, OutcomeSuccess)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "watch", SourceAPIServer, OutcomeSuccess)))
+
+	// Every call is counted and timed; objects are observed for successful reads only.
+	assert.Equal(t, 4, testutil.CollectAndCount(metrics.calls))
+	assert.Equal(t, 4, testutil.CollectAndCount(metrics.duration))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.objects))
+
+	expected := `
+		# HELP kaudit_api_call_objects Number of objects returned by the calls made through the resource APIs.
+		# TYPE kaudit_api_call_objects histogram
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="1"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="4"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="16"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="64"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="256"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="1024"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="4096"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="16384"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="get",le="+Inf"} 1
+		kaudit_api_call_objects_sum{outcome="success",resource="pods",source="apiserver",verb="get"} 1
+		kaudit_api_call_objects_count{outcome="success",resource="pods",source="apiserver",verb="get"} 1
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="1"} 0
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="4"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="16"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="64"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="256"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="1024"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="4096"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="16384"} 2
+		kaudit_api_call_objects_bucket{outcome="success",resource="pods",source="apiserver",verb="list",le="+Inf"} 2
+		kaudit_api_call_objects_sum{outcome="success",resource="pods",source="apiserver",verb="list"} 6
+		kaudit_api_call_objects_count{outcome="success",resource="pods",source="apiserver",verb="list"} 2
+	`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "kaudit_api_call_objects"))
+}
+
+func TestNewAPIs_Resources(t *testing.T) {
+	client := fake.NewClientset(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)
+	metrics, err := NewMetrics(prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tests := []struct {
+		resource string
+		call     func() error
+	}{
+		{
+			resource: "services",
+			call: func() error {
+				_, err := NewServiceAPI(serviceapi.NewServiceAPI(client), metrics).GetServiceByName(ctx, "default", "web")
+				return err
+			},
+		},
+		{
+			resource: "deployments",
+			call: func() error {
+				_, err := NewDeploymentAPI(deploymentapi.NewDeploymentAPI(client), metrics).GetDeploymentByName(ctx, "default", "web")
+				return err
+			},
+		},
+		{
+			resource: "namespaces",
+			call: func() error {
+				_, err := NewNamespaceAPI(namespaceapi.NewNamespaceAPI(client), metrics).GetNamespaceByName(ctx, "default")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			require.NoError(t, tt.call())
+
+			assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues(tt.resource, "get", SourceAPIServer, OutcomeSuccess)))
+		})
+	}
+}
+
+func TestWithSource(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+	hooks := metrics.hooks(WithSource(SourceCache))
+	call := func(context.Context) (any, error) {
+		return nil, nil
+	}
+
+	_, err = hooks.Intercept(context.Background(), intercept.Call{Resource: "pods", Verb: "list"}, call)
+	require.NoError(t, err)
+	_, err = hooks.Intercept(context.Background(), intercept.Call{Resource: "pods", Verb: "watch"}, call)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "list", SourceCache, OutcomeSuccess)))
+	// Watches of a cache-backed API are served by the apiserver
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.calls.WithLabelValues("pods", "watch", SourceAPIServer, OutcomeSuccess)))
+}
+
+func TestNewPodAPI_NilMetrics(t *testing.T) {
+	pods := podapi.NewPodAPI(fake.NewClientset())
+
+	assert.Same(t, pods, NewPodAPI(pods, nil))
+}
+
+func TestOutcome(t *testing.T) {
+	gr := schema.GroupResource{Resource: "pods"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "Success", expected: OutcomeSuccess},
+		{name: "Not found", err: api.NewResourceError("Pod", "default", "web", "failed to get pod", apierrors.NewNotFound(gr, "web")), expected: OutcomeNotFound},
+		{name: "Forbidden", err: api.ErrNamespaceNotAllowed, expected: OutcomeForbidden},
+		{name: "Validation", err: api.NewValidationError("Pod", "default", "", "name", "invalid name", errors.New("required")), expected: OutcomeValidation},
+		{name: "Timeout", err: fmt.Errorf("failed to get pod: %w", context.DeadlineExceeded), expected: OutcomeTimeout},
+		{name: "Conflict", err: api.ErrConflict, expected: OutcomeConflict},
+		{name: "Canceled", err: context.Canceled, expected: OutcomeCanceled},
+		{name: "Other", err: errors.New("connection refused"), expected: OutcomeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Outcome(tt.err))
+		})
+	}
+}
+
+func TestMetrics_Duration(t *testing.T) {
+	metrics, err := NewMetrics(prometheus.NewPedanticRegistry())
+	require.NoError(t, err)
+
+	call := func(context.Context) (any, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}
+	_, err = metrics.hooks().Intercept(context.Background(), intercept.Call{Resource: "pods", Verb: "get"}, call)
+	require.NoError(t, err)
+
+	var sample dto.Metric
+	require.NoError(t, metrics.duration.WithLabelValues("pods", "get", SourceAPIServer, OutcomeSuccess).(prometheus.Histogram).Write(&sample))
+	assert.Equal(t, uint64(1), sample.GetHistogram().GetSampleCount())
+	assert.GreaterOrEqual(t, sample.GetHistogram().GetSampleSum(), 0.02)
+}
@@ -3,27 +3,73 @@ package namespaceapi
 import (
 	"context"
 	"fmt"
+	"iter"
+	"log/slog"
+	"time"
 
 	"github.com/kaudit/val"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/retry"
+	"github.com/kaudit/api/internal/throttle"
+	"github.com/kaudit/api/internal/watcher"
 )
 
 // NamespaceAPI provides high-level methods for retrieving and manipulating Kubernetes namespaces.
 type NamespaceAPI struct {
-	client kubernetes.Interface
+	client  kubernetes.Interface
+	retry   api.RetryPolicy
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a NamespaceAPI.
+type Option func(*NamespaceAPI)
+
+// WithRetryPolicy retries Get and List requests failing with a transient apiserver
+// error according to policy. By default requests are not retried.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(n *NamespaceAPI) {
+		n.retry = policy
+	}
+}
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(n *NamespaceAPI) {
+		n.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *NamespaceAPI) {
+		n.logger = logger
+	}
 }
 
 // NewNamespaceAPI creates a new NamespaceAPI instance with the provided Kubernetes client.
 //
 // The client parameter should be a valid implementation of kubernetes.Interface.
+// Options such as WithRetryPolicy, WithRateLimiter and WithLogger customize the instance.
 //
 // Returns an initialized *NamespaceAPI.
-func NewNamespaceAPI(client kubernetes.Interface) *NamespaceAPI {
-	return &NamespaceAPI{
+func NewNamespaceAPI(client kubernetes.Interface, opts ...Option) *NamespaceAPI {
+	n := &NamespaceAPI{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
 }
 
 // GetNamespaceByName retrieves a single Namespace object by its name.
@@ -39,13 +85,22 @@ func NewNamespaceAPI(client kubernetes.Interface) *NamespaceAPI {
 func (n *NamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (*corev1.Namespace, error) {
 	err := val.ValidateWithTag(name, "required")
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate namespace name: %w", err)
+		return nil, api.NewValidationError("Namespace", "", name, "name", "failed to validate namespace name", err)
 	}
 
-	ns, err := n.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	req := reqlog.Request{Verb: "get", Resource: "namespaces", Name: name}
+	start := time.Now()
+	ns, err := retry.Do(ctx, n.retry, func() (*corev1.Namespace, error) {
+		return throttle.Do(ctx, n.limiter, func() (*corev1.Namespace, error) {
+			return n.client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get namespace %q: %w", name, err)
+		err = api.NewResourceError("Namespace", "", name, fmt.Sprintf("failed to get namespace %q", name), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
 	return ns, nil
 }
 
@@ -63,17 +118,26 @@ func (n *NamespaceAPI) GetNamespaceByName(ctx context.Context, name string) (*co
 func (n *NamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector string) ([]corev1.Namespace, error) {
 	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate label selector: %w", err)
+		return nil, api.NewValidationError("Namespace", "", "", "labelSelector", "failed to validate label selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
 
-	list, err := n.client.CoreV1().Namespaces().List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, n.retry, func() (*corev1.NamespaceList, error) {
+		return throttle.Do(ctx, n.limiter, func() (*corev1.NamespaceList, error) {
+			return n.client.CoreV1().Namespaces().List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces by label %q: %w", labelSelector, err)
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to list namespaces by label %q", labelSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
@@ -92,17 +156,215 @@ func (n *NamespaceAPI) ListNamespacesByLabel(ctx context.Context, labelSelector
 func (n *NamespaceAPI) ListNamespacesByField(ctx context.Context, fieldSelector string) ([]corev1.Namespace, error) {
 	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate field selector: %w", err)
+		return nil, api.NewValidationError("Namespace", "", "", "fieldSelector", "failed to validate field selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	}
 
-	list, err := n.client.CoreV1().Namespaces().List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, n.retry, func() (*corev1.NamespaceList, error) {
+		return throttle.Do(ctx, n.limiter, func() (*corev1.NamespaceList, error) {
+			return n.client.CoreV1().Namespaces().List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to list namespaces by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNamespacesByQuery retrieves a list of Namespace objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of corev1.Namespace objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (n *NamespaceAPI) ListNamespacesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.Namespace, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "namespaces", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, n.retry, func() (*corev1.NamespaceList, error) {
+		return throttle.Do(ctx, n.limiter, func() (*corev1.NamespaceList, error) {
+			return n.client.CoreV1().Namespaces().List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces by field %q: %w", fieldSelector, err)
+		err = api.NewResourceError("Namespace", "", "", "failed to list namespaces by query", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
+
+// ListNamespacesByLabelPaged retrieves Namespace objects filtered by a label selector,
+// fetching them from the Kubernetes API in pages.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax, and pageSize must be a positive number.
+// Each page is requested lazily while the returned iterator is consumed.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//   - pageSize: The maximum number of namespaces requested per page.
+//
+// Returns an iterator over the matching corev1.Namespace objects. Validation and
+// API errors are yielded as the second value, after which iteration stops. An
+// expired continue token is reported as an error wrapping api.ErrContinueExpired.
+func (n *NamespaceAPI) ListNamespacesByLabelPaged(ctx context.Context, labelSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "labelSelector", "failed to validate label selector", err))
+	}
+	err = val.ValidateWithTag(pageSize, "gt=0")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "pageSize", "failed to validate page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, n.listPage("label", labelSelector))
+}
+
+// ListNamespacesByFieldPaged retrieves Namespace objects filtered by a field selector,
+// fetching them from the Kubernetes API in pages.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax, and pageSize must be a positive number.
+// Each page is requested lazily while the returned iterator is consumed.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//   - pageSize: The maximum number of namespaces requested per page.
+//
+// Returns an iterator over the matching corev1.Namespace objects. Validation and
+// API errors are yielded as the second value, after which iteration stops. An
+// expired continue token is reported as an error wrapping api.ErrContinueExpired.
+func (n *NamespaceAPI) ListNamespacesByFieldPaged(ctx context.Context, fieldSelector string, pageSize int64) iter.Seq2[corev1.Namespace, error] {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "fieldSelector", "failed to validate field selector", err))
+	}
+	err = val.ValidateWithTag(pageSize, "gt=0")
+	if err != nil {
+		return pager.Err[corev1.Namespace](api.NewValidationError("Namespace", "", "", "pageSize", "failed to validate page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, n.listPage("field", fieldSelector))
+}
+
+// WatchNamespacesByLabel watches Namespace objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax. Dropped connections are re-established in the background
+// from the last observed resourceVersion.
+//
+//   - ctx: The context to use for cancellation; the event channel is closed once it is done.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a channel of typed namespace events, or an error if the validation fails
+// or the initial watch cannot be established.
+func (n *NamespaceAPI) WatchNamespacesByLabel(ctx context.Context, labelSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "namespaces", LabelSelector: labelSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Namespace](ctx, opts, throttle.Watch(n.limiter, n.client.CoreV1().Namespaces().Watch))
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to watch namespaces by label %q", labelSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 0)
+
+	return events, nil
+}
+
+// WatchNamespacesByField watches Namespace objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax. Dropped connections are re-established in the background
+// from the last observed resourceVersion.
+//
+//   - ctx: The context to use for cancellation; the event channel is closed once it is done.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a channel of typed namespace events, or an error if the validation fails
+// or the initial watch cannot be established.
+func (n *NamespaceAPI) WatchNamespacesByField(ctx context.Context, fieldSelector string) (<-chan api.WatchEvent[*corev1.Namespace], error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Namespace", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "namespaces", FieldSelector: fieldSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Namespace](ctx, opts, throttle.Watch(n.limiter, n.client.CoreV1().Namespaces().Watch))
+	if err != nil {
+		err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to watch namespaces by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 0)
+
+	return events, nil
+}
+
+// listPage returns a pager.PageFunc listing namespaces matching the given selector.
+func (n *NamespaceAPI) listPage(selectorKind, selector string) pager.PageFunc[corev1.Namespace] {
+	return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Namespace, string, error) {
+		req := reqlog.Request{Verb: "list", Resource: "namespaces", LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+		start := time.Now()
+		list, err := retry.Do(ctx, n.retry, func() (*corev1.NamespaceList, error) {
+			return throttle.Do(ctx, n.limiter, func() (*corev1.NamespaceList, error) {
+				return n.client.CoreV1().Namespaces().List(ctx, opts)
+			})
+		})
+		if err != nil {
+			err = api.NewResourceError("Namespace", "", "", fmt.Sprintf("failed to list namespaces by %s %q", selectorKind, selector), err)
+			reqlog.Failed(ctx, n.logger, req, start, err)
+			return nil, "", err
+		}
+		reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+		return list.Items, list.Continue, nil
+	}
+}
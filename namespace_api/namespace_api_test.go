@@ -1,15 +1,29 @@
 package namespaceapi
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
 )
 
 func TestNewNamespaceAPI(t *testing.T) {
@@ -266,3 +280,637 @@ func TestNamespaceAPI_ListNamespacesByField(t *testing.T) {
 		})
 	}
 }
+
+func TestNamespaceAPI_ListNamespacesByQuery(t *testing.T) {
+	// Setup namespaces with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	nsAPI := NewNamespaceAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := nsAPI.ListNamespacesByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
+
+// pagingReactor serves namespaces listings from the fake object tracker in pages of
+// opts.Limit items, encoding the offset of the next page in the continue token.
+// Every served request is recorded in calls.
+func pagingReactor(client *fake.Clientset, calls *[]metav1.ListOptions) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listAction := action.(k8stesting.ListActionImpl)
+		opts := listAction.ListOptions
+		*calls = append(*calls, opts)
+
+		obj, err := client.Tracker().List(
+			corev1.SchemeGroupVersion.WithResource("namespaces"),
+			corev1.SchemeGroupVersion.WithKind("Namespace"),
+			listAction.GetNamespace(),
+		)
+		if err != nil {
+			return true, nil, err
+		}
+
+		var items []corev1.Namespace
+		for _, item := range obj.(*corev1.NamespaceList).Items {
+			if listAction.GetListRestrictions().Labels.Matches(labels.Set(item.Labels)) {
+				items = append(items, item)
+			}
+		}
+
+		offset, _ := strconv.Atoi(opts.Continue)
+		end := min(offset+int(opts.Limit), len(items))
+		page := &corev1.NamespaceList{Items: items[offset:end]}
+		if end < len(items) {
+			page.Continue = strconv.Itoa(end)
+		}
+		return true, page, nil
+	}
+}
+
+func TestNamespaceAPI_ListNamespacesByLabelPaged(t *testing.T) {
+	// Create test namespaces
+	var objects []runtime.Object
+	for i := range 5 {
+		objects = append(objects, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("namespace-%d", i),
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		})
+	}
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		labelSelector string
+		pageSize      int64
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Single page",
+			labelSelector: "app=test-app",
+			pageSize:      10,
+			wantCount:     5,
+			wantPages:     1,
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "failed to validate label selector",
+		},
+		{
+			name:          "Zero page size",
+			labelSelector: "app=test-app",
+			pageSize:      0,
+			wantErr:       true,
+			errMsg:        "failed to validate page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "namespaces", pagingReactor(client, &calls))
+			nsAPI := NewNamespaceAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var got []corev1.Namespace
+			var gotErr error
+			for item, err := range nsAPI.ListNamespacesByLabelPaged(ctx, tt.labelSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, item)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+				assert.Empty(t, got)
+				assert.Empty(t, calls)
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, got, tt.wantCount)
+				assert.Len(t, calls, tt.wantPages)
+				for _, call := range calls {
+					assert.Equal(t, tt.pageSize, call.Limit)
+					assert.Equal(t, tt.labelSelector, call.LabelSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestNamespaceAPI_ListNamespacesByFieldPaged(t *testing.T) {
+	// Create test namespaces
+	var objects []runtime.Object
+	for i := range 5 {
+		objects = append(objects, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("namespace-%d", i),
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		})
+	}
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		fieldSelector string
+		pageSize      int64
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Single page",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      10,
+			wantCount:     5,
+			wantPages:     1,
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "failed to validate field selector",
+		},
+		{
+			name:          "Zero page size",
+			fieldSelector: "metadata.name!=unknown",
+			pageSize:      0,
+			wantErr:       true,
+			errMsg:        "failed to validate page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "namespaces", pagingReactor(client, &calls))
+			nsAPI := NewNamespaceAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var got []corev1.Namespace
+			var gotErr error
+			for item, err := range nsAPI.ListNamespacesByFieldPaged(ctx, tt.fieldSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				got = append(got, item)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+				assert.Empty(t, got)
+				assert.Empty(t, calls)
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, got, tt.wantCount)
+				assert.Len(t, calls, tt.wantPages)
+				for _, call := range calls {
+					assert.Equal(t, tt.pageSize, call.Limit)
+					assert.Equal(t, tt.fieldSelector, call.FieldSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestNamespaceAPI_WatchNamespacesByLabel(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		labelSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			labelSelector: "app=test-app",
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errMsg:        "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errMsg:        "failed to validate label selector",
+		},
+		{
+			name:          "Watch refused",
+			labelSelector: "app=test-app",
+			watchErr:      apierrors.NewForbidden(corev1.Resource("namespaces"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch namespaces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("namespaces", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			namespaceAPI := NewNamespaceAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := namespaceAPI.WatchNamespacesByLabel(ctx, tt.labelSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestNamespaceAPI_WatchNamespacesByField(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		fieldSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			fieldSelector: "metadata.name=test",
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errMsg:        "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errMsg:        "failed to validate field selector",
+		},
+		{
+			name:          "Watch refused",
+			fieldSelector: "metadata.name=test",
+			watchErr:      apierrors.NewForbidden(corev1.Resource("namespaces"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch namespaces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("namespaces", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			namespaceAPI := NewNamespaceAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := namespaceAPI.WatchNamespacesByField(ctx, tt.fieldSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestNamespaceAPI_ErrorCategories(t *testing.T) {
+	resource := schema.GroupResource{Group: "", Resource: "namespaces"}
+
+	tests := []struct {
+		name     string
+		reactor  k8stesting.ReactionFunc
+		objName  string
+		category error
+		field    string
+	}{
+		{
+			name:     "Not found",
+			objName:  "missing",
+			category: api.ErrNotFound,
+		},
+		{
+			name: "Forbidden",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(resource, "payments", errors.New("rbac denied"))
+			},
+			objName:  "payments",
+			category: api.ErrForbidden,
+		},
+		{
+			name: "Timeout",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewTimeoutError("request timed out", 1)
+			},
+			objName:  "payments",
+			category: api.ErrTimeout,
+		},
+		{
+			name: "Conflict",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(resource, "payments", errors.New("object was modified"))
+			},
+			objName:  "payments",
+			category: api.ErrConflict,
+		},
+		{
+			name:     "Validation",
+			objName:  "",
+			category: api.ErrValidation,
+			field:    "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset()
+			if tt.reactor != nil {
+				fakeClient.PrependReactor("get", "namespaces", tt.reactor)
+			}
+			namespaceAPI := NewNamespaceAPI(fakeClient)
+
+			_, err := namespaceAPI.GetNamespaceByName(context.Background(), tt.objName)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.category)
+
+			if tt.field != "" {
+				var validationErr *api.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "Namespace", validationErr.Kind)
+				assert.Equal(t, tt.field, validationErr.Field)
+				return
+			}
+
+			var resourceErr *api.ResourceError
+			require.ErrorAs(t, err, &resourceErr)
+			assert.Equal(t, "Namespace", resourceErr.Kind)
+			assert.Equal(t, "", resourceErr.Namespace)
+			assert.Equal(t, tt.objName, resourceErr.Name)
+		})
+	}
+}
+
+func TestNamespaceAPI_Retry(t *testing.T) {
+	policy := api.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		Multiplier:           2,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+
+	tests := []struct {
+		name          string
+		verb          string
+		failures      int
+		err           error
+		expectedCalls int
+		wantErr       bool
+	}{
+		{
+			name:          "Get succeeds after throttling",
+			verb:          "get",
+			failures:      2,
+			err:           apierrors.NewTooManyRequests("slow down", 0),
+			expectedCalls: 3,
+		},
+		{
+			name:          "List succeeds after unavailability",
+			verb:          "list",
+			failures:      1,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 2,
+		},
+		{
+			name:          "Gives up after max attempts",
+			verb:          "list",
+			failures:      3,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 3,
+			wantErr:       true,
+		},
+		{
+			name:          "Non-retryable error",
+			verb:          "get",
+			failures:      1,
+			err:           apierrors.NewForbidden(schema.GroupResource{Group: "", Resource: "namespaces"}, "payments", errors.New("rbac denied")),
+			expectedCalls: 1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}})
+			calls := 0
+			fakeClient.PrependReactor(tt.verb, "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= tt.failures {
+					return true, nil, tt.err
+				}
+				return false, nil, nil
+			})
+			namespaceAPI := NewNamespaceAPI(fakeClient, WithRetryPolicy(policy))
+
+			var err error
+			if tt.verb == "get" {
+				_, err = namespaceAPI.GetNamespaceByName(context.Background(), "payments")
+			} else {
+				_, err = namespaceAPI.ListNamespacesByLabel(context.Background(), "app=web")
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNamespaceAPI_Logger(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	)
+	var buf bytes.Buffer
+	namespaceAPI := NewNamespaceAPI(fakeClient, WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	_, err := namespaceAPI.ListNamespacesByField(context.Background(), "status.phase=Active")
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=list resource=namespaces fieldSelector="status.phase=Active" duration=`)
+	assert.Contains(t, buf.String(), "items=2")
+}
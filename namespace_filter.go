@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kaudit/val"
+)
+
+// NamespaceFilter selects namespaces by name. Every List*AllNamespaces method takes
+// one: it lists all namespaces together rather than one at a time, then applies the
+// filter client-side and keeps the items of the namespaces it matches, so the filter
+// does not reduce what the apiserver sends. A nil *NamespaceFilter keeps every item.
+//
+// A namespace matches when it matches at least one include pattern, or there are none,
+// and no exclude pattern. A pattern is a glob as understood by path.Match, such as
+// "kube-*", or a regular expression when enclosed in slashes, such as "/^team-(a|b)$/".
+//
+// A NamespaceFilter is immutable and safe for concurrent use.
+type NamespaceFilter struct {
+	include []namespacePattern
+	exclude []namespacePattern
+}
+
+// namespacePattern is a compiled include or exclude pattern of a NamespaceFilter.
+type namespacePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// NewNamespaceFilter returns a NamespaceFilter matching the namespaces that match one
+// of the include patterns, or any namespace when there are none, and none of the
+// exclude patterns.
+//
+// Returns a *ValidationError if a pattern is empty, a malformed glob or an invalid
+// regular expression.
+func NewNamespaceFilter(include, exclude []string) (*NamespaceFilter, error) {
+	f := &NamespaceFilter{}
+	var err error
+	if f.include, err = compileNamespacePatterns("include", include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = compileNamespacePatterns("exclude", exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ExcludeNamespaces returns a NamespaceFilter matching the namespaces that match none of
+// patterns, e.g. ExcludeNamespaces("kube-*"). See NewNamespaceFilter.
+func ExcludeNamespaces(patterns ...string) (*NamespaceFilter, error) {
+	return NewNamespaceFilter(nil, patterns)
+}
+
+// Match reports whether namespace is selected by f.
+func (f *NamespaceFilter) Match(namespace string) bool {
+	if f == nil {
+		return true
+	}
+	if len(f.include) > 0 && !matchAny(f.include, namespace) {
+		return false
+	}
+	return !matchAny(f.exclude, namespace)
+}
+
+// compileNamespacePatterns compiles the patterns of field, "include" or "exclude".
+func compileNamespacePatterns(field string, patterns []string) ([]namespacePattern, error) {
+	compiled := make([]namespacePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if err := val.ValidateWithTag(pattern, "required"); err != nil {
+			return nil, NewValidationError("Namespace", "", "", field, "invalid namespace pattern", err)
+		}
+
+		if expr, ok := strings.CutPrefix(pattern, "/"); ok && len(expr) > 0 && strings.HasSuffix(expr, "/") {
+			re, err := regexp.Compile(strings.TrimSuffix(expr, "/"))
+			if err != nil {
+				return nil, NewValidationError("Namespace", "", "", field, fmt.Sprintf("invalid namespace pattern %q", pattern), err)
+			}
+			compiled = append(compiled, namespacePattern{re: re})
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, NewValidationError("Namespace", "", "", field, fmt.Sprintf("invalid namespace pattern %q", pattern), err)
+		}
+		compiled = append(compiled, namespacePattern{glob: pattern})
+	}
+	return compiled, nil
+}
+
+// matchAny reports whether namespace matches one of patterns.
+func matchAny(patterns []namespacePattern, namespace string) bool {
+	for _, p := range patterns {
+		if p.re != nil {
+			if p.re.MatchString(namespace) {
+				return true
+			}
+			continue
+		}
+		// The glob was validated when compiling, so Match cannot fail.
+		if ok, _ := path.Match(p.glob, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceFilter_Match(t *testing.T) {
+	tests := []struct {
+		name      string
+		include   []string
+		exclude   []string
+		matched   []string
+		unmatched []string
+	}{
+		{
+			name:    "No patterns",
+			matched: []string{"default", "kube-system"},
+		},
+		{
+			name:      "Exclude glob",
+			exclude:   []string{"kube-*"},
+			matched:   []string{"default", "team-kube"},
+			unmatched: []string{"kube-system", "kube-public"},
+		},
+		{
+			name:      "Include glob",
+			include:   []string{"team-?", "payments"},
+			matched:   []string{"team-a", "payments"},
+			unmatched: []string{"team-ab", "default"},
+		},
+		{
+			name:      "Include regex",
+			include:   []string{"/^team-(a|b)$/"},
+			matched:   []string{"team-a", "team-b"},
+			unmatched: []string{"team-c", "my-team-a"},
+		},
+		{
+			name:      "Exclude wins over include",
+			include:   []string{"team-*"},
+			exclude:   []string{"/-staging$/"},
+			matched:   []string{"team-a"},
+			unmatched: []string{"team-a-staging", "default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewNamespaceFilter(tt.include, tt.exclude)
+			require.NoError(t, err)
+
+			for _, namespace := range tt.matched {
+				assert.True(t, f.Match(namespace), namespace)
+			}
+			for _, namespace := range tt.unmatched {
+				assert.False(t, f.Match(namespace), namespace)
+			}
+		})
+	}
+}
+
+func TestNamespaceFilter_MatchNil(t *testing.T) {
+	var f *NamespaceFilter
+
+	assert.True(t, f.Match("kube-system"))
+}
+
+func TestNewNamespaceFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		field   string
+		errMsg  string
+	}{
+		{
+			name:    "Empty pattern",
+			include: []string{""},
+			field:   "include",
+			errMsg:  "invalid namespace pattern",
+		},
+		{
+			name:    "Malformed glob",
+			exclude: []string{"kube-["},
+			field:   "exclude",
+			errMsg:  `invalid namespace pattern "kube-["`,
+		},
+		{
+			name:    "Invalid regex",
+			exclude: []string{"/kube-(/"},
+			field:   "exclude",
+			errMsg:  `invalid namespace pattern "/kube-(/"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewNamespaceFilter(tt.include, tt.exclude)
+
+			require.ErrorIs(t, err, ErrValidation)
+			assert.Nil(t, f)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package networkingapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetIngressByName retrieves a specific Ingress by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the ingress (must be non-empty).
+//   - name: Name of the ingress (must be non-empty).
+//
+// Returns the matched *networkingv1.Ingress or an error if not found or invalid.
+func (n *NetworkingAPI) GetIngressByName(ctx context.Context, namespace, name string) (*networkingv1.Ingress, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, name, "name", "invalid ingress name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "ingresses", Namespace: namespace, Name: name}
+	start := time.Now()
+	ing, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.Ingress, error) {
+		return n.client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", namespace, name, fmt.Sprintf("failed to get ingress %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
+
+	return ing, nil
+}
+
+// ListIngressesByLabel lists ingresses by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching ingresses or an error.
+func (n *NetworkingAPI) ListIngressesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.Ingress, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingresses", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressList, error) {
+		return n.client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", namespace, "", fmt.Sprintf("failed to list ingresses by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListIngressesByField lists ingresses by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching ingresses or an error.
+func (n *NetworkingAPI) ListIngressesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.Ingress, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingresses", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressList, error) {
+		return n.client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", namespace, "", fmt.Sprintf("failed to list ingresses by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListIngressesByLabelAllNamespaces lists ingresses by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching ingresses or an error.
+func (n *NetworkingAPI) ListIngressesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.Ingress, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Ingress", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingresses", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressList, error) {
+		return n.client.NetworkingV1().Ingresses(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", "", "", "failed to list ingresses by label in all namespaces", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListIngressesByFieldAllNamespaces lists ingresses by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching ingresses or an error.
+func (n *NetworkingAPI) ListIngressesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.Ingress, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Ingress", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingresses", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressList, error) {
+		return n.client.NetworkingV1().Ingresses(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", "", "", "failed to list ingresses by field in all namespaces", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListIngressesByQuery lists ingresses by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching ingresses, at most query.Limit when set, or an error.
+func (n *NetworkingAPI) ListIngressesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]networkingv1.Ingress, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Ingress", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "ingresses", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressList, error) {
+		return n.client.NetworkingV1().Ingresses(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Ingress", namespace, "", fmt.Sprintf("failed to list ingresses by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,151 @@
+package networkingapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetIngressClassByName retrieves a single IngressClass object by its name.
+//
+// The name parameter is validated to ensure it is not empty.
+// If the validation fails or if the retrieval from the Kubernetes API fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the cluster-scoped ingressclass to retrieve.
+//
+// Returns a pointer to a networkingv1.IngressClass object or an error if the ingressclass
+// is not found or if any other retrieval error occurs.
+func (n *NetworkingAPI) GetIngressClassByName(ctx context.Context, name string) (*networkingv1.IngressClass, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("IngressClass", "", name, "name", "failed to validate ingressclass name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "ingressclasses", Name: name}
+	start := time.Now()
+	ic, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressClass, error) {
+		return n.client.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("IngressClass", "", name, fmt.Sprintf("failed to get ingressclass %q", name), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
+	return ic, nil
+}
+
+// ListIngressClassesByLabel retrieves a list of IngressClass objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of networkingv1.IngressClass objects matching the label selector, or
+// an error if the operation fails.
+func (n *NetworkingAPI) ListIngressClassesByLabel(ctx context.Context, labelSelector string) ([]networkingv1.IngressClass, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("IngressClass", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingressclasses", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressClassList, error) {
+		return n.client.NetworkingV1().IngressClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("IngressClass", "", "", fmt.Sprintf("failed to list ingressclasses by label %q", labelSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListIngressClassesByField retrieves a list of IngressClass objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of networkingv1.IngressClass objects matching the field selector, or
+// an error if the operation fails.
+func (n *NetworkingAPI) ListIngressClassesByField(ctx context.Context, fieldSelector string) ([]networkingv1.IngressClass, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("IngressClass", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "ingressclasses", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressClassList, error) {
+		return n.client.NetworkingV1().IngressClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("IngressClass", "", "", fmt.Sprintf("failed to list ingressclasses by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListIngressClassesByQuery retrieves a list of IngressClass objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of networkingv1.IngressClass objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (n *NetworkingAPI) ListIngressClassesByQuery(ctx context.Context, query api.ListQuery) ([]networkingv1.IngressClass, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("IngressClass", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "ingressclasses", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.IngressClassList, error) {
+		return n.client.NetworkingV1().IngressClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("IngressClass", "", "", "failed to list ingressclasses by query", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
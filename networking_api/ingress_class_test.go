@@ -0,0 +1,354 @@
+package networkingapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestNetworkingAPI_GetIngressClassByName(t *testing.T) {
+	// Setup a cluster-scoped ingressclass
+	testIngressClass := &networkingv1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-ingressclass",
+		},
+	}
+
+	// Create fake clientset with test ingressclass
+	fakeClient := fake.NewClientset(testIngressClass)
+
+	// Initialize networking API
+	icAPI := NewNetworkingAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		icName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:    "Successfully get ingressclass",
+			icName:  "test-ingressclass",
+			wantErr: false,
+		},
+		{
+			name:          "Empty ingressclass name",
+			icName:        "",
+			wantErr:       true,
+			errorContains: "failed to validate ingressclass name",
+		},
+		{
+			name:          "IngressClass not found",
+			icName:        "nonexistent-ingressclass",
+			wantErr:       true,
+			errorContains: "failed to get ingressclass",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ic, err := icAPI.GetIngressClassByName(ctx, tt.icName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, ic)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, ic)
+				assert.Equal(t, tt.icName, ic.Name)
+			}
+		})
+	}
+}
+
+func TestNetworkingAPI_ListIngressClassesByLabel(t *testing.T) {
+	// Setup test ingressclasses
+	testIngressClasses := []*networkingv1.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ingressclass-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ingressclass-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-ingressclass",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testIngressClasses {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize networking API
+	icAPI := NewNetworkingAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List ingressclasses by app label",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-ingressclass-1", "test-ingressclass-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List ingressclasses with multiple labels",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-ingressclass-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ingressClasses, err := icAPI.ListIngressClassesByLabel(ctx, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, ingressClasses)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, ingressClasses, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(ingressClasses))
+				for _, item := range ingressClasses {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestNetworkingAPI_ListIngressClassesByField(t *testing.T) {
+	// Setup test ingressclasses
+	testIngressClasses := []*networkingv1.IngressClass{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ingressclass-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ingressclass-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-ingressclass",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testIngressClasses {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize networking API
+	icAPI := NewNetworkingAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every ingressclass
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List ingressclasses by field",
+			fieldSelector: "metadata.name=test-ingressclass-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ingressClasses, err := icAPI.ListIngressClassesByField(ctx, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, ingressClasses)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, ingressClasses, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestNetworkingAPI_ListIngressClassesByQuery(t *testing.T) {
+	// Setup ingressclasses with different labels
+	fakeClient := fake.NewClientset(
+		&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	icAPI := NewNetworkingAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := icAPI.ListIngressClassesByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package networkingapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetNetworkPolicyByName retrieves a specific NetworkPolicy by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the networkpolicy (must be non-empty).
+//   - name: Name of the networkpolicy (must be non-empty).
+//
+// Returns the matched *networkingv1.NetworkPolicy or an error if not found or invalid.
+func (n *NetworkingAPI) GetNetworkPolicyByName(ctx context.Context, namespace, name string) (*networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, name, "name", "invalid networkpolicy name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "networkpolicies", Namespace: namespace, Name: name}
+	start := time.Now()
+	np, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicy, error) {
+		return n.client.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", namespace, name, fmt.Sprintf("failed to get networkpolicy %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
+
+	return np, nil
+}
+
+// ListNetworkPoliciesByLabel lists networkpolicies by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching networkpolicies or an error.
+func (n *NetworkingAPI) ListNetworkPoliciesByLabel(ctx context.Context, namespace string, labelSelector string) ([]networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "networkpolicies", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicyList, error) {
+		return n.client.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", namespace, "", fmt.Sprintf("failed to list networkpolicies by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNetworkPoliciesByField lists networkpolicies by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching networkpolicies or an error.
+func (n *NetworkingAPI) ListNetworkPoliciesByField(ctx context.Context, namespace string, fieldSelector string) ([]networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "networkpolicies", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicyList, error) {
+		return n.client.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", namespace, "", fmt.Sprintf("failed to list networkpolicies by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNetworkPoliciesByLabelAllNamespaces lists networkpolicies by label selector in
+// all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching networkpolicies or an error.
+func (n *NetworkingAPI) ListNetworkPoliciesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "networkpolicies", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicyList, error) {
+		return n.client.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", "", "", "failed to list networkpolicies by label in all namespaces", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListNetworkPoliciesByFieldAllNamespaces lists networkpolicies by field selector in
+// all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching networkpolicies or an error.
+func (n *NetworkingAPI) ListNetworkPoliciesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "networkpolicies", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicyList, error) {
+		return n.client.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", "", "", "failed to list networkpolicies by field in all namespaces", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListNetworkPoliciesByQuery lists networkpolicies by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching networkpolicies, at most query.Limit when set, or an error.
+func (n *NetworkingAPI) ListNetworkPoliciesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]networkingv1.NetworkPolicy, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("NetworkPolicy", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "networkpolicies", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*networkingv1.NetworkPolicyList, error) {
+		return n.client.NetworkingV1().NetworkPolicies(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("NetworkPolicy", namespace, "", fmt.Sprintf("failed to list networkpolicies by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,50 @@
+package networkingapi
+
+import (
+	"log/slog"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+)
+
+// NetworkingAPI provides high-level methods for retrieving networking.k8s.io/v1
+// Ingresses, IngressClasses and NetworkPolicies.
+type NetworkingAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a NetworkingAPI.
+type Option func(*NetworkingAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(n *NetworkingAPI) {
+		n.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *NetworkingAPI) {
+		n.logger = logger
+	}
+}
+
+// NewNetworkingAPI creates a new NetworkingAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewNetworkingAPI(client kubernetes.Interface, opts ...Option) *NetworkingAPI {
+	n := &NetworkingAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
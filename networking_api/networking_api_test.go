@@ -0,0 +1,16 @@
+package networkingapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewNetworkingAPI(t *testing.T) {
+	client := fake.NewClientset()
+	networkingAPI := NewNetworkingAPI(client)
+
+	assert.NotNil(t, networkingAPI)
+	assert.Equal(t, client, networkingAPI.client)
+}
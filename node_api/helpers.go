@@ -0,0 +1,67 @@
+package nodeapi
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// GetCondition returns the condition of the given type reported by node, or nil if
+// the node does not report it.
+func GetCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == conditionType {
+			return &node.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsReady reports whether the Ready condition of node is True.
+func IsReady(node *corev1.Node) bool {
+	condition := GetCondition(node, corev1.NodeReady)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// HasTaint reports whether node carries a taint with the given key. An empty effect
+// matches a taint with any effect.
+func HasTaint(node *corev1.Node, key string, effect corev1.TaintEffect) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key && (effect == "" || taint.Effect == effect) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allocatable returns the amount of a resource, such as cpu or memory, that node
+// makes available to pods, and whether the node reports that resource at all.
+func Allocatable(node *corev1.Node, name corev1.ResourceName) (resource.Quantity, bool) {
+	quantity, ok := node.Status.Allocatable[name]
+	return quantity, ok
+}
+
+// KubeletVersion parses the kubelet version reported by node, e.g. v1.31.2.
+func KubeletVersion(node *corev1.Node) (*version.Version, error) {
+	v, err := version.ParseGeneric(node.Status.NodeInfo.KubeletVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet version of node %q: %w", node.Name, err)
+	}
+	return v, nil
+}
+
+// MinorVersionSkew returns how many minor versions the kubelet of node lags behind
+// server, typically the API server version. A negative skew means the kubelet is
+// newer than server, which Kubernetes does not support.
+func MinorVersionSkew(node *corev1.Node, server *version.Version) (int, error) {
+	kubelet, err := KubeletVersion(node)
+	if err != nil {
+		return 0, err
+	}
+	if kubelet.Major() != server.Major() {
+		return 0, fmt.Errorf("kubelet version %s of node %q has a different major version than %s", kubelet, node.Name, server)
+	}
+	return int(server.Minor()) - int(kubelet.Minor()), nil
+}
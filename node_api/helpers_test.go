@@ -0,0 +1,118 @@
+package nodeapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestGetCondition(t *testing.T) {
+	node := &corev1.Node{
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+			},
+		},
+	}
+
+	condition := GetCondition(node, corev1.NodeMemoryPressure)
+	require.NotNil(t, condition)
+	assert.Equal(t, "KubeletHasInsufficientMemory", condition.Reason)
+
+	assert.Nil(t, GetCondition(node, corev1.NodeDiskPressure))
+	assert.True(t, IsReady(node))
+	assert.False(t, IsReady(&corev1.Node{}))
+}
+
+func TestHasTaint(t *testing.T) {
+	node := &corev1.Node{
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{
+				{Key: "node.kubernetes.io/unreachable", Effect: corev1.TaintEffectNoExecute},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		effect   corev1.TaintEffect
+		expected bool
+	}{
+		{name: "Key with any effect", key: "node.kubernetes.io/unreachable", expected: true},
+		{name: "Key with matching effect", key: "node.kubernetes.io/unreachable", effect: corev1.TaintEffectNoExecute, expected: true},
+		{name: "Key with other effect", key: "node.kubernetes.io/unreachable", effect: corev1.TaintEffectNoSchedule, expected: false},
+		{name: "Other key", key: "dedicated", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HasTaint(node, tt.key, tt.effect))
+		})
+	}
+}
+
+func TestAllocatable(t *testing.T) {
+	node := &corev1.Node{
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3500m"),
+				corev1.ResourceMemory: resource.MustParse("14Gi"),
+			},
+		},
+	}
+
+	cpu, ok := Allocatable(node, corev1.ResourceCPU)
+	require.True(t, ok)
+	assert.Equal(t, int64(3500), cpu.MilliValue())
+
+	_, ok = Allocatable(node, "nvidia.com/gpu")
+	assert.False(t, ok)
+}
+
+func TestMinorVersionSkew(t *testing.T) {
+	server := version.MustParseGeneric("v1.31.2")
+	node := func(kubeletVersion string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{KubeletVersion: kubeletVersion},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		kubelet       string
+		expected      int
+		wantErr       bool
+		errorContains string
+	}{
+		{name: "Same minor", kubelet: "v1.31.0", expected: 0},
+		{name: "Older kubelet", kubelet: "v1.29.4-eks-1", expected: 2},
+		{name: "Newer kubelet", kubelet: "v1.32.0", expected: -1},
+		{name: "Different major", kubelet: "v2.0.0", wantErr: true, errorContains: "different major version"},
+		{name: "Unparsable version", kubelet: "", wantErr: true, errorContains: "failed to parse kubelet version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew, err := MinorVersionSkew(node(tt.kubelet), server)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, skew)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package nodeapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// NodeAPI provides high-level methods for retrieving Kubernetes nodes.
+type NodeAPI struct {
+	client  kubernetes.Interface
+	pods    api.PodAPI
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a NodeAPI.
+type Option func(*NodeAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(n *NodeAPI) {
+		n.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(n *NodeAPI) {
+		n.logger = logger
+	}
+}
+
+// NewNodeAPI creates a new NodeAPI instance with the provided Kubernetes client.
+//
+// The client parameter should be a valid implementation of kubernetes.Interface.
+// The pods API is used to resolve the pods scheduled on a node, see ListPodsOnNode.
+//
+// Returns an initialized *NodeAPI.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewNodeAPI(client kubernetes.Interface, pods api.PodAPI, opts ...Option) *NodeAPI {
+	n := &NodeAPI{
+		client: client,
+		pods:   pods,
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// GetNodeByName retrieves a single Node object by its name.
+//
+// The name parameter is validated to ensure it is not empty.
+// If the validation fails or if the retrieval from the Kubernetes API fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the cluster-scoped node to retrieve.
+//
+// Returns a pointer to a corev1.Node object or an error if the node
+// is not found or if any other retrieval error occurs.
+func (n *NodeAPI) GetNodeByName(ctx context.Context, name string) (*corev1.Node, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", name, "name", "failed to validate node name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "nodes", Name: name}
+	start := time.Now()
+	node, err := throttle.Do(ctx, n.limiter, func() (*corev1.Node, error) {
+		return n.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", name, fmt.Sprintf("failed to get node %q", name), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, 1)
+	return node, nil
+}
+
+// ListNodesByLabel retrieves a list of Node objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of corev1.Node objects matching the label selector, or
+// an error if the operation fails.
+func (n *NodeAPI) ListNodesByLabel(ctx context.Context, labelSelector string) ([]corev1.Node, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "nodes", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*corev1.NodeList, error) {
+		return n.client.CoreV1().Nodes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", "", fmt.Sprintf("failed to list nodes by label %q", labelSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNodesByField retrieves a list of Node objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of corev1.Node objects matching the field selector, or
+// an error if the operation fails.
+func (n *NodeAPI) ListNodesByField(ctx context.Context, fieldSelector string) ([]corev1.Node, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "nodes", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*corev1.NodeList, error) {
+		return n.client.CoreV1().Nodes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", "", fmt.Sprintf("failed to list nodes by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNodesByQuery retrieves a list of Node objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of corev1.Node objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (n *NodeAPI) ListNodesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.Node, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "nodes", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*corev1.NodeList, error) {
+		return n.client.CoreV1().Nodes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", "", "failed to list nodes by query", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListNotReadyNodes retrieves the nodes whose Ready condition is not True, including
+// nodes that do not report a Ready condition at all.
+//
+//   - ctx: The context to use for cancellation.
+//
+// Returns a slice of corev1.Node objects that are not ready, or an error if the
+// operation fails.
+func (n *NodeAPI) ListNotReadyNodes(ctx context.Context) ([]corev1.Node, error) {
+	req := reqlog.Request{Verb: "list", Resource: "nodes"}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*corev1.NodeList, error) {
+		return n.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", "", "failed to list not ready nodes", err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	var nodes []corev1.Node
+	for _, node := range list.Items {
+		if !IsReady(&node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// ListNodesWithTaint retrieves the nodes carrying a taint with the given key.
+//
+// The key parameter is validated to ensure it is not empty.
+//
+//   - ctx: The context to use for cancellation.
+//   - key: The taint key, e.g. node.kubernetes.io/unreachable.
+//   - effect: The taint effect to match; an empty effect matches any effect.
+//
+// Returns a slice of corev1.Node objects carrying the taint, or an error if the
+// validation or the operation fails.
+func (n *NodeAPI) ListNodesWithTaint(ctx context.Context, key string, effect corev1.TaintEffect) ([]corev1.Node, error) {
+	err := val.ValidateWithTag(key, "required")
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", "", "key", "failed to validate taint key", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "nodes"}
+	start := time.Now()
+	list, err := throttle.Do(ctx, n.limiter, func() (*corev1.NodeList, error) {
+		return n.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Node", "", "", fmt.Sprintf("failed to list nodes with taint %q", key), err)
+		reqlog.Failed(ctx, n.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, n.logger, req, start, len(list.Items))
+
+	var nodes []corev1.Node
+	for _, node := range list.Items {
+		if HasTaint(&node, key, effect) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+// ListPodsOnNode retrieves the pods scheduled on a node, across all namespaces.
+//
+// Pods are listed with a single request through the PodAPI the NodeAPI was created
+// with, using the spec.nodeName field selector.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the node.
+//
+// Returns a slice of corev1.Pod objects scheduled on the node, or an error if the
+// validation or the operation fails.
+func (n *NodeAPI) ListPodsOnNode(ctx context.Context, name string) ([]corev1.Pod, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("Node", "", name, "name", "failed to validate node name", err)
+	}
+
+	pods, err := n.pods.ListPodsByFieldAllNamespaces(ctx, "spec.nodeName="+name, nil)
+	if err != nil {
+		return nil, api.NewResourceError("Node", "", name, fmt.Sprintf("failed to list pods on node %q", name), err)
+	}
+	return pods, nil
+}
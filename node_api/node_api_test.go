@@ -0,0 +1,580 @@
+package nodeapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/pod_api"
+)
+
+func TestNewNodeAPI(t *testing.T) {
+	client := fake.NewClientset()
+	nodeAPI := NewNodeAPI(client, podapi.NewPodAPI(client))
+
+	assert.NotNil(t, nodeAPI)
+	assert.Equal(t, client, nodeAPI.client)
+}
+
+func TestNodeAPI_GetNodeByName(t *testing.T) {
+	// Setup a cluster-scoped node
+	testNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: true,
+		},
+	}
+
+	// Create fake clientset with test node
+	fakeClient := fake.NewClientset(testNode)
+
+	// Initialize node API
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		nodeName      string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:     "Successfully get node",
+			nodeName: "test-node",
+			wantErr:  false,
+		},
+		{
+			name:          "Empty node name",
+			nodeName:      "",
+			wantErr:       true,
+			errorContains: "failed to validate node name",
+		},
+		{
+			name:          "Node not found",
+			nodeName:      "nonexistent-node",
+			wantErr:       true,
+			errorContains: "failed to get node",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			node, err := nodeAPI.GetNodeByName(ctx, tt.nodeName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, node)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, node)
+				assert.Equal(t, tt.nodeName, node.Name)
+			}
+		})
+	}
+}
+
+func TestNodeAPI_ListNodesByLabel(t *testing.T) {
+	// Setup test nodes
+	testNodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-node",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testNodes {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize node API
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List nodes by app label",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-node-1", "test-node-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List nodes with multiple labels",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-node-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			nodes, err := nodeAPI.ListNodesByLabel(ctx, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, nodes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, nodes, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(nodes))
+				for _, item := range nodes {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestNodeAPI_ListNodesByField(t *testing.T) {
+	// Setup test nodes
+	testNodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-node",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testNodes {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize node API
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors, so every node
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List nodes by field",
+			fieldSelector: "metadata.name=test-node-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			nodes, err := nodeAPI.ListNodesByField(ctx, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, nodes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, nodes, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestNodeAPI_ListNodesByQuery(t *testing.T) {
+	// Setup nodes with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := nodeAPI.ListNodesByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
+
+func TestNodeAPI_ListNotReadyNodes(t *testing.T) {
+	// Setup nodes with different Ready conditions
+	node := func(name string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: conditions},
+		}
+	}
+	fakeClient := fake.NewClientset(
+		node("ready", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}),
+		node("not-ready", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse}),
+		node("unknown", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown}),
+		node("no-conditions"),
+	)
+
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	nodes, err := nodeAPI.ListNotReadyNodes(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	assert.ElementsMatch(t, []string{"not-ready", "unknown", "no-conditions"}, names)
+}
+
+func TestNodeAPI_ListNodesWithTaint(t *testing.T) {
+	// Setup nodes with different taints
+	node := func(name string, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Taints: taints},
+		}
+	}
+	fakeClient := fake.NewClientset(
+		node("gpu", corev1.Taint{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}),
+		node("gpu-preferred", corev1.Taint{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectPreferNoSchedule}),
+		node("plain"),
+	)
+
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	tests := []struct {
+		name          string
+		key           string
+		effect        corev1.TaintEffect
+		expectedNodes []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "Any effect",
+			key:           "nvidia.com/gpu",
+			expectedNodes: []string{"gpu", "gpu-preferred"},
+		},
+		{
+			name:          "Specific effect",
+			key:           "nvidia.com/gpu",
+			effect:        corev1.TaintEffectNoSchedule,
+			expectedNodes: []string{"gpu"},
+		},
+		{
+			name:          "Unknown key",
+			key:           "dedicated",
+			expectedNodes: []string{},
+		},
+		{
+			name:          "Empty key",
+			key:           "",
+			wantErr:       true,
+			errorContains: "failed to validate taint key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := nodeAPI.ListNodesWithTaint(context.Background(), tt.key, tt.effect)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				names = append(names, n.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNodes, names)
+		})
+	}
+}
+
+func TestNodeAPI_ListPodsOnNode(t *testing.T) {
+	// Setup pods spread over two nodes and two namespaces
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"}, Spec: corev1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}, Spec: corev1.PodSpec{NodeName: "node-2"}},
+	}
+	fakeClient := fake.NewClientset()
+
+	// The fake clientset does not evaluate field selectors, so pods are
+	// served by a reactor that does
+	fakeClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		restrictions := action.(k8stesting.ListActionImpl).GetListRestrictions()
+		list := &corev1.PodList{}
+		for _, pod := range pods {
+			if (action.GetNamespace() == "" || pod.Namespace == action.GetNamespace()) && restrictions.Fields.Matches(fields.Set{"spec.nodeName": pod.Spec.NodeName}) {
+				list.Items = append(list.Items, pod)
+			}
+		}
+		return true, list, nil
+	})
+
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	tests := []struct {
+		name          string
+		nodeName      string
+		expectedPods  []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:         "Pods across namespaces",
+			nodeName:     "node-1",
+			expectedPods: []string{"web", "dns"},
+		},
+		{
+			name:         "Single pod",
+			nodeName:     "node-2",
+			expectedPods: []string{"db"},
+		},
+		{
+			name:         "Node without pods",
+			nodeName:     "node-3",
+			expectedPods: []string{},
+		},
+		{
+			name:          "Empty node name",
+			nodeName:      "",
+			wantErr:       true,
+			errorContains: "failed to validate node name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			result, err := nodeAPI.ListPodsOnNode(context.Background(), tt.nodeName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(result))
+			for _, pod := range result {
+				names = append(names, pod.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedPods, names)
+
+			// A single request lists the pods of every namespace
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, "", fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestNodeAPI_TypedErrors(t *testing.T) {
+	fakeClient := fake.NewClientset()
+	nodeAPI := NewNodeAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	_, err := nodeAPI.GetNodeByName(context.Background(), "worker-1")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	var resourceErr *api.ResourceError
+	require.ErrorAs(t, err, &resourceErr)
+	assert.Equal(t, "Node", resourceErr.Kind)
+	assert.Empty(t, resourceErr.Namespace)
+	assert.Equal(t, "worker-1", resourceErr.Name)
+
+	_, err = nodeAPI.GetNodeByName(context.Background(), "")
+	require.ErrorIs(t, err, api.ErrValidation)
+	var validationErr *api.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+}
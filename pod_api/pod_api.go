@@ -3,23 +3,71 @@ package podapi
 import (
 	"context"
 	"fmt"
+	"iter"
+	"log/slog"
+	"time"
 
 	"github.com/kaudit/val"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/retry"
+	"github.com/kaudit/api/internal/throttle"
+	"github.com/kaudit/api/internal/watcher"
 )
 
 // PodAPI provides high-level methods for retrieving Kubernetes pods.
 type PodAPI struct {
-	client kubernetes.Interface
+	client  kubernetes.Interface
+	retry   api.RetryPolicy
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a PodAPI.
+type Option func(*PodAPI)
+
+// WithRetryPolicy retries Get and List requests failing with a transient apiserver
+// error according to policy. By default requests are not retried.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(p *PodAPI) {
+		p.retry = policy
+	}
+}
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(p *PodAPI) {
+		p.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *PodAPI) {
+		p.logger = logger
+	}
 }
 
 // NewPodAPI creates a new PodAPI instance using the provided client.
-func NewPodAPI(client kubernetes.Interface) *PodAPI {
-	return &PodAPI{
+//
+// Options such as WithRetryPolicy, WithRateLimiter and WithLogger customize the instance.
+func NewPodAPI(client kubernetes.Interface, opts ...Option) *PodAPI {
+	p := &PodAPI{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // GetPodByName retrieves a specific Pod by namespace and name.
@@ -32,16 +80,25 @@ func NewPodAPI(client kubernetes.Interface) *PodAPI {
 // Returns the matched *corev1.Pod or an error if not found or invalid.
 func (p *PodAPI) GetPodByName(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, name, "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(name, "required"); err != nil {
-		return nil, fmt.Errorf("invalid pod name: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, name, "name", "invalid pod name", err)
 	}
 
-	pod, err := p.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	req := reqlog.Request{Verb: "get", Resource: "pods", Namespace: namespace, Name: name}
+	start := time.Now()
+	pod, err := retry.Do(ctx, p.retry, func() (*corev1.Pod, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.Pod, error) {
+			return p.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pod %q in namespace %q: %w", name, namespace, err)
+		err = api.NewResourceError("Pod", namespace, name, fmt.Sprintf("failed to get pod %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, p.logger, req, start, 1)
 
 	return pod, nil
 }
@@ -56,20 +113,29 @@ func (p *PodAPI) GetPodByName(ctx context.Context, namespace, name string) (*cor
 // Returns all matching pods or an error.
 func (p *PodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Pod, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, "", "labelSelector", "invalid label selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
 
-	list, err := p.client.CoreV1().Pods(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+			return p.client.CoreV1().Pods(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods by label in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
@@ -84,20 +150,276 @@ func (p *PodAPI) ListPodsByLabel(ctx context.Context, namespace string, labelSel
 // Returns all matching pods or an error.
 func (p *PodAPI) ListPodsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Pod, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
-		return nil, fmt.Errorf("invalid field selector: %w", err)
+		return nil, api.NewValidationError("Pod", namespace, "", "fieldSelector", "invalid field selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	}
 
-	list, err := p.client.CoreV1().Pods(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+			return p.client.CoreV1().Pods(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods by field in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
+
+// ListPodsByLabelAllNamespaces lists pods by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching pods or an error.
+func (p *PodAPI) ListPodsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+			return p.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Pod", "", "", "failed to list pods by label in all namespaces", err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListPodsByFieldAllNamespaces lists pods by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching pods or an error.
+func (p *PodAPI) ListPodsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+			return p.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Pod", "", "", "failed to list pods by field in all namespaces", err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListPodsByQuery lists pods by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching pods, at most query.Limit when set, or an error.
+func (p *PodAPI) ListPodsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Pod, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+		return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+			return p.client.CoreV1().Pods(namespace).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPodsByLabelPaged lists pods by namespace and label selector, fetching them in pages.
+// An empty namespace lists the pods of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - pageSize: Maximum number of pods requested per page (must be positive).
+//
+// Returns an iterator over all matching pods. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (p *PodAPI) ListPodsByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "labelSelector", "invalid label selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, p.listPage(namespace, "label"))
+}
+
+// ListPodsByFieldPaged lists pods by namespace and field selector, fetching them in pages.
+// An empty namespace lists the pods of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - pageSize: Maximum number of pods requested per page (must be positive).
+//
+// Returns an iterator over all matching pods. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (p *PodAPI) ListPodsByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Pod, error] {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "fieldSelector", "invalid field selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Pod](api.NewValidationError("Pod", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, p.listPage(namespace, "field"))
+}
+
+// WatchPodsByLabel watches pods by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns a channel of typed pod events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (p *PodAPI) WatchPodsByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "pods", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Pod](ctx, opts, throttle.Watch(p.limiter, p.client.CoreV1().Pods(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to watch pods by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, 0)
+
+	return events, nil
+}
+
+// WatchPodsByField watches pods by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns a channel of typed pod events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (p *PodAPI) WatchPodsByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Pod], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Pod", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "pods", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Pod](ctx, opts, throttle.Watch(p.limiter, p.client.CoreV1().Pods(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to watch pods by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, p.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, p.logger, req, start, 0)
+
+	return events, nil
+}
+
+// listPage returns a pager.PageFunc listing pods in the given namespace.
+func (p *PodAPI) listPage(namespace, selectorKind string) pager.PageFunc[corev1.Pod] {
+	return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Pod, string, error) {
+		req := reqlog.Request{Verb: "list", Resource: "pods", Namespace: namespace, LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+		start := time.Now()
+		list, err := retry.Do(ctx, p.retry, func() (*corev1.PodList, error) {
+			return throttle.Do(ctx, p.limiter, func() (*corev1.PodList, error) {
+				return p.client.CoreV1().Pods(namespace).List(ctx, opts)
+			})
+		})
+		if err != nil {
+			err = api.NewResourceError("Pod", namespace, "", fmt.Sprintf("failed to list pods by %s in namespace %q", selectorKind, namespace), err)
+			reqlog.Failed(ctx, p.logger, req, start, err)
+			return nil, "", err
+		}
+		reqlog.Succeeded(ctx, p.logger, req, start, len(list.Items))
+
+		return list.Items, list.Continue, nil
+	}
+}
@@ -1,15 +1,30 @@
 package podapi
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
 )
 
 func TestNewPodAPI(t *testing.T) {
@@ -339,3 +354,912 @@ func TestPodAPI_ListPodsByField(t *testing.T) {
 		})
 	}
 }
+
+func TestPodAPI_ListPodsAllNamespaces(t *testing.T) {
+	// Setup pods with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	podAPI := NewPodAPI(fakeClient)
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every pod of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]corev1.Pod, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]corev1.Pod, error) {
+				return podAPI.ListPodsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByQuery(t *testing.T) {
+	// Setup pods with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	podAPI := NewPodAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := podAPI.ListPodsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
+
+// pagingReactor serves pod listings from the fake object tracker in pages of
+// opts.Limit items, encoding the offset of the next page in the continue token.
+// Every served request is recorded in calls.
+func pagingReactor(client *fake.Clientset, calls *[]metav1.ListOptions) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listAction := action.(k8stesting.ListActionImpl)
+		opts := listAction.ListOptions
+		*calls = append(*calls, opts)
+
+		obj, err := client.Tracker().List(
+			corev1.SchemeGroupVersion.WithResource("pods"),
+			corev1.SchemeGroupVersion.WithKind("Pod"),
+			listAction.GetNamespace(),
+		)
+		if err != nil {
+			return true, nil, err
+		}
+
+		var items []corev1.Pod
+		for _, pod := range obj.(*corev1.PodList).Items {
+			if listAction.GetListRestrictions().Labels.Matches(labels.Set(pod.Labels)) {
+				items = append(items, pod)
+			}
+		}
+
+		offset, _ := strconv.Atoi(opts.Continue)
+		end := min(offset+int(opts.Limit), len(items))
+		page := &corev1.PodList{Items: items[offset:end]}
+		if end < len(items) {
+			page.Continue = strconv.Itoa(end)
+		}
+		return true, page, nil
+	}
+}
+
+func TestPodAPI_ListPodsByLabelPaged(t *testing.T) {
+	// Create test pods
+	var objects []runtime.Object
+	for i := range 5 {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%d", i),
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		})
+	}
+	objects = append(objects, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-pod",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"app": "other-app",
+			},
+		},
+	})
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		pageSize      int64
+		expired       bool
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Single page",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      10,
+			wantCount:     5,
+			wantPages:     1,
+		},
+		{
+			name:          "Expired continue token",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			expired:       true,
+			wantCount:     2,
+			wantPages:     2,
+			wantErr:       true,
+			errMsg:        "continue token expired",
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			pageSize:      2,
+			wantCount:     5,
+			wantPages:     3,
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Zero page size",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			pageSize:      0,
+			wantErr:       true,
+			errMsg:        "invalid page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "pods", pagingReactor(client, &calls))
+			if tt.expired {
+				client.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					if action.(k8stesting.ListActionImpl).ListOptions.Continue == "" {
+						return false, nil, nil
+					}
+					calls = append(calls, action.(k8stesting.ListActionImpl).ListOptions)
+					return true, nil, apierrors.NewResourceExpired("continue token too old")
+				})
+			}
+			podAPI := NewPodAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var pods []corev1.Pod
+			var gotErr error
+			for pod, err := range podAPI.ListPodsByLabelPaged(ctx, tt.namespace, tt.labelSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				pods = append(pods, pod)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			if tt.expired {
+				require.ErrorIs(t, gotErr, api.ErrContinueExpired)
+			}
+			assert.Len(t, pods, tt.wantCount)
+			assert.Len(t, calls, tt.wantPages)
+			for _, call := range calls {
+				assert.Equal(t, tt.pageSize, call.Limit)
+			}
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByFieldPaged(t *testing.T) {
+	// Create test pods
+	var objects []runtime.Object
+	for i := range 3 {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%d", i),
+				Namespace: "test-namespace",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+		})
+	}
+
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		pageSize      int64
+		wantCount     int
+		wantPages     int
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Multiple pages",
+			namespace:     "test-namespace",
+			fieldSelector: "spec.nodeName=node-1",
+			pageSize:      2,
+			wantCount:     3,
+			wantPages:     2,
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			fieldSelector: "spec.nodeName=node-1",
+			pageSize:      2,
+			wantCount:     3,
+			wantPages:     2,
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			pageSize:      2,
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Negative page size",
+			namespace:     "test-namespace",
+			fieldSelector: "spec.nodeName=node-1",
+			pageSize:      -1,
+			wantErr:       true,
+			errMsg:        "invalid page size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client with test objects and a paginating reactor
+			client := fake.NewClientset(objects...)
+			var calls []metav1.ListOptions
+			client.PrependReactor("list", "pods", pagingReactor(client, &calls))
+			podAPI := NewPodAPI(client)
+
+			// Execute the method
+			ctx := context.Background()
+			var pods []corev1.Pod
+			var gotErr error
+			for pod, err := range podAPI.ListPodsByFieldPaged(ctx, tt.namespace, tt.fieldSelector, tt.pageSize) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				pods = append(pods, pod)
+			}
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), tt.errMsg)
+				assert.Empty(t, pods)
+				assert.Empty(t, calls)
+			} else {
+				require.NoError(t, gotErr)
+				assert.Len(t, pods, tt.wantCount)
+				assert.Len(t, calls, tt.wantPages)
+				for _, call := range calls {
+					assert.Equal(t, tt.fieldSelector, call.FieldSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestPodAPI_WatchPodsByLabel(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errMsg:        "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errMsg:        "invalid label selector",
+		},
+		{
+			name:          "Watch refused",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			watchErr:      apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch pods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			podAPI := NewPodAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := podAPI.WatchPodsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Namespace:       "test-namespace",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestPodAPI_WatchPodsByField(t *testing.T) {
+	// Setup tests
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		watchErr      error
+		wantErr       bool
+		errMsg        string
+	}{
+		{
+			name:          "Valid input parameters",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test",
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test",
+			wantErr:       true,
+			errMsg:        "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errMsg:        "invalid field selector",
+		},
+		{
+			name:          "Watch refused",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test",
+			watchErr:      apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("denied")),
+			wantErr:       true,
+			errMsg:        "failed to watch pods",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create fake client backed by a fake watcher
+			client := fake.NewClientset()
+			fakeWatcher := watch.NewFakeWithChanSize(1, false)
+			client.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(fakeWatcher, tt.watchErr))
+			podAPI := NewPodAPI(client)
+
+			// Execute the method
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, err := podAPI.WatchPodsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			// Verify results
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+
+			fakeWatcher.Add(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Namespace:       "test-namespace",
+					ResourceVersion: "1",
+				},
+			})
+
+			select {
+			case event := <-events:
+				assert.Equal(t, api.EventAdded, event.Type)
+				assert.Equal(t, "test", event.Object.Name)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for event")
+			}
+
+			cancel()
+			for range events {
+			}
+			assert.True(t, fakeWatcher.IsStopped())
+		})
+	}
+}
+
+func TestPodAPI_ErrorCategories(t *testing.T) {
+	resource := schema.GroupResource{Group: "", Resource: "pods"}
+
+	tests := []struct {
+		name     string
+		reactor  k8stesting.ReactionFunc
+		objName  string
+		category error
+		field    string
+	}{
+		{
+			name:     "Not found",
+			objName:  "missing",
+			category: api.ErrNotFound,
+		},
+		{
+			name: "Forbidden",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(resource, "web", errors.New("rbac denied"))
+			},
+			objName:  "web",
+			category: api.ErrForbidden,
+		},
+		{
+			name: "Timeout",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewTimeoutError("request timed out", 1)
+			},
+			objName:  "web",
+			category: api.ErrTimeout,
+		},
+		{
+			name: "Conflict",
+			reactor: func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(resource, "web", errors.New("object was modified"))
+			},
+			objName:  "web",
+			category: api.ErrConflict,
+		},
+		{
+			name:     "Validation",
+			objName:  "",
+			category: api.ErrValidation,
+			field:    "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset()
+			if tt.reactor != nil {
+				fakeClient.PrependReactor("get", "pods", tt.reactor)
+			}
+			podAPI := NewPodAPI(fakeClient)
+
+			_, err := podAPI.GetPodByName(context.Background(), "default", tt.objName)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.category)
+
+			if tt.field != "" {
+				var validationErr *api.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "Pod", validationErr.Kind)
+				assert.Equal(t, tt.field, validationErr.Field)
+				return
+			}
+
+			var resourceErr *api.ResourceError
+			require.ErrorAs(t, err, &resourceErr)
+			assert.Equal(t, "Pod", resourceErr.Kind)
+			assert.Equal(t, "default", resourceErr.Namespace)
+			assert.Equal(t, tt.objName, resourceErr.Name)
+		})
+	}
+}
+
+func TestPodAPI_Retry(t *testing.T) {
+	policy := api.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		Multiplier:           2,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+
+	tests := []struct {
+		name          string
+		verb          string
+		failures      int
+		err           error
+		expectedCalls int
+		wantErr       bool
+	}{
+		{
+			name:          "Get succeeds after throttling",
+			verb:          "get",
+			failures:      2,
+			err:           apierrors.NewTooManyRequests("slow down", 0),
+			expectedCalls: 3,
+		},
+		{
+			name:          "List succeeds after unavailability",
+			verb:          "list",
+			failures:      1,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 2,
+		},
+		{
+			name:          "Gives up after max attempts",
+			verb:          "list",
+			failures:      3,
+			err:           apierrors.NewServiceUnavailable("apiserver restarting"),
+			expectedCalls: 3,
+			wantErr:       true,
+		},
+		{
+			name:          "Non-retryable error",
+			verb:          "get",
+			failures:      1,
+			err:           apierrors.NewForbidden(schema.GroupResource{Group: "", Resource: "pods"}, "web", errors.New("rbac denied")),
+			expectedCalls: 1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+			calls := 0
+			fakeClient.PrependReactor(tt.verb, "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls <= tt.failures {
+					return true, nil, tt.err
+				}
+				return false, nil, nil
+			})
+			podAPI := NewPodAPI(fakeClient, WithRetryPolicy(policy))
+
+			var err error
+			if tt.verb == "get" {
+				_, err = podAPI.GetPodByName(context.Background(), "default", "web")
+			} else {
+				_, err = podAPI.ListPodsByLabel(context.Background(), "default", "app=web")
+			}
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPodAPI_ListPodsByLabelPaged_Retry(t *testing.T) {
+	fakeClient := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	)
+	calls := 0
+	fakeClient.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls == 1 {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver restarting")
+		}
+		return false, nil, nil
+	})
+	podAPI := NewPodAPI(fakeClient, WithRetryPolicy(api.RetryPolicy{
+		MaxAttempts:          2,
+		InitialBackoff:       time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}))
+
+	var names []string
+	for pod, err := range podAPI.ListPodsByLabelPaged(context.Background(), "default", "app=web", 10) {
+		require.NoError(t, err)
+		names = append(names, pod.Name)
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.ElementsMatch(t, []string{"web-1", "web-2"}, names)
+}
+
+func TestPodAPI_RateLimiter(t *testing.T) {
+	fakeClient := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}})
+	calls := 0
+	fakeClient.PrependReactor("get", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		if calls == 1 {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver restarting")
+		}
+		return false, nil, nil
+	})
+	podAPI := NewPodAPI(fakeClient,
+		WithRetryPolicy(api.RetryPolicy{
+			MaxAttempts:          2,
+			InitialBackoff:       time.Millisecond,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		}),
+		WithRateLimiter(api.NewRateLimiter(api.RateLimit{QPS: 50, Burst: 1})),
+	)
+
+	ctx, stats := api.WithRequestStats(context.Background())
+	_, err := podAPI.GetPodByName(ctx, "default", "web")
+	require.NoError(t, err)
+	_, err = podAPI.ListPodsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+
+	// Each attempt is admitted on its own: the retried get and the list wait for a token.
+	assert.Equal(t, 3, stats.Requests())
+	assert.GreaterOrEqual(t, stats.Wait(), 30*time.Millisecond)
+
+	// A request that is not admitted is not sent.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = podAPI.GetPodByName(canceled, "default", "web")
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPodAPI_Logger(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	fakeClient := fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: labels}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: labels}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-3", Namespace: "default", Labels: labels}},
+	)
+	var buf bytes.Buffer
+	podAPI := NewPodAPI(fakeClient, WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	ctx := context.Background()
+
+	_, err := podAPI.GetPodByName(ctx, "default", "web-1")
+	require.NoError(t, err)
+	_, err = podAPI.GetPodByName(ctx, "default", "missing")
+	require.Error(t, err)
+	_, err = podAPI.ListPodsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+	for _, err := range podAPI.ListPodsByLabelPaged(ctx, "default", "app=web", 2) {
+		require.NoError(t, err)
+	}
+	// Invalid input is rejected before any request is sent, and not logged.
+	_, err = podAPI.GetPodByName(ctx, "", "web-1")
+	require.Error(t, err)
+
+	var records []map[string]any
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]any
+		require.NoError(t, decoder.Decode(&record))
+		assert.Contains(t, record, "duration")
+		delete(record, "time")
+		delete(record, "duration")
+		records = append(records, record)
+	}
+
+	assert.Equal(t, []map[string]any{
+		{"level": "DEBUG", "msg": "kubernetes request", "verb": "get", "resource": "pods", "namespace": "default", "name": "web-1", "items": 1.0},
+		{"level": "WARN", "msg": "kubernetes request failed", "verb": "get", "resource": "pods", "namespace": "default", "name": "missing",
+			"error": `failed to get pod "missing" in namespace "default": pods "missing" not found`},
+		{"level": "DEBUG", "msg": "kubernetes request", "verb": "list", "resource": "pods", "namespace": "default", "labelSelector": "app=web", "items": 3.0},
+		// Each page is logged as a request of its own; the fake clientset ignores the limit.
+		{"level": "DEBUG", "msg": "kubernetes request", "verb": "list", "resource": "pods", "namespace": "default", "labelSelector": "app=web", "items": 3.0},
+	}, records)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// RateLimit bounds the load a client puts on the apiserver.
+//
+// The zero value imposes no limit.
+type RateLimit struct {
+	// QPS is the sustained number of requests per second. Values of 0 or below disable
+	// the token bucket.
+	QPS float32
+	// Burst is the number of requests that may be sent at once before QPS applies.
+	// It defaults to 1 when QPS is set.
+	Burst int
+	// MaxInFlight is the maximum number of requests awaiting a response at any time.
+	// Values of 0 or below leave concurrency unbounded.
+	MaxInFlight int
+}
+
+// RateLimiter admits requests to the apiserver according to a RateLimit. It is safe for
+// concurrent use and meant to be shared by every resource API talking to one cluster,
+// so that the limit holds for all of them together.
+//
+// A nil *RateLimiter admits every request immediately.
+type RateLimiter struct {
+	bucket   flowcontrol.RateLimiter
+	inFlight chan struct{}
+}
+
+// NewRateLimiter creates a RateLimiter enforcing limit.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	l := &RateLimiter{}
+	if limit.QPS > 0 {
+		l.bucket = flowcontrol.NewTokenBucketRateLimiter(limit.QPS, max(limit.Burst, 1))
+	}
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// Acquire blocks until a request may be sent: a token is available and fewer than
+// MaxInFlight requests are pending. The returned function must be called once the
+// request completes to free its in-flight slot.
+//
+// The time spent blocked is added to the RequestStats of ctx, if any. When the deadline
+// of ctx expires, or would expire before a token is available, the returned error
+// wraps ErrTimeout; when ctx is canceled, its error is returned.
+func (l *RateLimiter) Acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	if l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			return nil, waitError(ctx, err)
+		}
+	}
+
+	release := func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			release = func() { <-l.inFlight }
+		case <-ctx.Done():
+			return nil, waitError(ctx, ctx.Err())
+		}
+	}
+
+	if stats, ok := ctx.Value(requestStatsKey{}).(*RequestStats); ok {
+		stats.requests.Add(1)
+		stats.wait.Add(int64(time.Since(start)))
+	}
+
+	return release, nil
+}
+
+// waitError returns the error of a wait for admission that ctx interrupted with err.
+func waitError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ctx.Err()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return fmt.Errorf("%w waiting for the rate limiter: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+// RequestStats accumulates the requests admitted by a RateLimiter on behalf of one
+// call, and the time they spent waiting to be admitted. It is safe for concurrent use.
+type RequestStats struct {
+	requests atomic.Int64
+	wait     atomic.Int64
+}
+
+type requestStatsKey struct{}
+
+// WithRequestStats returns a copy of ctx recording into the returned RequestStats. Pass
+// it to a resource API method to learn how long that call was held back:
+//
+//	ctx, stats := api.WithRequestStats(ctx)
+//	pods, err := podAPI.ListPodsByLabel(ctx, "default", "app=web")
+//	log.Printf("%d requests waited %s", stats.Requests(), stats.Wait())
+func WithRequestStats(ctx context.Context) (context.Context, *RequestStats) {
+	stats := &RequestStats{}
+	return context.WithValue(ctx, requestStatsKey{}, stats), stats
+}
+
+// Requests returns the number of requests admitted so far.
+func (s *RequestStats) Requests() int {
+	return int(s.requests.Load())
+}
+
+// Wait returns the total time the admitted requests waited for a token or an
+// in-flight slot.
+func (s *RequestStats) Wait() time.Duration {
+	return time.Duration(s.wait.Load())
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var limiter *RateLimiter
+
+	ctx, stats := WithRequestStats(context.Background())
+	release, err := limiter.Acquire(ctx)
+
+	require.NoError(t, err)
+	release()
+	assert.Zero(t, stats.Requests())
+}
+
+func TestRateLimiter_Unlimited(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{})
+
+	ctx, stats := WithRequestStats(context.Background())
+	for range 100 {
+		release, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		release()
+	}
+
+	assert.Equal(t, 100, stats.Requests())
+	assert.Less(t, stats.Wait(), 100*time.Millisecond)
+}
+
+func TestRateLimiter_QPS(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{QPS: 20, Burst: 2})
+
+	ctx, stats := WithRequestStats(context.Background())
+	start := time.Now()
+	for range 4 {
+		release, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		release()
+	}
+
+	// The burst is admitted at once, the two other requests wait 50ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Equal(t, 4, stats.Requests())
+	assert.GreaterOrEqual(t, stats.Wait(), 90*time.Millisecond)
+}
+
+func TestRateLimiter_QPSDefaultBurst(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{QPS: 1})
+
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+
+	// The next token is a second away, past the deadline of ctx.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx)
+
+	require.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestRateLimiter_MaxInFlight(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{MaxInFlight: 2})
+
+	first, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	second, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// Both slots are taken: the third request is held back until ctx is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(ctx)
+	require.ErrorIs(t, err, ErrTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A canceled wait is not a timeout.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = limiter.Acquire(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, ErrTimeout)
+
+	// Releasing a slot admits the next request.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		first()
+	}()
+	ctx, stats := WithRequestStats(context.Background())
+	third, err := limiter.Acquire(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, stats.Wait(), 15*time.Millisecond)
+
+	second()
+	third()
+}
+
+func TestRequestStats_Concurrent(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{MaxInFlight: 4})
+	ctx, stats := WithRequestStats(context.Background())
+
+	done := make(chan struct{})
+	for range 10 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			release, err := limiter.Acquire(ctx)
+			if err == nil {
+				time.Sleep(time.Millisecond)
+				release()
+			}
+		}()
+	}
+	for range 10 {
+		<-done
+	}
+
+	assert.Equal(t, 10, stats.Requests())
+}
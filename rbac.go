@@ -0,0 +1,43 @@
+package api
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// RBACRef identifies a Role, ClusterRole, RoleBinding or ClusterRoleBinding.
+// Namespace is empty for the cluster-scoped kinds.
+type RBACRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// BoundSubject is a subject granted a role, together with the binding granting it
+// and the role that binding references: the role itself, or a ClusterRole
+// aggregating it.
+type BoundSubject struct {
+	Subject rbacv1.Subject
+	Role    RBACRef
+	Binding RBACRef
+}
+
+// EffectiveRule is a policy rule granted to a subject, together with the role that
+// defines it and the binding that grants that role. A rule granted through a
+// RoleBinding only applies within the namespace of that binding.
+type EffectiveRule struct {
+	Rule    rbacv1.PolicyRule
+	Role    RBACRef
+	Binding RBACRef
+}
+
+// RoleGrant is a Role or ClusterRole granting a verb on a resource, together with the
+// rules of the role granting it.
+//
+// ResourceNames is empty when the role grants the verb on every object of the
+// resource. When every granting rule is limited by resourceNames, it holds the names
+// of the objects the grant is limited to, sorted.
+type RoleGrant struct {
+	Role          RBACRef
+	Rules         []rbacv1.PolicyRule
+	ResourceNames []string
+}
@@ -0,0 +1,151 @@
+package rbacapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetClusterRoleByName retrieves a single ClusterRole object by its name.
+//
+// The name parameter is validated to ensure it is not empty.
+// If the validation fails or if the retrieval from the Kubernetes API fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the cluster-scoped clusterrole to retrieve.
+//
+// Returns a pointer to a rbacv1.ClusterRole object or an error if the clusterrole
+// is not found or if any other retrieval error occurs.
+func (r *RBACAPI) GetClusterRoleByName(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("ClusterRole", "", name, "name", "failed to validate clusterrole name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "clusterroles", Name: name}
+	start := time.Now()
+	cr, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRole, error) {
+		return r.client.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRole", "", name, fmt.Sprintf("failed to get clusterrole %q", name), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, 1)
+	return cr, nil
+}
+
+// ListClusterRolesByLabel retrieves a list of ClusterRole objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of rbacv1.ClusterRole objects matching the label selector, or
+// an error if the operation fails.
+func (r *RBACAPI) ListClusterRolesByLabel(ctx context.Context, labelSelector string) ([]rbacv1.ClusterRole, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("ClusterRole", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "clusterroles", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRoleList, error) {
+		return r.client.RbacV1().ClusterRoles().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRole", "", "", fmt.Sprintf("failed to list clusterroles by label %q", labelSelector), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListClusterRolesByField retrieves a list of ClusterRole objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of rbacv1.ClusterRole objects matching the field selector, or
+// an error if the operation fails.
+func (r *RBACAPI) ListClusterRolesByField(ctx context.Context, fieldSelector string) ([]rbacv1.ClusterRole, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("ClusterRole", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "clusterroles", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRoleList, error) {
+		return r.client.RbacV1().ClusterRoles().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRole", "", "", fmt.Sprintf("failed to list clusterroles by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListClusterRolesByQuery retrieves a list of ClusterRole objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of rbacv1.ClusterRole objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (r *RBACAPI) ListClusterRolesByQuery(ctx context.Context, query api.ListQuery) ([]rbacv1.ClusterRole, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("ClusterRole", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "clusterroles", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRoleList, error) {
+		return r.client.RbacV1().ClusterRoles().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRole", "", "", "failed to list clusterroles by query", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,354 @@
+package rbacapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestRBACAPI_GetClusterRoleBindingByName(t *testing.T) {
+	// Setup a cluster-scoped clusterrolebinding
+	testClusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-clusterrolebinding",
+		},
+	}
+
+	// Create fake clientset with test clusterrolebinding
+	fakeClient := fake.NewClientset(testClusterRoleBinding)
+
+	// Initialize RBAC API
+	crbAPI := NewRBACAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		crbName       string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:    "Successfully get clusterrolebinding",
+			crbName: "test-clusterrolebinding",
+			wantErr: false,
+		},
+		{
+			name:          "Empty clusterrolebinding name",
+			crbName:       "",
+			wantErr:       true,
+			errorContains: "failed to validate clusterrolebinding name",
+		},
+		{
+			name:          "ClusterRoleBinding not found",
+			crbName:       "nonexistent-clusterrolebinding",
+			wantErr:       true,
+			errorContains: "failed to get clusterrolebinding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			crb, err := crbAPI.GetClusterRoleBindingByName(ctx, tt.crbName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, crb)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, crb)
+				assert.Equal(t, tt.crbName, crb.Name)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRoleBindingsByLabel(t *testing.T) {
+	// Setup test clusterrolebindings
+	testClusterRoleBindings := []*rbacv1.ClusterRoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrolebinding-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrolebinding-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-clusterrolebinding",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testClusterRoleBindings {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize RBAC API
+	crbAPI := NewRBACAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List clusterrolebindings by app label",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-clusterrolebinding-1", "test-clusterrolebinding-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List clusterrolebindings with multiple labels",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-clusterrolebinding-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterRoleBindings, err := crbAPI.ListClusterRoleBindingsByLabel(ctx, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, clusterRoleBindings)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, clusterRoleBindings, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(clusterRoleBindings))
+				for _, item := range clusterRoleBindings {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRoleBindingsByField(t *testing.T) {
+	// Setup test clusterrolebindings
+	testClusterRoleBindings := []*rbacv1.ClusterRoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrolebinding-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrolebinding-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-clusterrolebinding",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testClusterRoleBindings {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize RBAC API
+	crbAPI := NewRBACAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every clusterrolebinding
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List clusterrolebindings by field",
+			fieldSelector: "metadata.name=test-clusterrolebinding-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterRoleBindings, err := crbAPI.ListClusterRoleBindingsByField(ctx, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, clusterRoleBindings)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, clusterRoleBindings, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRoleBindingsByQuery(t *testing.T) {
+	// Setup clusterrolebindings with different labels
+	fakeClient := fake.NewClientset(
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	crbAPI := NewRBACAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := crbAPI.ListClusterRoleBindingsByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
@@ -0,0 +1,354 @@
+package rbacapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+)
+
+func TestRBACAPI_GetClusterRoleByName(t *testing.T) {
+	// Setup a cluster-scoped clusterrole
+	testClusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-clusterrole",
+		},
+	}
+
+	// Create fake clientset with test clusterrole
+	fakeClient := fake.NewClientset(testClusterRole)
+
+	// Initialize RBAC API
+	crAPI := NewRBACAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		crName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:    "Successfully get clusterrole",
+			crName:  "test-clusterrole",
+			wantErr: false,
+		},
+		{
+			name:          "Empty clusterrole name",
+			crName:        "",
+			wantErr:       true,
+			errorContains: "failed to validate clusterrole name",
+		},
+		{
+			name:          "ClusterRole not found",
+			crName:        "nonexistent-clusterrole",
+			wantErr:       true,
+			errorContains: "failed to get clusterrole",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cr, err := crAPI.GetClusterRoleByName(ctx, tt.crName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, cr)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, cr)
+				assert.Equal(t, tt.crName, cr.Name)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRolesByLabel(t *testing.T) {
+	// Setup test clusterroles
+	testClusterRoles := []*rbacv1.ClusterRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrole-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrole-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-clusterrole",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testClusterRoles {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize RBAC API
+	crAPI := NewRBACAPI(fakeClient)
+
+	// Test cases
+	tests := []struct {
+		name          string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List clusterroles by app label",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-clusterrole-1", "test-clusterrole-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List clusterroles with multiple labels",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-clusterrole-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterRoles, err := crAPI.ListClusterRolesByLabel(ctx, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, clusterRoles)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, clusterRoles, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(clusterRoles))
+				for _, item := range clusterRoles {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRolesByField(t *testing.T) {
+	// Setup test clusterroles
+	testClusterRoles := []*rbacv1.ClusterRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrole-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-clusterrole-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-clusterrole",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testClusterRoles {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize RBAC API
+	crAPI := NewRBACAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors, so every clusterrole
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List clusterroles by field",
+			fieldSelector: "metadata.name=test-clusterrole-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clusterRoles, err := crAPI.ListClusterRolesByField(ctx, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, clusterRoles)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, clusterRoles, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestRBACAPI_ListClusterRolesByQuery(t *testing.T) {
+	// Setup clusterroles with different labels
+	fakeClient := fake.NewClientset(
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	crAPI := NewRBACAPI(fakeClient)
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := crAPI.ListClusterRolesByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
@@ -0,0 +1,453 @@
+package rbacapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kaudit/val"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// binding is the common shape of RoleBindings and ClusterRoleBindings.
+type binding struct {
+	ref      api.RBACRef
+	roleRef  rbacv1.RoleRef
+	subjects []rbacv1.Subject
+}
+
+// ListSubjectsForClusterRole lists the subjects bound to a ClusterRole, through
+// ClusterRoleBindings as well as RoleBindings in any namespace.
+//
+// Subjects bound to a ClusterRole aggregating the requested one, directly or through
+// other aggregated ClusterRoles, are included too, since they are granted its rules;
+// the Role of each subject tells which ClusterRole its binding references.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - name: Name of the ClusterRole (must be non-empty).
+//
+// Returns every bound subject with the binding granting it, or an error.
+func (r *RBACAPI) ListSubjectsForClusterRole(ctx context.Context, name string) ([]api.BoundSubject, error) {
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("ClusterRole", "", name, "name", "invalid clusterrole name", err)
+	}
+
+	clusterRoles, err := r.loadClusterRoles(ctx)
+	if err != nil {
+		return nil, api.NewResourceError("ClusterRole", "", name, fmt.Sprintf("failed to list subjects for clusterrole %q", name), err)
+	}
+	bindings, err := r.listBindings(ctx)
+	if err != nil {
+		return nil, api.NewResourceError("ClusterRole", "", name, fmt.Sprintf("failed to list subjects for clusterrole %q", name), err)
+	}
+
+	var subjects []api.BoundSubject
+	for _, b := range bindings {
+		if b.roleRef.Kind != "ClusterRole" ||
+			b.roleRef.Name != name && !clusterRoles.aggregates(b.roleRef.Name, name) {
+			continue
+		}
+		role := api.RBACRef{Kind: "ClusterRole", Name: b.roleRef.Name}
+		for _, subject := range b.subjects {
+			subjects = append(subjects, api.BoundSubject{Subject: subject, Role: role, Binding: b.ref})
+		}
+	}
+
+	return subjects, nil
+}
+
+// ListRolesGranting lists the Roles and ClusterRoles with a rule granting verb on
+// resource.
+//
+// Rules are matched the way the RBAC authorizer does: on the verb, the API group and
+// the resource, where "*" matches any verb, group or resource, subresources included,
+// and "*/<subresource>" matches that subresource of any resource. Aggregated
+// ClusterRoles are resolved from the ClusterRoles they select. Rules limited by
+// resourceNames are reported too: the ResourceNames of a grant tell the objects it is
+// limited to when no granting rule covers them all. Rules on non-resource URLs only
+// never match.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - verb: Verb to look for, e.g. "delete" (must be non-empty).
+//   - resource: Resource to look for as resource[.group][/subresource], e.g. "secrets",
+//     "pods/exec" or "deployments.apps/scale"; without a group, the resource is looked
+//     for in the core group (must be non-empty).
+//
+// Returns the grants of the matching ClusterRoles followed by those of the matching Roles,
+// or an error.
+func (r *RBACAPI) ListRolesGranting(ctx context.Context, verb, resource string) ([]api.RoleGrant, error) {
+	if err := val.ValidateWithTag(verb, "required"); err != nil {
+		return nil, api.NewValidationError("Role", "", "", "verb", "invalid verb", err)
+	}
+	if err := val.ValidateWithTag(resource, "required"); err != nil {
+		return nil, api.NewValidationError("Role", "", "", "resource", "invalid resource", err)
+	}
+	target, err := parseResource(resource)
+	if err != nil {
+		return nil, api.NewValidationError("Role", "", "", "resource", "invalid resource", err)
+	}
+
+	clusterRoles, err := r.loadClusterRoles(ctx)
+	if err != nil {
+		return nil, api.NewResourceError("Role", "", "", fmt.Sprintf("failed to list roles granting %q on %q", verb, resource), err)
+	}
+	req := reqlog.Request{Verb: "list", Resource: "roles"}
+	start := time.Now()
+	roles, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", "", "", fmt.Sprintf("failed to list roles granting %q on %q", verb, resource), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(roles.Items))
+
+	var grants []api.RoleGrant
+	for _, name := range clusterRoles.names() {
+		if grant, ok := granting(clusterRoles.rules(name), verb, target); ok {
+			grant.Role = api.RBACRef{Kind: "ClusterRole", Name: name}
+			grants = append(grants, grant)
+		}
+	}
+	for _, role := range roles.Items {
+		if grant, ok := granting(role.Rules, verb, target); ok {
+			grant.Role = api.RBACRef{Kind: "Role", Namespace: role.Namespace, Name: role.Name}
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, nil
+}
+
+// ListEffectiveRulesForServiceAccount lists the rules granted to a ServiceAccount.
+//
+// A binding applies to the ServiceAccount when it names it directly, or names one of the
+// groups every ServiceAccount of the namespace belongs to: system:serviceaccounts,
+// system:serviceaccounts:<namespace> and system:authenticated. RoleBindings of every
+// namespace are considered; the Binding of each rule tells where the rule applies.
+// Aggregated ClusterRoles are resolved and bindings to missing roles grant nothing.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the service account (must be non-empty).
+//   - name: Name of the service account (must be non-empty).
+//
+// Returns every effective rule with its role and binding, or an error.
+func (r *RBACAPI) ListEffectiveRulesForServiceAccount(ctx context.Context, namespace, name string) ([]api.EffectiveRule, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, name, "name", "invalid service account name", err)
+	}
+
+	resolve, err := r.loadRoles(ctx)
+	if err != nil {
+		return nil, api.NewResourceError("ServiceAccount", namespace, name, fmt.Sprintf("failed to list rules for service account %q in namespace %q", name, namespace), err)
+	}
+	bindings, err := r.listBindings(ctx)
+	if err != nil {
+		return nil, api.NewResourceError("ServiceAccount", namespace, name, fmt.Sprintf("failed to list rules for service account %q in namespace %q", name, namespace), err)
+	}
+
+	var rules []api.EffectiveRule
+	for _, b := range bindings {
+		if !slices.ContainsFunc(b.subjects, func(s rbacv1.Subject) bool {
+			return isServiceAccount(s, b.ref.Namespace, namespace, name)
+		}) {
+			continue
+		}
+
+		role, roleRules := resolve(b)
+		for _, rule := range roleRules {
+			rules = append(rules, api.EffectiveRule{Rule: rule, Role: role, Binding: b.ref})
+		}
+	}
+
+	return rules, nil
+}
+
+// listBindings lists the ClusterRoleBindings and the RoleBindings of every namespace.
+func (r *RBACAPI) listBindings(ctx context.Context) ([]binding, error) {
+	req := reqlog.Request{Verb: "list", Resource: "clusterrolebindings"}
+	start := time.Now()
+	crbs, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRoleBindingList, error) {
+		return r.client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRoleBinding", "", "", "failed to list clusterrolebindings", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(crbs.Items))
+	req = reqlog.Request{Verb: "list", Resource: "rolebindings"}
+	start = time.Now()
+	rbs, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", "", "", "failed to list rolebindings", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(rbs.Items))
+
+	bindings := make([]binding, 0, len(crbs.Items)+len(rbs.Items))
+	for _, crb := range crbs.Items {
+		bindings = append(bindings, binding{
+			ref:      api.RBACRef{Kind: "ClusterRoleBinding", Name: crb.Name},
+			roleRef:  crb.RoleRef,
+			subjects: crb.Subjects,
+		})
+	}
+	for _, rb := range rbs.Items {
+		bindings = append(bindings, binding{
+			ref:      api.RBACRef{Kind: "RoleBinding", Namespace: rb.Namespace, Name: rb.Name},
+			roleRef:  rb.RoleRef,
+			subjects: rb.Subjects,
+		})
+	}
+
+	return bindings, nil
+}
+
+// loadRoles lists every Role and ClusterRole and returns a function resolving the
+// role referenced by a binding to its reference and rules.
+func (r *RBACAPI) loadRoles(ctx context.Context) (func(binding) (api.RBACRef, []rbacv1.PolicyRule), error) {
+	clusterRoles, err := r.loadClusterRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := reqlog.Request{Verb: "list", Resource: "roles"}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", "", "", "failed to list roles", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	roles := make(map[api.RBACRef][]rbacv1.PolicyRule, len(list.Items))
+	for _, role := range list.Items {
+		roles[api.RBACRef{Kind: "Role", Namespace: role.Namespace, Name: role.Name}] = role.Rules
+	}
+
+	return func(b binding) (api.RBACRef, []rbacv1.PolicyRule) {
+		if b.roleRef.Kind == "ClusterRole" {
+			return api.RBACRef{Kind: "ClusterRole", Name: b.roleRef.Name}, clusterRoles.rules(b.roleRef.Name)
+		}
+		// A RoleBinding can only reference a Role of its own namespace.
+		ref := api.RBACRef{Kind: "Role", Namespace: b.ref.Namespace, Name: b.roleRef.Name}
+		return ref, roles[ref]
+	}, nil
+}
+
+// isServiceAccount reports whether subject, taken from a binding in bindingNamespace,
+// applies to the service account name in namespace.
+func isServiceAccount(subject rbacv1.Subject, bindingNamespace, namespace, name string) bool {
+	switch subject.Kind {
+	case rbacv1.ServiceAccountKind:
+		// The namespace of a ServiceAccount subject defaults to that of the binding.
+		subjectNamespace := subject.Namespace
+		if subjectNamespace == "" {
+			subjectNamespace = bindingNamespace
+		}
+		return subject.Name == name && subjectNamespace == namespace
+	case rbacv1.UserKind:
+		return subject.Name == "system:serviceaccount:"+namespace+":"+name
+	case rbacv1.GroupKind:
+		return subject.Name == "system:serviceaccounts" ||
+			subject.Name == "system:serviceaccounts:"+namespace ||
+			subject.Name == "system:authenticated"
+	}
+	return false
+}
+
+// resourceTarget is the resource a query asks about, split into its API group,
+// resource and subresource.
+type resourceTarget struct {
+	group       string
+	resource    string
+	subresource string
+}
+
+// parseResource parses resource[.group][/subresource], e.g. "deployments.apps/scale".
+func parseResource(s string) (resourceTarget, error) {
+	name, subresource, hasSubresource := strings.Cut(s, "/")
+	resource, group, _ := strings.Cut(name, ".")
+	if resource == "" || hasSubresource && (subresource == "" || strings.Contains(subresource, "/")) {
+		return resourceTarget{}, errors.New("resource must be resource[.group][/subresource]")
+	}
+	return resourceTarget{group: group, resource: resource, subresource: subresource}, nil
+}
+
+// granting returns the grant, without its Role, of the rules allowing verb on
+// target, and whether there is any.
+func granting(rules []rbacv1.PolicyRule, verb string, target resourceTarget) (api.RoleGrant, bool) {
+	var grant api.RoleGrant
+	restricted := true
+	for _, rule := range rules {
+		// Non-resource URL rules have no resources and never match
+		if !matches(rule.Verbs, verb) || !matches(rule.APIGroups, target.group) || !resourceMatches(rule.Resources, target) {
+			continue
+		}
+		grant.Rules = append(grant.Rules, rule)
+		if len(rule.ResourceNames) == 0 {
+			restricted = false
+		}
+		grant.ResourceNames = append(grant.ResourceNames, rule.ResourceNames...)
+	}
+	if len(grant.Rules) == 0 {
+		return api.RoleGrant{}, false
+	}
+
+	if restricted {
+		slices.Sort(grant.ResourceNames)
+		grant.ResourceNames = slices.Compact(grant.ResourceNames)
+	} else {
+		grant.ResourceNames = nil
+	}
+	return grant, true
+}
+
+// matches reports whether values contains value or the "*" wildcard.
+func matches(values []string, value string) bool {
+	return slices.Contains(values, value) || slices.Contains(values, "*")
+}
+
+// resourceMatches reports whether the resources of a rule cover target, as the RBAC
+// authorizer does: "*" covers every resource and subresource, "<resource>/<subresource>"
+// only that subresource and "*/<subresource>" that subresource of every resource.
+func resourceMatches(resources []string, target resourceTarget) bool {
+	combined := target.resource
+	if target.subresource != "" {
+		combined += "/" + target.subresource
+	}
+	for _, resource := range resources {
+		if resource == "*" || resource == combined {
+			return true
+		}
+		if target.subresource != "" && resource == "*/"+target.subresource {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterRoleSet indexes ClusterRoles by name to resolve aggregation.
+type clusterRoleSet map[string]rbacv1.ClusterRole
+
+// loadClusterRoles lists every ClusterRole.
+func (r *RBACAPI) loadClusterRoles(ctx context.Context) (clusterRoleSet, error) {
+	req := reqlog.Request{Verb: "list", Resource: "clusterroles"}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.ClusterRoleList, error) {
+		return r.client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ClusterRole", "", "", "failed to list clusterroles", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	set := make(clusterRoleSet, len(list.Items))
+	for _, cr := range list.Items {
+		set[cr.Name] = cr
+	}
+	return set, nil
+}
+
+// names returns the names of the ClusterRoles in sorted order.
+func (c clusterRoleSet) names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// rules returns the rules of the named ClusterRole. For an aggregated ClusterRole
+// these are its own rules plus those of every ClusterRole its selectors match,
+// resolved recursively and without duplicates. An unknown name has no rules.
+func (c clusterRoleSet) rules(name string) []rbacv1.PolicyRule {
+	return c.collect(name, map[string]bool{})
+}
+
+// aggregates reports whether the named ClusterRole aggregates member, directly or
+// through other aggregated ClusterRoles.
+func (c clusterRoleSet) aggregates(name, member string) bool {
+	return c.reaches(name, member, map[string]bool{})
+}
+
+func (c clusterRoleSet) reaches(name, member string, seen map[string]bool) bool {
+	cr, ok := c[name]
+	if !ok || seen[name] || cr.AggregationRule == nil {
+		return false
+	}
+	seen[name] = true
+
+	for _, other := range c.aggregatedBy(cr.AggregationRule) {
+		if other == member || c.reaches(other, member, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c clusterRoleSet) collect(name string, seen map[string]bool) []rbacv1.PolicyRule {
+	cr, ok := c[name]
+	if !ok || seen[name] {
+		return nil
+	}
+	seen[name] = true
+
+	rules := slices.Clone(cr.Rules)
+	if cr.AggregationRule == nil {
+		return rules
+	}
+
+	for _, other := range c.aggregatedBy(cr.AggregationRule) {
+		for _, rule := range c.collect(other, seen) {
+			if !slices.ContainsFunc(rules, func(existing rbacv1.PolicyRule) bool {
+				return equality.Semantic.DeepEqual(existing, rule)
+			}) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+// aggregatedBy returns the names, in sorted order, of the ClusterRoles selected by
+// any of the selectors of rule. Selectors that cannot be parsed select nothing.
+func (c clusterRoleSet) aggregatedBy(rule *rbacv1.AggregationRule) []string {
+	var names []string
+	for _, name := range c.names() {
+		set := labels.Set(c[name].Labels)
+		if slices.ContainsFunc(rule.ClusterRoleSelectors, func(s metav1.LabelSelector) bool {
+			selector, err := metav1.LabelSelectorAsSelector(&s)
+			return err == nil && !selector.Empty() && selector.Matches(set)
+		}) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
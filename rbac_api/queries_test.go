@@ -0,0 +1,437 @@
+package rbacapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api"
+)
+
+// rbacFixture returns a small RBAC setup:
+//   - "monitoring" aggregates "pod-reader" and "secret-reader" through labels, and is
+//     itself aggregated by "observability"
+//   - "cluster-admin" allows everything
+//   - "ci/deployer" is a Role allowing deployments to be updated
+//   - "ci/tls-rotator" is a Role allowing named secrets to be read and updated, and
+//     every secret to be listed
+//   - "healthz-reader" only allows a non-resource URL
+//   - "scaler" allows the scale subresource of any apps resource to be updated
+//   - bindings grant these to ServiceAccounts and groups, one to a missing Role
+func rbacFixture() []runtime.Object {
+	podRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}
+	secretRule := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}
+	deployRule := rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"update"}}
+
+	return []runtime.Object{
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"aggregate-to-observability": "true"}},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"aggregate-to-monitoring": "true"}},
+				},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "observability"},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"aggregate-to-observability": "true"}},
+				},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod-reader", Labels: map[string]string{"aggregate-to-monitoring": "true"}},
+			Rules:      []rbacv1.PolicyRule{podRule},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-reader", Labels: map[string]string{"aggregate-to-monitoring": "true"}},
+			Rules:      []rbacv1.PolicyRule{secretRule, podRule},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+			Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "ci"},
+			Rules:      []rbacv1.PolicyRule{deployRule},
+		},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls-rotator", Namespace: "ci"},
+			Rules: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"tls-b", "tls-a"}, Verbs: []string{"get", "update"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"ca", "tls-a"}, Verbs: []string{"get"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"list"}},
+			},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "healthz-reader"},
+			Rules:      []rbacv1.PolicyRule{{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}}},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "scaler"},
+			Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"*/scale"}, Verbs: []string{"update"}}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "observability"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "sre"},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "monitoring-agent"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "monitoring"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "agent", Namespace: "monitoring"},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "platform-admins"},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "ci-monitoring", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "monitoring"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "system:serviceaccounts:ci"},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "deployer"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "builder"},
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "dangling", Namespace: "ci"},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "deleted-role"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "builder"},
+			},
+		},
+	}
+}
+
+func TestRBACAPI_ListSubjectsForClusterRole(t *testing.T) {
+	rbacAPI := NewRBACAPI(fake.NewClientset(rbacFixture()...))
+
+	tests := []struct {
+		name          string
+		clusterRole   string
+		expected      []api.BoundSubject
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:        "Bound through ClusterRoleBinding and RoleBinding",
+			clusterRole: "monitoring",
+			expected: []api.BoundSubject{
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "agent", Namespace: "monitoring"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "monitoring"},
+					Binding: api.RBACRef{Kind: "ClusterRoleBinding", Name: "monitoring-agent"},
+				},
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "system:serviceaccounts:ci"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "monitoring"},
+					Binding: api.RBACRef{Kind: "RoleBinding", Namespace: "ci", Name: "ci-monitoring"},
+				},
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "sre"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "observability"},
+					Binding: api.RBACRef{Kind: "ClusterRoleBinding", Name: "sre"},
+				},
+			},
+		},
+		{
+			name:        "Bound through aggregating roles only",
+			clusterRole: "pod-reader",
+			expected: []api.BoundSubject{
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "agent", Namespace: "monitoring"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "monitoring"},
+					Binding: api.RBACRef{Kind: "ClusterRoleBinding", Name: "monitoring-agent"},
+				},
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "system:serviceaccounts:ci"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "monitoring"},
+					Binding: api.RBACRef{Kind: "RoleBinding", Namespace: "ci", Name: "ci-monitoring"},
+				},
+				{
+					Subject: rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "sre"},
+					Role:    api.RBACRef{Kind: "ClusterRole", Name: "observability"},
+					Binding: api.RBACRef{Kind: "ClusterRoleBinding", Name: "sre"},
+				},
+			},
+		},
+		{
+			name:        "Unbound role",
+			clusterRole: "scaler",
+			expected:    nil,
+		},
+		{
+			name:          "Empty name",
+			clusterRole:   "",
+			wantErr:       true,
+			errorContains: "invalid clusterrole name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subjects, err := rbacAPI.ListSubjectsForClusterRole(context.Background(), tt.clusterRole)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, subjects)
+		})
+	}
+}
+
+func TestRBACAPI_ListRolesGranting(t *testing.T) {
+	rbacAPI := NewRBACAPI(fake.NewClientset(rbacFixture()...))
+
+	tests := []struct {
+		name          string
+		verb          string
+		resource      string
+		expected      []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:     "Aggregated, wildcard and resource name restricted roles",
+			verb:     "get",
+			resource: "secrets",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+				"ClusterRole/monitoring",
+				"ClusterRole/observability",
+				"ClusterRole/secret-reader",
+				"Role/ci/tls-rotator on [ca tls-a tls-b]",
+			},
+		},
+		{
+			name:     "Rules restricted to other resource names",
+			verb:     "update",
+			resource: "secrets",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+				"Role/ci/tls-rotator on [tls-a tls-b]",
+			},
+		},
+		{
+			name:     "Unrestricted rule",
+			verb:     "list",
+			resource: "secrets",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+				"Role/ci/tls-rotator",
+			},
+		},
+		{
+			name:     "Namespaced role",
+			verb:     "update",
+			resource: "deployments.apps",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+				"Role/ci/deployer",
+			},
+		},
+		{
+			name:     "Resource without a group is in the core group",
+			verb:     "update",
+			resource: "deployments",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+			},
+		},
+		{
+			name:     "Subresource wildcard",
+			verb:     "update",
+			resource: "statefulsets.apps/scale",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+				"ClusterRole/scaler",
+			},
+		},
+		{
+			name:     "Resource rule does not cover its subresources",
+			verb:     "get",
+			resource: "pods/log",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+			},
+		},
+		{
+			name:     "Non-resource URL rules never match",
+			verb:     "get",
+			resource: "healthz",
+			expected: []string{
+				"ClusterRole/cluster-admin",
+			},
+		},
+		{
+			name:          "Empty verb",
+			verb:          "",
+			resource:      "secrets",
+			wantErr:       true,
+			errorContains: "invalid verb",
+		},
+		{
+			name:          "Empty resource",
+			verb:          "get",
+			resource:      "",
+			wantErr:       true,
+			errorContains: "invalid resource",
+		},
+		{
+			name:          "Empty subresource",
+			verb:          "get",
+			resource:      "pods/",
+			wantErr:       true,
+			errorContains: "invalid resource",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants, err := rbacAPI.ListRolesGranting(context.Background(), tt.verb, tt.resource)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			got := make([]string, 0, len(grants))
+			for _, g := range grants {
+				require.NotEmpty(t, g.Rules)
+				desc := refString(g.Role)
+				if len(g.ResourceNames) > 0 {
+					desc += fmt.Sprintf(" on %v", g.ResourceNames)
+				}
+				got = append(got, desc)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRBACAPI_ListRolesGranting_Rules(t *testing.T) {
+	rbacAPI := NewRBACAPI(fake.NewClientset(rbacFixture()...))
+
+	grants, err := rbacAPI.ListRolesGranting(context.Background(), "get", "secrets")
+	require.NoError(t, err)
+	require.Len(t, grants, 5)
+
+	// Only the rules granting the verb on the resource are reported
+	rotator := grants[4]
+	assert.Equal(t, api.RBACRef{Kind: "Role", Namespace: "ci", Name: "tls-rotator"}, rotator.Role)
+	require.Len(t, rotator.Rules, 2)
+	assert.Equal(t, []string{"tls-b", "tls-a"}, rotator.Rules[0].ResourceNames)
+	assert.Equal(t, []string{"ca", "tls-a"}, rotator.Rules[1].ResourceNames)
+}
+
+func TestRBACAPI_ListEffectiveRulesForServiceAccount(t *testing.T) {
+	rbacAPI := NewRBACAPI(fake.NewClientset(rbacFixture()...))
+
+	tests := []struct {
+		name          string
+		namespace     string
+		saName        string
+		expected      []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Direct ClusterRoleBinding resolves aggregation without duplicates",
+			namespace: "monitoring",
+			saName:    "agent",
+			expected: []string{
+				"ClusterRole/monitoring via ClusterRoleBinding/monitoring-agent: [get list] [pods]",
+				"ClusterRole/monitoring via ClusterRoleBinding/monitoring-agent: [get] [secrets]",
+			},
+		},
+		{
+			name:      "Group and namespace-defaulted subjects",
+			namespace: "ci",
+			saName:    "builder",
+			expected: []string{
+				"ClusterRole/monitoring via RoleBinding/ci/ci-monitoring: [get list] [pods]",
+				"ClusterRole/monitoring via RoleBinding/ci/ci-monitoring: [get] [secrets]",
+				"Role/ci/deployer via RoleBinding/ci/deployer: [update] [deployments]",
+			},
+		},
+		{
+			name:      "Unbound service account",
+			namespace: "default",
+			saName:    "default",
+			expected:  []string{},
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			saName:        "agent",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty name",
+			namespace:     "monitoring",
+			saName:        "",
+			wantErr:       true,
+			errorContains: "invalid service account name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := rbacAPI.ListEffectiveRulesForServiceAccount(context.Background(), tt.namespace, tt.saName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			described := make([]string, 0, len(rules))
+			for _, rule := range rules {
+				described = append(described, describe(rule))
+			}
+			assert.ElementsMatch(t, tt.expected, described)
+		})
+	}
+}
+
+// describe renders an effective rule as "role via binding: verbs resources".
+func describe(rule api.EffectiveRule) string {
+	return fmt.Sprintf("%s via %s: %v %v", refString(rule.Role), refString(rule.Binding), rule.Rule.Verbs, rule.Rule.Resources)
+}
+
+// refString renders ref as "Kind/name" or "Kind/namespace/name".
+func refString(ref api.RBACRef) string {
+	if ref.Namespace == "" {
+		return ref.Kind + "/" + ref.Name
+	}
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
@@ -0,0 +1,56 @@
+package rbacapi
+
+import (
+	"log/slog"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+)
+
+// RBACAPI provides high-level methods for inspecting rbac.authorization.k8s.io/v1
+// Roles, ClusterRoles, RoleBindings and ClusterRoleBindings.
+//
+// Besides the per-kind Get and List methods, it answers questions that span
+// several kinds, such as which subjects are bound to a ClusterRole or which rules
+// apply to a ServiceAccount. Those queries resolve aggregated ClusterRoles
+// themselves rather than relying on the aggregation controller having filled in
+// their rules.
+type RBACAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures an RBACAPI.
+type Option func(*RBACAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(r *RBACAPI) {
+		r.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *RBACAPI) {
+		r.logger = logger
+	}
+}
+
+// NewRBACAPI creates a new RBACAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewRBACAPI(client kubernetes.Interface, opts ...Option) *RBACAPI {
+	r := &RBACAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
@@ -0,0 +1,16 @@
+package rbacapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewRBACAPI(t *testing.T) {
+	client := fake.NewClientset()
+	rbacAPI := NewRBACAPI(client)
+
+	assert.NotNil(t, rbacAPI)
+	assert.Equal(t, client, rbacAPI.client)
+}
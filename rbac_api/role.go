@@ -0,0 +1,211 @@
+package rbacapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetRoleByName retrieves a specific Role by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the role (must be non-empty).
+//   - name: Name of the role (must be non-empty).
+//
+// Returns the matched *rbacv1.Role or an error if not found or invalid.
+func (r *RBACAPI) GetRoleByName(ctx context.Context, namespace, name string) (*rbacv1.Role, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, name, "name", "invalid role name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "roles", Namespace: namespace, Name: name}
+	start := time.Now()
+	role, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.Role, error) {
+		return r.client.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", namespace, name, fmt.Sprintf("failed to get role %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, 1)
+
+	return role, nil
+}
+
+// ListRolesByLabel lists roles by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching roles or an error.
+func (r *RBACAPI) ListRolesByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.Role, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "roles", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", namespace, "", fmt.Sprintf("failed to list roles by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListRolesByField lists roles by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching roles or an error.
+func (r *RBACAPI) ListRolesByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.Role, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Role", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "roles", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", namespace, "", fmt.Sprintf("failed to list roles by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListRolesByLabelAllNamespaces lists roles by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching roles or an error.
+func (r *RBACAPI) ListRolesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.Role, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Role", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "roles", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", "", "", "failed to list roles by label in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListRolesByFieldAllNamespaces lists roles by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching roles or an error.
+func (r *RBACAPI) ListRolesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.Role, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Role", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "roles", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", "", "", "failed to list roles by field in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListRolesByQuery lists roles by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching roles, at most query.Limit when set, or an error.
+func (r *RBACAPI) ListRolesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]rbacv1.Role, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Role", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "roles", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleList, error) {
+		return r.client.RbacV1().Roles(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("Role", namespace, "", fmt.Sprintf("failed to list roles by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,213 @@
+package rbacapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetRoleBindingByName retrieves a specific RoleBinding by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the rolebinding (must be non-empty).
+//   - name: Name of the rolebinding (must be non-empty).
+//
+// Returns the matched *rbacv1.RoleBinding or an error if not found or invalid.
+func (r *RBACAPI) GetRoleBindingByName(ctx context.Context, namespace, name string) (*rbacv1.RoleBinding, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, name, "name", "invalid rolebinding name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "rolebindings", Namespace: namespace, Name: name}
+	start := time.Now()
+	rb, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBinding, error) {
+		return r.client.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", namespace, name, fmt.Sprintf("failed to get rolebinding %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, 1)
+
+	return rb, nil
+}
+
+// ListRoleBindingsByLabel lists rolebindings by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching rolebindings or an error.
+func (r *RBACAPI) ListRoleBindingsByLabel(ctx context.Context, namespace string, labelSelector string) ([]rbacv1.RoleBinding, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "rolebindings", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", namespace, "", fmt.Sprintf("failed to list rolebindings by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListRoleBindingsByField lists rolebindings by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching rolebindings or an error.
+func (r *RBACAPI) ListRoleBindingsByField(ctx context.Context, namespace string, fieldSelector string) ([]rbacv1.RoleBinding, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "rolebindings", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", namespace, "", fmt.Sprintf("failed to list rolebindings by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListRoleBindingsByLabelAllNamespaces lists rolebindings by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching rolebindings or an error.
+func (r *RBACAPI) ListRoleBindingsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.RoleBinding, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "rolebindings", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", "", "", "failed to list rolebindings by label in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListRoleBindingsByFieldAllNamespaces lists rolebindings by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching rolebindings or an error.
+func (r *RBACAPI) ListRoleBindingsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]rbacv1.RoleBinding, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("RoleBinding", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "rolebindings", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", "", "", "failed to list rolebindings by field in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListRoleBindingsByQuery lists rolebindings by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching rolebindings, at most query.Limit when set, or an error.
+func (r *RBACAPI) ListRoleBindingsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]rbacv1.RoleBinding, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("RoleBinding", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "rolebindings", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*rbacv1.RoleBindingList, error) {
+		return r.client.RbacV1().RoleBindings(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("RoleBinding", namespace, "", fmt.Sprintf("failed to list rolebindings by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
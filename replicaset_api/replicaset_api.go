@@ -0,0 +1,255 @@
+package replicasetapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// ReplicaSetAPI provides high-level methods for retrieving Kubernetes replicasets.
+type ReplicaSetAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a ReplicaSetAPI.
+type Option func(*ReplicaSetAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(r *ReplicaSetAPI) {
+		r.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *ReplicaSetAPI) {
+		r.logger = logger
+	}
+}
+
+// NewReplicaSetAPI creates a new ReplicaSetAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewReplicaSetAPI(client kubernetes.Interface, opts ...Option) *ReplicaSetAPI {
+	r := &ReplicaSetAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// GetReplicaSetByName retrieves a specific ReplicaSet by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the replicaset (must be non-empty).
+//   - name: Name of the replicaset (must be non-empty).
+//
+// Returns the matched *appsv1.ReplicaSet or an error if not found or invalid.
+func (r *ReplicaSetAPI) GetReplicaSetByName(ctx context.Context, namespace, name string) (*appsv1.ReplicaSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, name, "name", "invalid replicaset name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "replicasets", Namespace: namespace, Name: name}
+	start := time.Now()
+	rs, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSet, error) {
+		return r.client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", namespace, name, fmt.Sprintf("failed to get replicaset %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, 1)
+
+	return rs, nil
+}
+
+// ListReplicaSetsByLabel lists replicasets by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching replicasets or an error.
+func (r *ReplicaSetAPI) ListReplicaSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.ReplicaSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "replicasets", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSetList, error) {
+		return r.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", namespace, "", fmt.Sprintf("failed to list replicasets by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListReplicaSetsByField lists replicasets by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching replicasets or an error.
+func (r *ReplicaSetAPI) ListReplicaSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.ReplicaSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "replicasets", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSetList, error) {
+		return r.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", namespace, "", fmt.Sprintf("failed to list replicasets by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListReplicaSetsByLabelAllNamespaces lists replicasets by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching replicasets or an error.
+func (r *ReplicaSetAPI) ListReplicaSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.ReplicaSet, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "replicasets", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSetList, error) {
+		return r.client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", "", "", "failed to list replicasets by label in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListReplicaSetsByFieldAllNamespaces lists replicasets by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching replicasets or an error.
+func (r *ReplicaSetAPI) ListReplicaSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.ReplicaSet, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "replicasets", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSetList, error) {
+		return r.client.AppsV1().ReplicaSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", "", "", "failed to list replicasets by field in all namespaces", err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListReplicaSetsByQuery lists replicasets by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching replicasets, at most query.Limit when set, or an error.
+func (r *ReplicaSetAPI) ListReplicaSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.ReplicaSet, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("ReplicaSet", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "replicasets", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, r.limiter, func() (*appsv1.ReplicaSetList, error) {
+		return r.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ReplicaSet", namespace, "", fmt.Sprintf("failed to list replicasets by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, r.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, r.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how reads failing with a transient apiserver error are retried.
+//
+// Only idempotent reads, Get and List requests including each page of a paginated
+// listing, are retried, and only when the apiserver answers with one of
+// RetryableStatusCodes. Watches are not retried: they re-establish dropped connections
+// on their own.
+//
+// The policy applies on top of the retries of client-go, whose REST client already
+// waits for and retries the 429 and 5xx responses carrying a Retry-After header, up to
+// 10 times by default. Such responses are therefore not retried again, whatever their
+// status code; the policy covers the responses without Retry-After.
+//
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first
+	// one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. Each further retry waits
+	// Multiplier times longer, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomly extends each delay by up to this fraction of it, e.g. 0.2 for
+	// up to 20%, so concurrent callers do not retry in lockstep.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes of the apiserver responses that
+	// are retried.
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the policy applied by k8sapi.NewK8sAPI: up to 4 attempts,
+// waiting 200ms, 400ms and 800ms plus up to 20% jitter in between, retrying throttled
+// (429), bad gateway (502), unavailable (503) and gateway timeout (504) responses
+// that client-go has not retried already.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package api
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretMetadata describes a Secret without its payload.
+//
+// It is returned by SecretAPI in place of corev1.Secret so that secret values are
+// never handed to callers: SecretAPI only requests the object metadata of Secrets, so
+// their type and data, which the apiserver returns with the payload alone, are not
+// known. The kubectl last-applied-configuration annotation, which can embed the full
+// Secret including its data, is removed from the ObjectMeta.
+type SecretMetadata struct {
+	metav1.ObjectMeta
+}
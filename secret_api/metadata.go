@@ -0,0 +1,113 @@
+package secretapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+)
+
+// Accept headers asking the apiserver for the metadata of a Secret, or of a list of
+// Secrets, in place of the full objects.
+const (
+	acceptPartialObjectMetadata     = "application/json;as=PartialObjectMetadata;g=meta.k8s.io;v=v1"
+	acceptPartialObjectMetadataList = "application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1"
+)
+
+// secretsResource identifies Secrets for the metadata client.
+var secretsResource = corev1.SchemeGroupVersion.WithResource("secrets")
+
+// errNoMetadataClient is returned when neither the core REST client nor a metadata
+// client is available to fetch secret metadata, e.g. with a fake clientset.
+var errNoMetadataClient = errors.New("no client to fetch secret metadata with: use WithMetadataClient")
+
+// metadataFetcher fetches secrets as PartialObjectMetadata, so that their payloads are
+// never sent by the apiserver.
+type metadataFetcher interface {
+	get(ctx context.Context, namespace, name string) (*metav1.PartialObjectMetadata, error)
+	list(ctx context.Context, namespace string, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error)
+}
+
+// restFetcher requests secret metadata through the REST client of the core API group.
+type restFetcher struct {
+	client rest.Interface
+}
+
+// newRESTFetcher returns a restFetcher for the core REST client of client, or nil when
+// client has none, as is the case of fake clientsets.
+func newRESTFetcher(client kubernetes.Interface) metadataFetcher {
+	c := client.CoreV1().RESTClient()
+	if rc, ok := c.(*rest.RESTClient); c == nil || ok && rc == nil {
+		return nil
+	}
+	return restFetcher{client: c}
+}
+
+func (f restFetcher) get(ctx context.Context, namespace, name string) (*metav1.PartialObjectMetadata, error) {
+	body, err := f.client.Get().
+		Namespace(namespace).
+		Resource("secrets").
+		Name(name).
+		SetHeader("Accept", acceptPartialObjectMetadata).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj metav1.PartialObjectMetadata
+	if err := decode(body, &obj.TypeMeta, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+func (f restFetcher) list(ctx context.Context, namespace string, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	body, err := f.client.Get().
+		Namespace(namespace).
+		Resource("secrets").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		SetHeader("Accept", acceptPartialObjectMetadataList).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list metav1.PartialObjectMetadataList
+	if err := decode(body, &list.TypeMeta, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// decode unmarshals body into obj, whose TypeMeta is typeMeta, and checks that the
+// apiserver returned metadata rather than full Secrets.
+func decode(body []byte, typeMeta *metav1.TypeMeta, obj any) error {
+	if err := json.Unmarshal(body, obj); err != nil {
+		return fmt.Errorf("failed to decode secret metadata: %w", err)
+	}
+	switch typeMeta.Kind {
+	case "PartialObjectMetadata", "PartialObjectMetadataList":
+		return nil
+	}
+	return fmt.Errorf("failed to decode secret metadata: apiserver returned %q instead", typeMeta.Kind)
+}
+
+// clientFetcher requests secret metadata through a metadata client.
+type clientFetcher struct {
+	client metadata.Interface
+}
+
+func (f clientFetcher) get(ctx context.Context, namespace, name string) (*metav1.PartialObjectMetadata, error) {
+	return f.client.Resource(secretsResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (f clientFetcher) list(ctx context.Context, namespace string, opts metav1.ListOptions) (*metav1.PartialObjectMetadataList, error) {
+	return f.client.Resource(secretsResource).Namespace(namespace).List(ctx, opts)
+}
@@ -0,0 +1,308 @@
+package secretapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// pageSize is the number of secrets requested per page when listing.
+const pageSize = 100
+
+// SecretAPI provides high-level methods for auditing Kubernetes secrets without
+// exposing their values to callers.
+//
+// Secrets are only ever requested as metadata (PartialObjectMetadata), through the REST
+// client of the core API group or a metadata client given with WithMetadataClient, so
+// their payloads never reach the process. Lists are requested in pages of 100 secrets.
+type SecretAPI struct {
+	fetcher metadataFetcher
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a SecretAPI.
+type Option func(*SecretAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(s *SecretAPI) {
+		s.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *SecretAPI) {
+		s.logger = logger
+	}
+}
+
+// WithMetadataClient fetches secret metadata through client rather than the REST client
+// of the core API group, e.g. to negotiate protobuf or to test against a fake client.
+func WithMetadataClient(client metadata.Interface) Option {
+	return func(s *SecretAPI) {
+		s.fetcher = clientFetcher{client: client}
+	}
+}
+
+// NewSecretAPI creates a new SecretAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance. A client without a REST
+// client, such as a fake clientset, needs WithMetadataClient: its calls fail otherwise.
+func NewSecretAPI(client kubernetes.Interface, opts ...Option) *SecretAPI {
+	s := &SecretAPI{
+		fetcher: newRESTFetcher(client),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetSecretByName retrieves the metadata of a specific Secret by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the secret (must be non-empty).
+//   - name: Name of the secret (must be non-empty).
+//
+// Returns the *api.SecretMetadata of the matched secret or an error if not found or invalid.
+func (s *SecretAPI) GetSecretByName(ctx context.Context, namespace, name string) (*api.SecretMetadata, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, name, "name", "invalid secret name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "secrets", Namespace: namespace, Name: name}
+	start := time.Now()
+	obj, err := throttle.Do(ctx, s.limiter, func() (*metav1.PartialObjectMetadata, error) {
+		if s.fetcher == nil {
+			return nil, errNoMetadataClient
+		}
+		return s.fetcher.get(ctx, namespace, name)
+	})
+	if err != nil {
+		err = api.NewResourceError("Secret", namespace, name, fmt.Sprintf("failed to get secret %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+
+	meta := metadataOf(obj)
+	return &meta, nil
+}
+
+// ListSecretsByLabel lists the metadata of secrets by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns the metadata of all matching secrets or an error.
+func (s *SecretAPI) ListSecretsByLabel(ctx context.Context, namespace string, labelSelector string) ([]api.SecretMetadata, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	list, err := s.list(ctx, namespace, opts, nil)
+	if err != nil {
+		return nil, api.NewResourceError("Secret", namespace, "", fmt.Sprintf("failed to list secrets by label in namespace %q", namespace), err)
+	}
+
+	return list, nil
+}
+
+// ListSecretsByField lists the metadata of secrets by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns the metadata of all matching secrets or an error.
+func (s *SecretAPI) ListSecretsByField(ctx context.Context, namespace string, fieldSelector string) ([]api.SecretMetadata, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	list, err := s.list(ctx, namespace, opts, nil)
+	if err != nil {
+		return nil, api.NewResourceError("Secret", namespace, "", fmt.Sprintf("failed to list secrets by field in namespace %q", namespace), err)
+	}
+
+	return list, nil
+}
+
+// ListSecretsByLabelAllNamespaces lists the metadata of secrets by label selector in
+// all namespaces, one page at a time.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns the metadata of all matching secrets or an error.
+func (s *SecretAPI) ListSecretsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]api.SecretMetadata, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Secret", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	list, err := s.list(ctx, metav1.NamespaceAll, opts, namespaces)
+	if err != nil {
+		return nil, api.NewResourceError("Secret", "", "", "failed to list secrets by label in all namespaces", err)
+	}
+
+	return list, nil
+}
+
+// ListSecretsByFieldAllNamespaces lists the metadata of secrets by field selector in
+// all namespaces, one page at a time.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns the metadata of all matching secrets or an error.
+func (s *SecretAPI) ListSecretsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]api.SecretMetadata, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Secret", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	list, err := s.list(ctx, metav1.NamespaceAll, opts, namespaces)
+	if err != nil {
+		return nil, api.NewResourceError("Secret", "", "", "failed to list secrets by field in all namespaces", err)
+	}
+
+	return list, nil
+}
+
+// ListSecretsByQuery lists the metadata of secrets by namespace and query. Unlike the
+// ByQuery methods of other APIs, secrets are requested in pages until query.Limit
+// secrets, or all of them, are fetched.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the metadata of the matching secrets, at most query.Limit when set, or an
+// error.
+func (s *SecretAPI) ListSecretsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]api.SecretMetadata, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Secret", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	list, err := s.list(ctx, namespace, opts, nil)
+	if err != nil {
+		return nil, api.NewResourceError("Secret", namespace, "", fmt.Sprintf("failed to list secrets by query in namespace %q", namespace), err)
+	}
+
+	return list, nil
+}
+
+// list returns the metadata of the secrets of namespace matched by opts and kept by
+// namespaces, at most opts.Limit when set. Secrets are requested in pages of at most
+// pageSize.
+func (s *SecretAPI) list(ctx context.Context, namespace string, opts metav1.ListOptions, namespaces *api.NamespaceFilter) ([]api.SecretMetadata, error) {
+	limit := opts.Limit
+	opts.Limit = pageSize
+	if limit > 0 && limit < pageSize {
+		opts.Limit = limit
+	}
+
+	var items []api.SecretMetadata
+	for item, err := range pager.Items(ctx, opts, s.page(namespace, namespaces)) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if int64(len(items)) == limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+// page returns the function fetching a page of the secrets of namespace, reduced to
+// the metadata of the ones kept by namespaces.
+func (s *SecretAPI) page(namespace string, namespaces *api.NamespaceFilter) pager.PageFunc[api.SecretMetadata] {
+	return func(ctx context.Context, opts metav1.ListOptions) ([]api.SecretMetadata, string, error) {
+		if opts.Continue != "" {
+			// The continue token carries the resource version of the first page
+			opts.ResourceVersion = ""
+			opts.ResourceVersionMatch = ""
+		}
+
+		req := reqlog.Request{Verb: "list", Resource: "secrets", Namespace: namespace, LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+		start := time.Now()
+		list, err := throttle.Do(ctx, s.limiter, func() (*metav1.PartialObjectMetadataList, error) {
+			if s.fetcher == nil {
+				return nil, errNoMetadataClient
+			}
+			return s.fetcher.list(ctx, namespace, opts)
+		})
+		if err != nil {
+			reqlog.Failed(ctx, s.logger, req, start, err)
+			return nil, "", err
+		}
+		reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+		items := make([]api.SecretMetadata, 0, len(list.Items))
+		for i := range list.Items {
+			items = append(items, metadataOf(&list.Items[i]))
+		}
+		return nsfilter.Keep(items, namespaces), list.Continue, nil
+	}
+}
+
+// metadataOf returns the metadata of a secret without the last-applied annotation.
+func metadataOf(obj *metav1.PartialObjectMetadata) api.SecretMetadata {
+	meta := *obj.ObjectMeta.DeepCopy()
+	delete(meta.Annotations, corev1.LastAppliedConfigAnnotation)
+
+	return api.SecretMetadata{ObjectMeta: meta}
+}
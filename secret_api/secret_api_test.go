@@ -0,0 +1,766 @@
+package secretapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/kaudit/api"
+)
+
+func TestNewSecretAPI(t *testing.T) {
+	secretAPI := NewSecretAPI(fake.NewClientset())
+	assert.NotNil(t, secretAPI)
+	// Fake clientsets have no REST client to request metadata with
+	assert.Nil(t, secretAPI.fetcher)
+
+	_, err := secretAPI.GetSecretByName(context.Background(), "default", "token")
+	require.ErrorIs(t, err, errNoMetadataClient)
+}
+
+func TestSecretAPI_GetSecretByName(t *testing.T) {
+	// Setup a secret in the test namespace
+	testSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "test-namespace",
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"password": []byte("hunter2"),
+		},
+	}
+
+	// Initialize secret API
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(newMetadataClient(testSecret)))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		secretName    string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:       "Successfully get secret",
+			namespace:  "test-namespace",
+			secretName: "test-secret",
+			wantErr:    false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			secretName:    "test-secret",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty secret name",
+			namespace:     "test-namespace",
+			secretName:    "",
+			wantErr:       true,
+			errorContains: "invalid secret name",
+		},
+		{
+			name:          "Secret not found",
+			namespace:     "test-namespace",
+			secretName:    "nonexistent-secret",
+			wantErr:       true,
+			errorContains: "failed to get secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			secret, err := secretAPI.GetSecretByName(ctx, tt.namespace, tt.secretName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, secret)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, secret)
+				assert.Equal(t, tt.secretName, secret.Name)
+				assert.Equal(t, tt.namespace, secret.Namespace)
+			}
+		})
+	}
+}
+
+func TestSecretAPI_ListSecretsByLabel(t *testing.T) {
+	// Setup test secrets
+	testSecrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-secret-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-secret-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-secret",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-secret",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+	}
+
+	// Initialize secret API
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(newMetadataClient(testSecrets...)))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List secrets by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-secret-1", "test-secret-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List secrets with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-secret-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			secrets, err := secretAPI.ListSecretsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, secrets)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, secrets, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(secrets))
+				for _, item := range secrets {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestSecretAPI_ListSecretsByField(t *testing.T) {
+	// Setup test secrets
+	testSecrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-secret-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-secret-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-secret",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-secret",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{
+				"password": []byte("hunter2"),
+			},
+		},
+	}
+
+	// Initialize secret API
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(newMetadataClient(testSecrets...)))
+
+	// The fake metadata client does not evaluate field selectors, so every secret
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List secrets by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-secret-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-secret-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			secrets, err := secretAPI.ListSecretsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, secrets)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, secrets, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestSecretAPI_ListSecretsAllNamespaces(t *testing.T) {
+	// Setup secrets with the same label in several namespaces
+	fakeClient := newMetadataClient(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(fakeClient))
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake metadata client does not evaluate field selectors, so listing by field
+	// returns every secret of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]api.SecretMetadata, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]api.SecretMetadata, error) {
+				return secretAPI.ListSecretsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]api.SecretMetadata, error) {
+				return secretAPI.ListSecretsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]api.SecretMetadata, error) {
+				return secretAPI.ListSecretsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]api.SecretMetadata, error) {
+				return secretAPI.ListSecretsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]api.SecretMetadata, error) {
+				return secretAPI.ListSecretsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestSecretAPI_ListSecretsByQuery(t *testing.T) {
+	// Setup secrets with different labels
+	fakeClient := newMetadataClient(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(fakeClient))
+
+	// The fake metadata client does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; TestSecretAPI_ListsInPages checks the options
+	// sent to the apiserver
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := secretAPI.ListSecretsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, tt.namespace, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestSecretAPI_StripsLastApplied(t *testing.T) {
+	// Setup a secret applied with kubectl, whose last-applied-configuration
+	// annotation embeds the secret data
+	testSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tls-secret",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"app": "test-app",
+			},
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"data":{"tls.key":"c2VjcmV0"}}`,
+				"owner":                            "team-a",
+			},
+		},
+	}
+
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(newMetadataClient(testSecret)))
+	ctx := context.Background()
+
+	assertMetadata := func(t *testing.T, secret api.SecretMetadata) {
+		t.Helper()
+		assert.Equal(t, "tls-secret", secret.Name)
+		assert.Equal(t, map[string]string{"owner": "team-a"}, secret.Annotations)
+	}
+
+	t.Run("GetSecretByName", func(t *testing.T) {
+		secret, err := secretAPI.GetSecretByName(ctx, "test-namespace", "tls-secret")
+		require.NoError(t, err)
+		assertMetadata(t, *secret)
+	})
+
+	t.Run("ListSecretsByLabelAllNamespaces", func(t *testing.T) {
+		secrets, err := secretAPI.ListSecretsByLabelAllNamespaces(ctx, "app=test-app", nil)
+		require.NoError(t, err)
+		require.Len(t, secrets, 1)
+		assertMetadata(t, secrets[0])
+	})
+}
+
+func TestSecretAPI_ListsInPages(t *testing.T) {
+	// Serve the secrets in pages of two, whatever the requested limit
+	names := []string{"s1", "s2", "s3"}
+	var requests []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query)
+		start := 0
+		if query.Get("continue") != "" {
+			start = 2
+		}
+		items := make([]string, 0, 2)
+		for _, name := range names[start:min(start+2, len(names))] {
+			items = append(items, fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"default"}}`, name))
+		}
+		next := ""
+		if start == 0 {
+			next = "page-2"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"kind":"PartialObjectMetadataList","apiVersion":"meta.k8s.io/v1","metadata":{"continue":%q},"items":[%s]}`,
+			next, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	secretAPI := NewSecretAPI(client)
+
+	t.Run("Every page", func(t *testing.T) {
+		requests = nil
+
+		query := api.ListQuery{
+			LabelSelector:        "app=web",
+			FieldSelector:        "metadata.namespace=default",
+			ResourceVersion:      "42",
+			ResourceVersionMatch: metav1.ResourceVersionMatchExact,
+		}
+		secrets, err := secretAPI.ListSecretsByQuery(context.Background(), "default", query)
+		require.NoError(t, err)
+		require.Len(t, secrets, 3)
+		assert.Equal(t, "s3", secrets[2].Name)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "app=web", requests[0].Get("labelSelector"))
+		assert.Equal(t, "metadata.namespace=default", requests[0].Get("fieldSelector"))
+		assert.Equal(t, strconv.Itoa(pageSize), requests[0].Get("limit"))
+		assert.Equal(t, "42", requests[0].Get("resourceVersion"))
+		// The continue token replaces the resource version
+		assert.Equal(t, "page-2", requests[1].Get("continue"))
+		assert.Empty(t, requests[1].Get("resourceVersion"))
+		assert.Empty(t, requests[1].Get("resourceVersionMatch"))
+	})
+
+	t.Run("Up to the limit", func(t *testing.T) {
+		requests = nil
+
+		secrets, err := secretAPI.ListSecretsByQuery(context.Background(), "default", api.ListQuery{Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, secrets, 2)
+
+		require.Len(t, requests, 1)
+		assert.Equal(t, "2", requests[0].Get("limit"))
+	})
+}
+
+func TestSecretAPI_RequestsMetadataOnly(t *testing.T) {
+	// Serve the metadata of a secret, or a full secret as a server ignoring the Accept
+	// header would
+	var accepts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		meta := `{"name":"token","namespace":"default","labels":{"app":"web"}}`
+		switch {
+		case r.URL.Path == "/api/v1/namespaces/default/secrets/missing":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		case r.URL.Path == "/api/v1/namespaces/default/secrets/legacy":
+			fmt.Fprintf(w, `{"kind":"Secret","apiVersion":"v1","metadata":%s,"data":{"token":"aHVudGVyMg=="}}`, meta)
+		case strings.Contains(r.Header.Get("Accept"), "as=PartialObjectMetadataList;"):
+			fmt.Fprintf(w, `{"kind":"PartialObjectMetadataList","apiVersion":"meta.k8s.io/v1","metadata":{},"items":[{"metadata":%s}]}`, meta)
+		default:
+			fmt.Fprintf(w, `{"kind":"PartialObjectMetadata","apiVersion":"meta.k8s.io/v1","metadata":%s}`, meta)
+		}
+	}))
+	defer server.Close()
+
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	secretAPI := NewSecretAPI(client)
+	ctx := context.Background()
+
+	secret, err := secretAPI.GetSecretByName(ctx, "default", "token")
+	require.NoError(t, err)
+	assert.Equal(t, "token", secret.Name)
+
+	secrets, err := secretAPI.ListSecretsByLabel(ctx, "default", "app=web")
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+	assert.Equal(t, map[string]string{"app": "web"}, secrets[0].Labels)
+
+	_, err = secretAPI.GetSecretByName(ctx, "default", "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+
+	assert.Equal(t, []string{acceptPartialObjectMetadata, acceptPartialObjectMetadataList, acceptPartialObjectMetadata}, accepts)
+
+	// A full Secret, from a server ignoring the Accept header, is not decoded
+	_, err = secretAPI.GetSecretByName(ctx, "default", "legacy")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `apiserver returned "Secret" instead`)
+}
+
+func TestSecretAPI_TypedErrors(t *testing.T) {
+	secretAPI := NewSecretAPI(fake.NewClientset(), WithMetadataClient(newMetadataClient()))
+
+	_, err := secretAPI.GetSecretByName(context.Background(), "default", "db-credentials")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	var resourceErr *api.ResourceError
+	require.ErrorAs(t, err, &resourceErr)
+	assert.Equal(t, "Secret", resourceErr.Kind)
+	assert.Equal(t, "default", resourceErr.Namespace)
+	assert.Equal(t, "db-credentials", resourceErr.Name)
+
+	_, err = secretAPI.ListSecretsByLabel(context.Background(), "default", "app in (")
+	require.ErrorIs(t, err, api.ErrValidation)
+	var validationErr *api.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Secret", validationErr.Kind)
+	assert.Equal(t, "labelSelector", validationErr.Field)
+}
+
+func TestSecretAPI_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	secretAPI := NewSecretAPI(fake.NewClientset(),
+		WithMetadataClient(newMetadataClient(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "default"}})),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+
+	_, err := secretAPI.GetSecretByName(context.Background(), "default", "token")
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=get resource=secrets namespace=default name=token duration=`)
+
+	// Each page of a listing is logged as a request
+	_, err = secretAPI.ListSecretsByLabelAllNamespaces(context.Background(), "app=db", nil)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `level=DEBUG msg="kubernetes request" verb=list resource=secrets labelSelector="app=db" duration=`)
+
+	_, err = secretAPI.GetSecretByName(context.Background(), "default", "missing")
+	require.ErrorIs(t, err, api.ErrNotFound)
+	assert.Contains(t, buf.String(), `level=WARN msg="kubernetes request failed" verb=get resource=secrets namespace=default name=missing duration=`)
+}
+
+// newMetadataClient returns a fake metadata client serving the metadata of secrets.
+func newMetadataClient(secrets ...*corev1.Secret) *metadatafake.FakeMetadataClient {
+	scheme := metadatafake.NewTestScheme()
+	scheme.AddKnownTypeWithName(corev1.SchemeGroupVersion.WithKind("Secret"), &metav1.PartialObjectMetadata{})
+	objs := make([]runtime.Object, 0, len(secrets))
+	for _, secret := range secrets {
+		objs = append(objs, &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: secret.ObjectMeta,
+		})
+	}
+	return metadatafake.NewSimpleMetadataClient(scheme, objs...)
+}
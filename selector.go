@@ -0,0 +1,177 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/kaudit/val"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// LabelSelectorBuilder builds a label selector from typed requirements, e.g.
+//
+//	selector, err := api.NewLabelSelector().
+//		Equals("app", "web").
+//		In("tier", "frontend", "edge").
+//		DoesNotExist("canary").
+//		Build()
+//
+// yields "app=web,!canary,tier in (edge,frontend)". An object matches the selector when
+// it meets every requirement; a builder without requirements matches every object.
+//
+// Keys and values are validated as the Kubernetes API validates them. The first invalid
+// requirement is reported by Selector and Build, the following ones are ignored.
+type LabelSelectorBuilder struct {
+	requirements labels.Requirements
+	err          error
+}
+
+// NewLabelSelector returns a LabelSelectorBuilder without requirements.
+func NewLabelSelector() *LabelSelectorBuilder {
+	return &LabelSelectorBuilder{}
+}
+
+// Equals requires the label key to be set to value.
+func (b *LabelSelectorBuilder) Equals(key, value string) *LabelSelectorBuilder {
+	return b.add(key, selection.Equals, value)
+}
+
+// NotEquals requires the label key not to be set to value. Objects without the label
+// match.
+func (b *LabelSelectorBuilder) NotEquals(key, value string) *LabelSelectorBuilder {
+	return b.add(key, selection.NotEquals, value)
+}
+
+// In requires the label key to be set to one of values.
+func (b *LabelSelectorBuilder) In(key string, values ...string) *LabelSelectorBuilder {
+	return b.add(key, selection.In, values...)
+}
+
+// NotIn requires the label key not to be set to any of values. Objects without the label
+// match.
+func (b *LabelSelectorBuilder) NotIn(key string, values ...string) *LabelSelectorBuilder {
+	return b.add(key, selection.NotIn, values...)
+}
+
+// Exists requires the label key to be set, to any value.
+func (b *LabelSelectorBuilder) Exists(key string) *LabelSelectorBuilder {
+	return b.add(key, selection.Exists)
+}
+
+// DoesNotExist requires the label key not to be set.
+func (b *LabelSelectorBuilder) DoesNotExist(key string) *LabelSelectorBuilder {
+	return b.add(key, selection.DoesNotExist)
+}
+
+// add appends the requirement on key, unless an earlier one was invalid.
+func (b *LabelSelectorBuilder) add(key string, op selection.Operator, values ...string) *LabelSelectorBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	r, err := labels.NewRequirement(key, op, values)
+	if err != nil {
+		b.err = NewValidationError("", "", "", "labelSelector", fmt.Sprintf("invalid requirement on label %q", key), err)
+		return b
+	}
+	b.requirements = append(b.requirements, *r)
+	return b
+}
+
+// Selector returns the labels.Selector of the requirements, e.g. to match objects
+// client-side.
+//
+// Returns a *ValidationError if a requirement is invalid.
+func (b *LabelSelectorBuilder) Selector() (labels.Selector, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return labels.NewSelector().Add(b.requirements...), nil
+}
+
+// Build returns the selector string of the requirements, as expected by the ByLabel
+// methods and ListQuery.LabelSelector.
+//
+// Returns a *ValidationError if a requirement is invalid.
+func (b *LabelSelectorBuilder) Build() (string, error) {
+	selector, err := b.Selector()
+	if err != nil {
+		return "", err
+	}
+
+	return selector.String(), nil
+}
+
+// FieldSelectorBuilder builds a field selector from typed requirements, e.g.
+//
+//	selector, err := api.NewFieldSelector().
+//		Equals("spec.nodeName", "node-1").
+//		NotEquals("status.phase", "Succeeded").
+//		Build()
+//
+// yields "spec.nodeName=node-1,status.phase!=Succeeded". An object matches the selector
+// when it meets every requirement; a builder without requirements matches every object.
+//
+// Which fields can be selected on depends on the resource kind, the apiserver rejects
+// the others. The first invalid requirement is reported by Selector and Build, the
+// following ones are ignored.
+type FieldSelectorBuilder struct {
+	selectors []fields.Selector
+	err       error
+}
+
+// NewFieldSelector returns a FieldSelectorBuilder without requirements.
+func NewFieldSelector() *FieldSelectorBuilder {
+	return &FieldSelectorBuilder{}
+}
+
+// Equals requires the field to be set to value.
+func (b *FieldSelectorBuilder) Equals(field, value string) *FieldSelectorBuilder {
+	return b.add(field, fields.OneTermEqualSelector(field, value))
+}
+
+// NotEquals requires the field not to be set to value.
+func (b *FieldSelectorBuilder) NotEquals(field, value string) *FieldSelectorBuilder {
+	return b.add(field, fields.OneTermNotEqualSelector(field, value))
+}
+
+// add appends the requirement on field, unless an earlier one was invalid.
+func (b *FieldSelectorBuilder) add(field string, selector fields.Selector) *FieldSelectorBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if err := val.ValidateWithTag(field, "required"); err != nil {
+		b.err = NewValidationError("", "", "", "fieldSelector", "invalid field name", err)
+		return b
+	}
+	b.selectors = append(b.selectors, selector)
+	return b
+}
+
+// Selector returns the fields.Selector of the requirements, e.g. to match objects
+// client-side.
+//
+// Returns a *ValidationError if a requirement is invalid.
+func (b *FieldSelectorBuilder) Selector() (fields.Selector, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return fields.AndSelectors(b.selectors...), nil
+}
+
+// Build returns the selector string of the requirements, as expected by the ByField
+// methods and ListQuery.FieldSelector.
+//
+// Returns a *ValidationError if a requirement is invalid.
+func (b *FieldSelectorBuilder) Build() (string, error) {
+	selector, err := b.Selector()
+	if err != nil {
+		return "", err
+	}
+
+	return selector.String(), nil
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestLabelSelectorBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *LabelSelectorBuilder
+		want    string
+	}{
+		{
+			name:    "No requirements",
+			builder: NewLabelSelector(),
+			want:    "",
+		},
+		{
+			name:    "Equality",
+			builder: NewLabelSelector().Equals("app", "web").NotEquals("tier", "db"),
+			want:    "app=web,tier!=db",
+		},
+		{
+			name:    "Set",
+			builder: NewLabelSelector().In("tier", "frontend", "edge").NotIn("env", "dev"),
+			want:    "env notin (dev),tier in (edge,frontend)",
+		},
+		{
+			name:    "Existence",
+			builder: NewLabelSelector().Exists("app").DoesNotExist("canary"),
+			want:    "app,!canary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := tt.builder.Build()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, selector)
+			// The result parses back to the same selector.
+			parsed, err := labels.Parse(selector)
+			require.NoError(t, err)
+			assert.Equal(t, selector, parsed.String())
+		})
+	}
+}
+
+func TestLabelSelectorBuilder_Selector(t *testing.T) {
+	selector, err := NewLabelSelector().Equals("app", "web").DoesNotExist("canary").Selector()
+	require.NoError(t, err)
+
+	assert.True(t, selector.Matches(labels.Set{"app": "web"}))
+	assert.False(t, selector.Matches(labels.Set{"app": "web", "canary": "true"}))
+	assert.False(t, selector.Matches(labels.Set{"app": "db"}))
+}
+
+func TestLabelSelectorBuilder_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *LabelSelectorBuilder
+		errMsg  string
+	}{
+		{
+			name:    "Invalid key",
+			builder: NewLabelSelector().Equals("app", "web").Exists("bad key"),
+			errMsg:  `invalid requirement on label "bad key"`,
+		},
+		{
+			name:    "Invalid value",
+			builder: NewLabelSelector().Equals("app", "web app"),
+			errMsg:  `invalid requirement on label "app"`,
+		},
+		{
+			name:    "Empty set",
+			builder: NewLabelSelector().In("tier"),
+			errMsg:  `invalid requirement on label "tier"`,
+		},
+		{
+			name:    "First error wins",
+			builder: NewLabelSelector().Equals("", "web").Equals("app", "web app"),
+			errMsg:  `invalid requirement on label ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := tt.builder.Build()
+
+			require.ErrorIs(t, err, ErrValidation)
+			assert.Empty(t, selector)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "labelSelector", validationErr.Field)
+		})
+	}
+}
+
+func TestFieldSelectorBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *FieldSelectorBuilder
+		want    string
+	}{
+		{
+			name:    "No requirements",
+			builder: NewFieldSelector(),
+			want:    "",
+		},
+		{
+			name:    "Equality",
+			builder: NewFieldSelector().Equals("spec.nodeName", "node-1").NotEquals("status.phase", "Succeeded"),
+			want:    "spec.nodeName=node-1,status.phase!=Succeeded",
+		},
+		{
+			name:    "Escaped value",
+			builder: NewFieldSelector().Equals("metadata.name", "a,b=c"),
+			want:    `metadata.name=a\,b\=c`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := tt.builder.Build()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, selector)
+			// The result parses back to the same selector.
+			parsed, err := fields.ParseSelector(selector)
+			require.NoError(t, err)
+			assert.Equal(t, selector, parsed.String())
+		})
+	}
+}
+
+func TestFieldSelectorBuilder_Selector(t *testing.T) {
+	selector, err := NewFieldSelector().Equals("spec.nodeName", "node-1").NotEquals("status.phase", "Succeeded").Selector()
+	require.NoError(t, err)
+
+	assert.True(t, selector.Matches(fields.Set{"spec.nodeName": "node-1", "status.phase": "Running"}))
+	assert.False(t, selector.Matches(fields.Set{"spec.nodeName": "node-1", "status.phase": "Succeeded"}))
+	assert.False(t, selector.Matches(fields.Set{"spec.nodeName": "node-2", "status.phase": "Running"}))
+}
+
+func TestFieldSelectorBuilder_Invalid(t *testing.T) {
+	selector, err := NewFieldSelector().Equals("spec.nodeName", "node-1").NotEquals("", "Succeeded").Build()
+
+	require.ErrorIs(t, err, ErrValidation)
+	assert.Empty(t, selector)
+	assert.Contains(t, err.Error(), "invalid field name")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "fieldSelector", validationErr.Field)
+}
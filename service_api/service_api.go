@@ -3,23 +3,71 @@ package serviceapi
 import (
 	"context"
 	"fmt"
+	"iter"
+	"log/slog"
+	"time"
 
 	"github.com/kaudit/val"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/pager"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/retry"
+	"github.com/kaudit/api/internal/throttle"
+	"github.com/kaudit/api/internal/watcher"
 )
 
 // ServiceAPI provides high-level methods for retrieving Kubernetes services.
 type ServiceAPI struct {
-	client kubernetes.Interface
+	client  kubernetes.Interface
+	retry   api.RetryPolicy
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a ServiceAPI.
+type Option func(*ServiceAPI)
+
+// WithRetryPolicy retries Get and List requests failing with a transient apiserver
+// error according to policy. By default requests are not retried.
+func WithRetryPolicy(policy api.RetryPolicy) Option {
+	return func(s *ServiceAPI) {
+		s.retry = policy
+	}
+}
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(s *ServiceAPI) {
+		s.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *ServiceAPI) {
+		s.logger = logger
+	}
 }
 
 // NewServiceAPI creates a new ServiceAPI instance using the provided client.
-func NewServiceAPI(client kubernetes.Interface) *ServiceAPI {
-	return &ServiceAPI{
+//
+// Options such as WithRetryPolicy, WithRateLimiter and WithLogger customize the instance.
+func NewServiceAPI(client kubernetes.Interface, opts ...Option) *ServiceAPI {
+	s := &ServiceAPI{
 		client: client,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetServiceByName retrieves a specific Service by namespace and name.
@@ -32,16 +80,25 @@ func NewServiceAPI(client kubernetes.Interface) *ServiceAPI {
 // Returns the matched *corev1.Service or an error if not found or invalid.
 func (s *ServiceAPI) GetServiceByName(ctx context.Context, namespace, name string) (*corev1.Service, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Service", namespace, name, "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(name, "required"); err != nil {
-		return nil, fmt.Errorf("invalid service name: %w", err)
+		return nil, api.NewValidationError("Service", namespace, name, "name", "invalid service name", err)
 	}
 
-	svc, err := s.client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	req := reqlog.Request{Verb: "get", Resource: "services", Namespace: namespace, Name: name}
+	start := time.Now()
+	svc, err := retry.Do(ctx, s.retry, func() (*corev1.Service, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.Service, error) {
+			return s.client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service %q in namespace %q: %w", name, namespace, err)
+		err = api.NewResourceError("Service", namespace, name, fmt.Sprintf("failed to get service %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
 
 	return svc, nil
 }
@@ -56,20 +113,29 @@ func (s *ServiceAPI) GetServiceByName(ctx context.Context, namespace, name strin
 // Returns all matching services or an error.
 func (s *ServiceAPI) ListServicesByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.Service, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+		return nil, api.NewValidationError("Service", namespace, "", "labelSelector", "invalid label selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		LabelSelector: labelSelector,
 	}
 
-	list, err := s.client.CoreV1().Services(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+			return s.client.CoreV1().Services(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list services by label in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
@@ -84,20 +150,276 @@ func (s *ServiceAPI) ListServicesByLabel(ctx context.Context, namespace string,
 // Returns all matching services or an error.
 func (s *ServiceAPI) ListServicesByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.Service, error) {
 	if err := val.ValidateWithTag(namespace, "required"); err != nil {
-		return nil, fmt.Errorf("invalid namespace: %w", err)
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
 	}
 	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
-		return nil, fmt.Errorf("invalid field selector: %w", err)
+		return nil, api.NewValidationError("Service", namespace, "", "fieldSelector", "invalid field selector", err)
 	}
 
 	opts := metav1.ListOptions{
 		FieldSelector: fieldSelector,
 	}
 
-	list, err := s.client.CoreV1().Services(namespace).List(ctx, opts)
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+			return s.client.CoreV1().Services(namespace).List(ctx, opts)
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list services by field in namespace %q: %w", namespace, err)
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
 	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
 
 	return list.Items, nil
 }
+
+// ListServicesByLabelAllNamespaces lists services by label selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching services or an error.
+func (s *ServiceAPI) ListServicesByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Service", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+			return s.client.CoreV1().Services(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Service", "", "", "failed to list services by label in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListServicesByFieldAllNamespaces lists services by field selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching services or an error.
+func (s *ServiceAPI) ListServicesByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.Service, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Service", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "services", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+			return s.client.CoreV1().Services(metav1.NamespaceAll).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Service", "", "", "failed to list services by field in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListServicesByQuery lists services by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching services, at most query.Limit when set, or an error.
+func (s *ServiceAPI) ListServicesByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.Service, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+		return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+			return s.client.CoreV1().Services(namespace).List(ctx, opts)
+		})
+	})
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListServicesByLabelPaged lists services by namespace and label selector, fetching them in pages.
+// An empty namespace lists the services of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - pageSize: Maximum number of services requested per page (must be positive).
+//
+// Returns an iterator over all matching services. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (s *ServiceAPI) ListServicesByLabelPaged(ctx context.Context, namespace string, labelSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "labelSelector", "invalid label selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, s.listPage(namespace, "label"))
+}
+
+// ListServicesByFieldPaged lists services by namespace and field selector, fetching them in pages.
+// An empty namespace lists the services of every namespace.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - pageSize: Maximum number of services requested per page (must be positive).
+//
+// Returns an iterator over all matching services. Validation and API errors are yielded
+// as the second value, after which iteration stops. An expired continue token is
+// reported as an error wrapping api.ErrContinueExpired.
+func (s *ServiceAPI) ListServicesByFieldPaged(ctx context.Context, namespace string, fieldSelector string, pageSize int64) iter.Seq2[corev1.Service, error] {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "fieldSelector", "invalid field selector", err))
+	}
+	if err := val.ValidateWithTag(pageSize, "gt=0"); err != nil {
+		return pager.Err[corev1.Service](api.NewValidationError("Service", namespace, "", "pageSize", "invalid page size", err))
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+		Limit:         pageSize,
+	}
+
+	return pager.Items(ctx, opts, s.listPage(namespace, "field"))
+}
+
+// WatchServicesByLabel watches services by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns a channel of typed service events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (s *ServiceAPI) WatchServicesByLabel(ctx context.Context, namespace string, labelSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "services", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Service](ctx, opts, throttle.Watch(s.limiter, s.client.CoreV1().Services(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to watch services by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 0)
+
+	return events, nil
+}
+
+// WatchServicesByField watches services by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation; the event channel is closed once it is done.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns a channel of typed service events or an error if the input is invalid or the
+// initial watch cannot be established. Dropped connections are re-established from
+// the last observed resourceVersion.
+func (s *ServiceAPI) WatchServicesByField(ctx context.Context, namespace string, fieldSelector string) (<-chan api.WatchEvent[*corev1.Service], error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("Service", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "watch", Resource: "services", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	events, err := watcher.Watch[*corev1.Service](ctx, opts, throttle.Watch(s.limiter, s.client.CoreV1().Services(namespace).Watch))
+	if err != nil {
+		err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to watch services by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 0)
+
+	return events, nil
+}
+
+// listPage returns a pager.PageFunc listing services in the given namespace.
+func (s *ServiceAPI) listPage(namespace, selectorKind string) pager.PageFunc[corev1.Service] {
+	return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Service, string, error) {
+		req := reqlog.Request{Verb: "list", Resource: "services", Namespace: namespace, LabelSelector: opts.LabelSelector, FieldSelector: opts.FieldSelector}
+		start := time.Now()
+		list, err := retry.Do(ctx, s.retry, func() (*corev1.ServiceList, error) {
+			return throttle.Do(ctx, s.limiter, func() (*corev1.ServiceList, error) {
+				return s.client.CoreV1().Services(namespace).List(ctx, opts)
+			})
+		})
+		if err != nil {
+			err = api.NewResourceError("Service", namespace, "", fmt.Sprintf("failed to list services by %s in namespace %q", selectorKind, namespace), err)
+			reqlog.Failed(ctx, s.logger, req, start, err)
+			return nil, "", err
+		}
+		reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+		return list.Items, list.Continue, nil
+	}
+}
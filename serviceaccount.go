@@ -0,0 +1,25 @@
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ServiceAccountUsage reports the pods running as a ServiceAccount.
+//
+// A ServiceAccount without pods is unused. Missing is set when pods reference a
+// ServiceAccount that does not exist; ServiceAccount then only carries its name and
+// namespace.
+type ServiceAccountUsage struct {
+	ServiceAccount corev1.ServiceAccount
+	Missing        bool
+	Pods           []PodIdentity
+}
+
+// PodIdentity is a pod together with the token-mount status of its ServiceAccount.
+type PodIdentity struct {
+	Pod corev1.Pod
+	// TokenMounted reports whether an API token is mounted into the pod. The pod's
+	// automountServiceAccountToken takes precedence over that of its ServiceAccount;
+	// tokens are mounted when neither sets it.
+	TokenMounted bool
+}
@@ -0,0 +1,346 @@
+package serviceaccountapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// ServiceAccountAPI provides high-level methods for retrieving Kubernetes service accounts.
+type ServiceAccountAPI struct {
+	client  kubernetes.Interface
+	pods    api.PodAPI
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a ServiceAccountAPI.
+type Option func(*ServiceAccountAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(s *ServiceAccountAPI) {
+		s.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *ServiceAccountAPI) {
+		s.logger = logger
+	}
+}
+
+// NewServiceAccountAPI creates a new ServiceAccountAPI instance using the provided client.
+//
+// The pods API is used to resolve the pods running as each service account, see
+// ListServiceAccountUsage.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewServiceAccountAPI(client kubernetes.Interface, pods api.PodAPI, opts ...Option) *ServiceAccountAPI {
+	s := &ServiceAccountAPI{
+		client: client,
+		pods:   pods,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetServiceAccountByName retrieves a specific ServiceAccount by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the service account (must be non-empty).
+//   - name: Name of the service account (must be non-empty).
+//
+// Returns the matched *corev1.ServiceAccount or an error if not found or invalid.
+func (s *ServiceAccountAPI) GetServiceAccountByName(ctx context.Context, namespace, name string) (*corev1.ServiceAccount, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, name, "name", "invalid service account name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "serviceaccounts", Namespace: namespace, Name: name}
+	start := time.Now()
+	sa, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccount, error) {
+		return s.client.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", namespace, name, fmt.Sprintf("failed to get service account %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+
+	return sa, nil
+}
+
+// ListServiceAccountsByLabel lists service accounts by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching service accounts or an error.
+func (s *ServiceAccountAPI) ListServiceAccountsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.ServiceAccount, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", namespace, "", fmt.Sprintf("failed to list service accounts by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListServiceAccountsByField lists service accounts by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching service accounts or an error.
+func (s *ServiceAccountAPI) ListServiceAccountsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.ServiceAccount, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", namespace, "", fmt.Sprintf("failed to list service accounts by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListServiceAccountsByLabelAllNamespaces lists service accounts by label selector in
+// all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching service accounts or an error.
+func (s *ServiceAccountAPI) ListServiceAccountsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.ServiceAccount, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", "", "", "failed to list service accounts by label in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListServiceAccountsByFieldAllNamespaces lists service accounts by field selector in
+// all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching service accounts or an error.
+func (s *ServiceAccountAPI) ListServiceAccountsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.ServiceAccount, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", "", "", "failed to list service accounts by field in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListServiceAccountsByQuery lists service accounts by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns all matching service accounts or an error.
+func (s *ServiceAccountAPI) ListServiceAccountsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.ServiceAccount, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", namespace, "", fmt.Sprintf("failed to list service accounts by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListServiceAccountUsage joins the service accounts of a namespace with the pods
+// running as them.
+//
+// Every service account of the namespace is reported, including unused ones, together
+// with the token-mount status of each of its pods. Service accounts that pods refer to
+// but that do not exist are reported with Missing set. Pods are listed through the
+// PodAPI the ServiceAccountAPI was created with.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope (must be non-empty).
+//
+// Returns one api.ServiceAccountUsage per service account, ordered by name, or an error.
+func (s *ServiceAccountAPI) ListServiceAccountUsage(ctx context.Context, namespace string) ([]api.ServiceAccountUsage, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("ServiceAccount", namespace, "", "namespace", "invalid namespace", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "serviceaccounts", Namespace: namespace}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.ServiceAccountList, error) {
+		return s.client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("ServiceAccount", namespace, "", fmt.Sprintf("failed to list service account usage in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+	pods, err := s.pods.ListPodsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return nil, api.NewResourceError("ServiceAccount", namespace, "", fmt.Sprintf("failed to list service account usage in namespace %q", namespace), err)
+	}
+
+	usage := make(map[string]*api.ServiceAccountUsage, len(list.Items))
+	for _, sa := range list.Items {
+		usage[sa.Name] = &api.ServiceAccountUsage{ServiceAccount: sa}
+	}
+
+	for _, pod := range pods {
+		name := pod.Spec.ServiceAccountName
+		if name == "" {
+			name = "default"
+		}
+
+		u, ok := usage[name]
+		if !ok {
+			u = &api.ServiceAccountUsage{
+				ServiceAccount: corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				},
+				Missing: true,
+			}
+			usage[name] = u
+		}
+
+		u.Pods = append(u.Pods, api.PodIdentity{
+			Pod:          pod,
+			TokenMounted: tokenMounted(&pod, &u.ServiceAccount),
+		})
+	}
+
+	result := make([]api.ServiceAccountUsage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ServiceAccount.Name < result[j].ServiceAccount.Name
+	})
+
+	return result, nil
+}
+
+// tokenMounted reports whether an API token is mounted into pod, following the
+// precedence the service account admission plugin applies.
+func tokenMounted(pod *corev1.Pod, sa *corev1.ServiceAccount) bool {
+	if pod.Spec.AutomountServiceAccountToken != nil {
+		return *pod.Spec.AutomountServiceAccountToken
+	}
+	if sa.AutomountServiceAccountToken != nil {
+		return *sa.AutomountServiceAccountToken
+	}
+	return true
+}
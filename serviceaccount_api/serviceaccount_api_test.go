@@ -0,0 +1,599 @@
+package serviceaccountapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/pod_api"
+)
+
+func TestNewServiceAccountAPI(t *testing.T) {
+	client := fake.NewClientset()
+	saAPI := NewServiceAccountAPI(client, podapi.NewPodAPI(client))
+	assert.NotNil(t, saAPI)
+	assert.Equal(t, client, saAPI.client)
+}
+
+func TestServiceAccountAPI_GetServiceAccountByName(t *testing.T) {
+	// Setup a service account in the test namespace
+	testServiceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-serviceaccount",
+			Namespace: "test-namespace",
+		},
+	}
+
+	// Create fake clientset with test service account
+	fakeClient := fake.NewClientset(testServiceAccount)
+
+	// Initialize service account API
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		saName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Successfully get service account",
+			namespace: "test-namespace",
+			saName:    "test-serviceaccount",
+			wantErr:   false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			saName:        "test-serviceaccount",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty service account name",
+			namespace:     "test-namespace",
+			saName:        "",
+			wantErr:       true,
+			errorContains: "invalid service account name",
+		},
+		{
+			name:          "ServiceAccount not found",
+			namespace:     "test-namespace",
+			saName:        "nonexistent-serviceaccount",
+			wantErr:       true,
+			errorContains: "failed to get service account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			sa, err := saAPI.GetServiceAccountByName(ctx, tt.namespace, tt.saName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, sa)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, sa)
+				assert.Equal(t, tt.saName, sa.Name)
+				assert.Equal(t, tt.namespace, sa.Namespace)
+			}
+		})
+	}
+}
+
+func TestServiceAccountAPI_ListServiceAccountsByLabel(t *testing.T) {
+	// Setup test service accounts
+	testServiceAccounts := []*corev1.ServiceAccount{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-serviceaccount-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-serviceaccount-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-serviceaccount",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-serviceaccount",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testServiceAccounts {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize service account API
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		namespace     string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List service accounts by app label",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-serviceaccount-1", "test-serviceaccount-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List service accounts with multiple labels",
+			namespace:     "test-namespace",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-serviceaccount-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			namespace:     "test-namespace",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			labelSelector: "app=test-app",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty label selector",
+			namespace:     "test-namespace",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "test-namespace",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serviceAccounts, err := saAPI.ListServiceAccountsByLabel(ctx, tt.namespace, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, serviceAccounts)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, serviceAccounts, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(serviceAccounts))
+				for _, item := range serviceAccounts {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestServiceAccountAPI_ListServiceAccountsByField(t *testing.T) {
+	// Setup test service accounts
+	testServiceAccounts := []*corev1.ServiceAccount{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-serviceaccount-1",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-serviceaccount-2",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "other-serviceaccount",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foreign-serviceaccount",
+				Namespace: "other-namespace",
+				Labels: map[string]string{
+					"app": "test-app",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testServiceAccounts {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize service account API
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors, so every service account
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		namespace     string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List service accounts by field",
+			namespace:     "test-namespace",
+			fieldSelector: "metadata.name=test-serviceaccount-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			fieldSelector: "metadata.name=test-serviceaccount-1",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty field selector",
+			namespace:     "test-namespace",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			namespace:     "test-namespace",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			serviceAccounts, err := saAPI.ListServiceAccountsByField(ctx, tt.namespace, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, serviceAccounts)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, serviceAccounts, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestServiceAccountAPI_ListServiceAccountsAllNamespaces(t *testing.T) {
+	// Setup service accounts with the same label in several namespaces
+	fakeClient := fake.NewClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "kube-system", Labels: map[string]string{"app": "web"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: map[string]string{"app": "web"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "team-b", Labels: map[string]string{"app": "db"}}},
+	)
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	excludeSystem, err := api.ExcludeNamespaces("kube-*")
+	require.NoError(t, err)
+	onlyTeams, err := api.NewNamespaceFilter([]string{"/^team-/"}, nil)
+	require.NoError(t, err)
+
+	// The fake clientset does not evaluate field selectors, so listing by field
+	// returns every service account of the selected namespaces
+	tests := []struct {
+		name               string
+		list               func(ctx context.Context) ([]corev1.ServiceAccount, error)
+		expectedNamespaces []string
+		wantErr            bool
+		errorContains      string
+	}{
+		{
+			name: "By label in every namespace",
+			list: func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+				return saAPI.ListServiceAccountsByLabelAllNamespaces(ctx, "app=web", nil)
+			},
+			expectedNamespaces: []string{"default", "kube-system", "team-a"},
+		},
+		{
+			name: "By label excluding system namespaces",
+			list: func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+				return saAPI.ListServiceAccountsByLabelAllNamespaces(ctx, "app=web", excludeSystem)
+			},
+			expectedNamespaces: []string{"default", "team-a"},
+		},
+		{
+			name: "By field in team namespaces",
+			list: func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+				return saAPI.ListServiceAccountsByFieldAllNamespaces(ctx, "metadata.name=web", onlyTeams)
+			},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name: "Invalid label selector format",
+			list: func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+				return saAPI.ListServiceAccountsByLabelAllNamespaces(ctx, "invalid@label", nil)
+			},
+			wantErr:       true,
+			errorContains: "invalid label selector",
+		},
+		{
+			name: "Empty field selector",
+			list: func(ctx context.Context) ([]corev1.ServiceAccount, error) {
+				return saAPI.ListServiceAccountsByFieldAllNamespaces(ctx, "", excludeSystem)
+			},
+			wantErr:       true,
+			errorContains: "invalid field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := tt.list(context.Background())
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			namespaces := make([]string, 0, len(items))
+			for _, item := range items {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			assert.ElementsMatch(t, tt.expectedNamespaces, namespaces)
+
+			// Every namespace is listed with a single request
+			require.Len(t, fakeClient.Actions(), 1)
+			assert.Equal(t, metav1.NamespaceAll, fakeClient.Actions()[0].GetNamespace())
+		})
+	}
+}
+
+func TestServiceAccountAPI_ListServiceAccountsByQuery(t *testing.T) {
+	// Setup service accounts with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+	)
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		namespace     string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:      "List by label and field selectors with limit and resource version",
+			namespace: "default",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			namespace:     "default",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "All namespaces",
+			namespace:     "",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name:          "Invalid label selector format",
+			namespace:     "default",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			namespace:     "default",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			namespace:     "default",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := saAPI.ListServiceAccountsByQuery(context.Background(), tt.namespace, tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+			assert.Equal(t, tt.namespace, listAction.GetNamespace())
+		})
+	}
+}
+
+func TestServiceAccountAPI_ListServiceAccountUsage(t *testing.T) {
+	// Setup service accounts and the pods running as them
+	disabled := false
+	enabled := true
+	pod := func(name, namespace, serviceAccount string, automount *bool) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName:           serviceAccount,
+				AutomountServiceAccountToken: automount,
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "test-namespace"},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta:                   metav1.ObjectMeta{Name: "api", Namespace: "test-namespace"},
+			AutomountServiceAccountToken: &disabled,
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "test-namespace"},
+		},
+		pod("web", "test-namespace", "", nil),
+		pod("api-1", "test-namespace", "api", nil),
+		pod("api-2", "test-namespace", "api", &enabled),
+		pod("batch", "test-namespace", "deleted", &disabled),
+		pod("foreign", "other-namespace", "api", nil),
+	)
+
+	// Initialize service account API
+	saAPI := NewServiceAccountAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	t.Run("Usage per service account", func(t *testing.T) {
+		usage, err := saAPI.ListServiceAccountUsage(context.Background(), "test-namespace")
+		require.NoError(t, err)
+
+		// Summarize as service account -> pod -> token mounted
+		got := make(map[string]map[string]bool, len(usage))
+		missing := make(map[string]bool, len(usage))
+		names := make([]string, 0, len(usage))
+		for _, u := range usage {
+			names = append(names, u.ServiceAccount.Name)
+			missing[u.ServiceAccount.Name] = u.Missing
+			got[u.ServiceAccount.Name] = make(map[string]bool, len(u.Pods))
+			for _, p := range u.Pods {
+				got[u.ServiceAccount.Name][p.Pod.Name] = p.TokenMounted
+			}
+		}
+
+		assert.Equal(t, []string{"api", "default", "deleted", "unused"}, names)
+		assert.Equal(t, map[string]map[string]bool{
+			"api":     {"api-1": false, "api-2": true},
+			"default": {"web": true},
+			"deleted": {"batch": false},
+			"unused":  {},
+		}, got)
+		assert.Equal(t, map[string]bool{"api": false, "default": false, "deleted": true, "unused": false}, missing)
+	})
+
+	t.Run("Empty namespace", func(t *testing.T) {
+		_, err := saAPI.ListServiceAccountUsage(context.Background(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+	})
+}
@@ -0,0 +1,255 @@
+package statefulsetapi
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kaudit/val"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// StatefulSetAPI provides high-level methods for retrieving Kubernetes statefulsets.
+type StatefulSetAPI struct {
+	client  kubernetes.Interface
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a StatefulSetAPI.
+type Option func(*StatefulSetAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(s *StatefulSetAPI) {
+		s.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *StatefulSetAPI) {
+		s.logger = logger
+	}
+}
+
+// NewStatefulSetAPI creates a new StatefulSetAPI instance using the provided client.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewStatefulSetAPI(client kubernetes.Interface, opts ...Option) *StatefulSetAPI {
+	s := &StatefulSetAPI{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetStatefulSetByName retrieves a specific StatefulSet by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the statefulset (must be non-empty).
+//   - name: Name of the statefulset (must be non-empty).
+//
+// Returns the matched *appsv1.StatefulSet or an error if not found or invalid.
+func (s *StatefulSetAPI) GetStatefulSetByName(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, name, "name", "invalid statefulset name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "statefulsets", Namespace: namespace, Name: name}
+	start := time.Now()
+	sts, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSet, error) {
+		return s.client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", namespace, name, fmt.Sprintf("failed to get statefulset %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+
+	return sts, nil
+}
+
+// ListStatefulSetsByLabel lists statefulsets by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching statefulsets or an error.
+func (s *StatefulSetAPI) ListStatefulSetsByLabel(ctx context.Context, namespace string, labelSelector string) ([]appsv1.StatefulSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "statefulsets", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSetList, error) {
+		return s.client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", namespace, "", fmt.Sprintf("failed to list statefulsets by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListStatefulSetsByField lists statefulsets by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching statefulsets or an error.
+func (s *StatefulSetAPI) ListStatefulSetsByField(ctx context.Context, namespace string, fieldSelector string) ([]appsv1.StatefulSet, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "statefulsets", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSetList, error) {
+		return s.client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", namespace, "", fmt.Sprintf("failed to list statefulsets by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListStatefulSetsByLabelAllNamespaces lists statefulsets by label selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching statefulsets or an error.
+func (s *StatefulSetAPI) ListStatefulSetsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]appsv1.StatefulSet, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "statefulsets", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSetList, error) {
+		return s.client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", "", "", "failed to list statefulsets by label in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListStatefulSetsByFieldAllNamespaces lists statefulsets by field selector in all
+// namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching statefulsets or an error.
+func (s *StatefulSetAPI) ListStatefulSetsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]appsv1.StatefulSet, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("StatefulSet", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "statefulsets", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSetList, error) {
+		return s.client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", "", "", "failed to list statefulsets by field in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListStatefulSetsByQuery lists statefulsets by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching statefulsets, at most query.Limit when set, or an error.
+func (s *StatefulSetAPI) ListStatefulSetsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]appsv1.StatefulSet, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("StatefulSet", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "statefulsets", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*appsv1.StatefulSetList, error) {
+		return s.client.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StatefulSet", namespace, "", fmt.Sprintf("failed to list statefulsets by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,16 @@
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ClaimBinding joins a PersistentVolumeClaim with the PersistentVolume bound to it
+// and the Pods mounting it.
+//
+// Volume is nil when the claim is not bound or its volume no longer exists. A claim
+// without Pods is not in use by any Pod.
+type ClaimBinding struct {
+	Claim  corev1.PersistentVolumeClaim
+	Volume *corev1.PersistentVolume
+	Pods   []corev1.Pod
+}
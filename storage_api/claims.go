@@ -0,0 +1,171 @@
+package storageapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetVolumeForClaim retrieves the PersistentVolume bound to a PersistentVolumeClaim.
+//
+// The binding is verified in both directions: the claim must name the volume and the
+// volume's claimRef must point back at the claim.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the claim (must be non-empty).
+//   - name: Name of the claim (must be non-empty).
+//
+// Returns the bound *corev1.PersistentVolume or an error, matching api.ErrNotFound if the
+// claim is not bound.
+func (s *StorageAPI) GetVolumeForClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolume, error) {
+	pvc, err := s.GetPersistentVolumeClaimByName(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if pvc.Spec.VolumeName == "" {
+		// The claim exists but has no volume to return
+		return nil, api.NewResourceError("PersistentVolumeClaim", namespace, name, fmt.Sprintf("persistentvolumeclaim %q in namespace %q is not bound", name, namespace), api.ErrNotFound)
+	}
+
+	pv, err := s.GetPersistentVolumeByName(ctx, pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, err
+	}
+	if !boundTo(pv, pvc) {
+		return nil, api.NewResourceError("PersistentVolumeClaim", namespace, name, fmt.Sprintf("persistentvolume %q is not bound to persistentvolumeclaim %q in namespace %q", pv.Name, name, namespace), api.ErrNotFound)
+	}
+
+	return pv, nil
+}
+
+// ListPodsForClaim lists the pods mounting a PersistentVolumeClaim, either directly or
+// through a generic ephemeral volume whose claim it is.
+//
+// Pods are listed through the PodAPI the StorageAPI was created with.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the claim (must be non-empty).
+//   - name: Name of the claim (must be non-empty).
+//
+// Returns the pods mounting the claim or an error.
+func (s *StorageAPI) ListPodsForClaim(ctx context.Context, namespace, name string) ([]corev1.Pod, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, name, "name", "invalid persistentvolumeclaim name", err)
+	}
+
+	pods, err := s.pods.ListPodsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return nil, api.NewResourceError("PersistentVolumeClaim", namespace, name, fmt.Sprintf("failed to list pods for persistentvolumeclaim %q in namespace %q", name, namespace), err)
+	}
+
+	return podsByClaim(pods)[name], nil
+}
+
+// ListClaimBindings joins every PersistentVolumeClaim of a namespace with its bound
+// PersistentVolume and the pods mounting it.
+//
+// Claims that are unbound, or whose volume does not point back at them, are reported
+// with a nil Volume. Claims without pods are not mounted by any pod.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope (must be non-empty).
+//
+// Returns one api.ClaimBinding per claim or an error.
+func (s *StorageAPI) ListClaimBindings(ctx context.Context, namespace string) ([]api.ClaimBinding, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "namespace", "invalid namespace", err)
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", Namespace: namespace}
+	start := time.Now()
+	claims, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list claim bindings in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(claims.Items))
+	req = reqlog.Request{Verb: "list", Resource: "persistentvolumes"}
+	start = time.Now()
+	volumes, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeList, error) {
+		return s.client.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list claim bindings in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(volumes.Items))
+	pods, err := s.pods.ListPodsByQuery(ctx, namespace, api.ListQuery{})
+	if err != nil {
+		return nil, api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list claim bindings in namespace %q", namespace), err)
+	}
+
+	volumesByName := make(map[string]*corev1.PersistentVolume, len(volumes.Items))
+	for i := range volumes.Items {
+		volumesByName[volumes.Items[i].Name] = &volumes.Items[i]
+	}
+	mounts := podsByClaim(pods)
+
+	bindings := make([]api.ClaimBinding, 0, len(claims.Items))
+	for _, pvc := range claims.Items {
+		binding := api.ClaimBinding{Claim: pvc, Pods: mounts[pvc.Name]}
+		if pv, ok := volumesByName[pvc.Spec.VolumeName]; ok && boundTo(pv, &pvc) {
+			binding.Volume = pv
+		}
+		bindings = append(bindings, binding)
+	}
+
+	return bindings, nil
+}
+
+// boundTo reports whether the claimRef of pv points at pvc. The UID is only compared
+// when both sides carry one.
+func boundTo(pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) bool {
+	ref := pv.Spec.ClaimRef
+	if ref == nil || ref.Namespace != pvc.Namespace || ref.Name != pvc.Name {
+		return false
+	}
+	return ref.UID == "" || pvc.UID == "" || ref.UID == pvc.UID
+}
+
+// podsByClaim groups pods by the names of the claims they mount. The claim of a
+// generic ephemeral volume is named after the pod and the volume.
+func podsByClaim(pods []corev1.Pod) map[string][]corev1.Pod {
+	mounts := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		seen := make(map[string]bool, len(pod.Spec.Volumes))
+		for _, volume := range pod.Spec.Volumes {
+			var claim string
+			switch {
+			case volume.PersistentVolumeClaim != nil:
+				claim = volume.PersistentVolumeClaim.ClaimName
+			case volume.Ephemeral != nil:
+				claim = pod.Name + "-" + volume.Name
+			default:
+				continue
+			}
+			if !seen[claim] {
+				seen[claim] = true
+				mounts[claim] = append(mounts[claim], pod)
+			}
+		}
+	}
+	return mounts
+}
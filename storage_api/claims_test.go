@@ -0,0 +1,251 @@
+package storageapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/pod_api"
+)
+
+// claimFixture returns claims in "test-namespace":
+//   - "data" is bound to "pv-data" and mounted by "db-0" and "backup"
+//   - "scratch" is bound to "pv-scratch" and mounted by no pod
+//   - "pending" is not bound
+//   - "stolen" names "pv-other", which is claimed by another namespace
+//   - "cache-tmp" is the claim of the generic ephemeral volume "tmp" of pod "cache"
+func claimFixture() []runtime.Object {
+	claim := func(name, volume string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volume},
+		}
+	}
+	volume := func(name, namespace, claim string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef: &corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: namespace, Name: claim},
+			},
+		}
+	}
+	pod := func(name string, volumes ...corev1.Volume) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+			Spec:       corev1.PodSpec{Volumes: volumes},
+		}
+	}
+	claimVolume := func(name, claim string) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		}
+	}
+
+	return []runtime.Object{
+		claim("data", "pv-data"),
+		claim("scratch", "pv-scratch"),
+		claim("pending", ""),
+		claim("stolen", "pv-other"),
+		claim("cache-tmp", "pv-cache"),
+		volume("pv-data", "test-namespace", "data"),
+		volume("pv-scratch", "test-namespace", "scratch"),
+		volume("pv-other", "other-namespace", "stolen"),
+		volume("pv-cache", "test-namespace", "cache-tmp"),
+		pod("db-0", claimVolume("data", "data"), claimVolume("data-again", "data")),
+		pod("backup", claimVolume("source", "data")),
+		pod("cache", corev1.Volume{
+			Name:         "tmp",
+			VolumeSource: corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}},
+		}),
+		pod("web", corev1.Volume{
+			Name:         "config",
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}},
+		}),
+	}
+}
+
+func TestStorageAPI_GetVolumeForClaim(t *testing.T) {
+	fakeClient := fake.NewClientset(claimFixture()...)
+	storageAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	tests := []struct {
+		name          string
+		namespace     string
+		claim         string
+		expected      string
+		wantErr       bool
+		errorContains string
+		category      error
+	}{
+		{
+			name:      "Bound claim",
+			namespace: "test-namespace",
+			claim:     "data",
+			expected:  "pv-data",
+		},
+		{
+			name:          "Unbound claim",
+			namespace:     "test-namespace",
+			claim:         "pending",
+			wantErr:       true,
+			errorContains: "is not bound",
+			category:      api.ErrNotFound,
+		},
+		{
+			name:          "Volume claimed by another claim",
+			namespace:     "test-namespace",
+			claim:         "stolen",
+			wantErr:       true,
+			errorContains: `persistentvolume "pv-other" is not bound`,
+			category:      api.ErrNotFound,
+		},
+		{
+			name:          "Missing claim",
+			namespace:     "test-namespace",
+			claim:         "missing",
+			wantErr:       true,
+			errorContains: "failed to get persistentvolumeclaim",
+			category:      api.ErrNotFound,
+		},
+		{
+			name:          "Empty name",
+			namespace:     "test-namespace",
+			claim:         "",
+			wantErr:       true,
+			errorContains: "invalid persistentvolumeclaim name",
+			category:      api.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv, err := storageAPI.GetVolumeForClaim(context.Background(), tt.namespace, tt.claim)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, tt.category)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pv.Name)
+		})
+	}
+}
+
+func TestStorageAPI_ListPodsForClaim(t *testing.T) {
+	fakeClient := fake.NewClientset(claimFixture()...)
+	storageAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	tests := []struct {
+		name          string
+		namespace     string
+		claim         string
+		expected      []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:      "Claim mounted twice by one pod",
+			namespace: "test-namespace",
+			claim:     "data",
+			expected:  []string{"backup", "db-0"},
+		},
+		{
+			name:      "Ephemeral volume claim",
+			namespace: "test-namespace",
+			claim:     "cache-tmp",
+			expected:  []string{"cache"},
+		},
+		{
+			name:      "Unused claim",
+			namespace: "test-namespace",
+			claim:     "scratch",
+			expected:  []string{},
+		},
+		{
+			name:          "Empty namespace",
+			namespace:     "",
+			claim:         "data",
+			wantErr:       true,
+			errorContains: "invalid namespace",
+		},
+		{
+			name:          "Empty name",
+			namespace:     "test-namespace",
+			claim:         "",
+			wantErr:       true,
+			errorContains: "invalid persistentvolumeclaim name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods, err := storageAPI.ListPodsForClaim(context.Background(), tt.namespace, tt.claim)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(pods))
+			for _, pod := range pods {
+				names = append(names, pod.Name)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestStorageAPI_ListClaimBindings(t *testing.T) {
+	fakeClient := fake.NewClientset(claimFixture()...)
+	storageAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	t.Run("Binding per claim", func(t *testing.T) {
+		bindings, err := storageAPI.ListClaimBindings(context.Background(), "test-namespace")
+		require.NoError(t, err)
+
+		// Summarize as claim -> volume and claim -> pods
+		volumes := make(map[string]string, len(bindings))
+		pods := make(map[string][]string, len(bindings))
+		for _, b := range bindings {
+			if b.Volume != nil {
+				volumes[b.Claim.Name] = b.Volume.Name
+			}
+			pods[b.Claim.Name] = []string{}
+			for _, p := range b.Pods {
+				pods[b.Claim.Name] = append(pods[b.Claim.Name], p.Name)
+			}
+		}
+
+		assert.Equal(t, map[string]string{
+			"data":      "pv-data",
+			"scratch":   "pv-scratch",
+			"cache-tmp": "pv-cache",
+		}, volumes)
+		assert.ElementsMatch(t, []string{"backup", "db-0"}, pods["data"])
+		assert.Equal(t, []string{"cache"}, pods["cache-tmp"])
+		assert.Empty(t, pods["scratch"])
+		assert.Empty(t, pods["pending"])
+		assert.Empty(t, pods["stolen"])
+		assert.Len(t, pods, 5)
+	})
+
+	t.Run("Empty namespace", func(t *testing.T) {
+		_, err := storageAPI.ListClaimBindings(context.Background(), "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid namespace")
+	})
+}
@@ -0,0 +1,151 @@
+package storageapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetPersistentVolumeByName retrieves a single PersistentVolume object by its name.
+//
+// The name parameter is validated to ensure it is not empty.
+// If the validation fails or if the retrieval from the Kubernetes API fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the cluster-scoped persistentvolume to retrieve.
+//
+// Returns a pointer to a corev1.PersistentVolume object or an error if the persistentvolume
+// is not found or if any other retrieval error occurs.
+func (s *StorageAPI) GetPersistentVolumeByName(ctx context.Context, name string) (*corev1.PersistentVolume, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("PersistentVolume", "", name, "name", "failed to validate persistentvolume name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "persistentvolumes", Name: name}
+	start := time.Now()
+	pv, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolume, error) {
+		return s.client.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolume", "", name, fmt.Sprintf("failed to get persistentvolume %q", name), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+	return pv, nil
+}
+
+// ListPersistentVolumesByLabel retrieves a list of PersistentVolume objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of corev1.PersistentVolume objects matching the label selector, or
+// an error if the operation fails.
+func (s *StorageAPI) ListPersistentVolumesByLabel(ctx context.Context, labelSelector string) ([]corev1.PersistentVolume, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("PersistentVolume", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumes", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeList, error) {
+		return s.client.CoreV1().PersistentVolumes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolume", "", "", fmt.Sprintf("failed to list persistentvolumes by label %q", labelSelector), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPersistentVolumesByField retrieves a list of PersistentVolume objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of corev1.PersistentVolume objects matching the field selector, or
+// an error if the operation fails.
+func (s *StorageAPI) ListPersistentVolumesByField(ctx context.Context, fieldSelector string) ([]corev1.PersistentVolume, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("PersistentVolume", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumes", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeList, error) {
+		return s.client.CoreV1().PersistentVolumes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolume", "", "", fmt.Sprintf("failed to list persistentvolumes by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPersistentVolumesByQuery retrieves a list of PersistentVolume objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of corev1.PersistentVolume objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (s *StorageAPI) ListPersistentVolumesByQuery(ctx context.Context, query api.ListQuery) ([]corev1.PersistentVolume, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("PersistentVolume", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumes", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeList, error) {
+		return s.client.CoreV1().PersistentVolumes().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolume", "", "", "failed to list persistentvolumes by query", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,214 @@
+package storageapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/nsfilter"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetPersistentVolumeClaimByName retrieves a specific PersistentVolumeClaim by namespace and name.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace of the persistentvolumeclaim (must be non-empty).
+//   - name: Name of the persistentvolumeclaim (must be non-empty).
+//
+// Returns the matched *corev1.PersistentVolumeClaim or an error if not found or invalid.
+func (s *StorageAPI) GetPersistentVolumeClaimByName(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, name, "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(name, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, name, "name", "invalid persistentvolumeclaim name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "persistentvolumeclaims", Namespace: namespace, Name: name}
+	start := time.Now()
+	pvc, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaim, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, name, fmt.Sprintf("failed to get persistentvolumeclaim %q in namespace %q", name, namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+
+	return pvc, nil
+}
+
+// ListPersistentVolumeClaimsByLabel lists persistentvolumeclaims by namespace and label selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - labelSelector: Kubernetes label selector syntax.
+//
+// Returns all matching persistentvolumeclaims or an error.
+func (s *StorageAPI) ListPersistentVolumeClaimsByLabel(ctx context.Context, namespace string, labelSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", Namespace: namespace, LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list persistentvolumeclaims by label in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPersistentVolumeClaimsByField lists persistentvolumeclaims by namespace and field selector.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope.
+//   - fieldSelector: Kubernetes field selector syntax.
+//
+// Returns all matching persistentvolumeclaims or an error.
+func (s *StorageAPI) ListPersistentVolumeClaimsByField(ctx context.Context, namespace string, fieldSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateWithTag(namespace, "required"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "namespace", "invalid namespace", err)
+	}
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", Namespace: namespace, FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list persistentvolumeclaims by field in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListPersistentVolumeClaimsByLabelAllNamespaces lists persistentvolumeclaims by label
+// selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - labelSelector: Kubernetes label selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching persistentvolumeclaims or an error.
+func (s *StorageAPI) ListPersistentVolumeClaimsByLabelAllNamespaces(ctx context.Context, labelSelector string, namespaces *api.NamespaceFilter) ([]corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", "", "", "labelSelector", "invalid label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", "", "", "failed to list persistentvolumeclaims by label in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListPersistentVolumeClaimsByFieldAllNamespaces lists persistentvolumeclaims by field
+// selector in all namespaces.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - fieldSelector: Kubernetes field selector syntax.
+//   - namespaces: Namespaces to keep; see api.NamespaceFilter.
+//
+// Returns all matching persistentvolumeclaims or an error.
+func (s *StorageAPI) ListPersistentVolumeClaimsByFieldAllNamespaces(ctx context.Context, fieldSelector string, namespaces *api.NamespaceFilter) ([]corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector"); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", "", "", "fieldSelector", "invalid field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", "", "", "failed to list persistentvolumeclaims by field in all namespaces", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return nsfilter.Keep(list.Items, namespaces), nil
+}
+
+// ListPersistentVolumeClaimsByQuery lists persistentvolumeclaims by namespace and query.
+//
+// Parameters:
+//   - ctx: Context for cancellation.
+//   - namespace: Namespace scope; empty for every namespace.
+//   - query: Label and field selectors, limit and resource version; see api.ListQuery.
+//
+// Returns the matching persistentvolumeclaims, at most query.Limit when set, or an
+// error.
+func (s *StorageAPI) ListPersistentVolumeClaimsByQuery(ctx context.Context, namespace string, query api.ListQuery) ([]corev1.PersistentVolumeClaim, error) {
+	if err := val.ValidateStruct(query); err != nil {
+		return nil, api.NewValidationError("PersistentVolumeClaim", namespace, "", "query", "invalid list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "persistentvolumeclaims", Namespace: namespace, LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*corev1.PersistentVolumeClaimList, error) {
+		return s.client.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("PersistentVolumeClaim", namespace, "", fmt.Sprintf("failed to list persistentvolumeclaims by query in namespace %q", namespace), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
@@ -0,0 +1,355 @@
+package storageapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/pod_api"
+)
+
+func TestStorageAPI_GetPersistentVolumeByName(t *testing.T) {
+	// Setup a cluster-scoped persistentvolume
+	testPersistentVolume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-persistentvolume",
+		},
+	}
+
+	// Create fake clientset with test persistentvolume
+	fakeClient := fake.NewClientset(testPersistentVolume)
+
+	// Initialize storage API
+	pvAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		pvName        string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:    "Successfully get persistentvolume",
+			pvName:  "test-persistentvolume",
+			wantErr: false,
+		},
+		{
+			name:          "Empty persistentvolume name",
+			pvName:        "",
+			wantErr:       true,
+			errorContains: "failed to validate persistentvolume name",
+		},
+		{
+			name:          "PersistentVolume not found",
+			pvName:        "nonexistent-persistentvolume",
+			wantErr:       true,
+			errorContains: "failed to get persistentvolume",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pv, err := pvAPI.GetPersistentVolumeByName(ctx, tt.pvName)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, pv)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, pv)
+				assert.Equal(t, tt.pvName, pv.Name)
+			}
+		})
+	}
+}
+
+func TestStorageAPI_ListPersistentVolumesByLabel(t *testing.T) {
+	// Setup test persistentvolumes
+	testPersistentVolumes := []*corev1.PersistentVolume{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-persistentvolume-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-persistentvolume-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-persistentvolume",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testPersistentVolumes {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize storage API
+	pvAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// Test cases
+	tests := []struct {
+		name          string
+		labelSelector string
+		expectedCount int
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List persistentvolumes by app label",
+			labelSelector: "app=test-app",
+			expectedCount: 2,
+			expectedNames: []string{"test-persistentvolume-1", "test-persistentvolume-2"},
+			wantErr:       false,
+		},
+		{
+			name:          "List persistentvolumes with multiple labels",
+			labelSelector: "app=test-app,environment=production",
+			expectedCount: 1,
+			expectedNames: []string{"test-persistentvolume-1"},
+			wantErr:       false,
+		},
+		{
+			name:          "No results",
+			labelSelector: "app=nonexistent",
+			expectedCount: 0,
+			expectedNames: []string{},
+			wantErr:       false,
+		},
+		{
+			name:          "Empty label selector",
+			labelSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+		{
+			name:          "Invalid label selector format",
+			labelSelector: "invalid@label",
+			wantErr:       true,
+			errorContains: "failed to validate label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			persistentVolumes, err := pvAPI.ListPersistentVolumesByLabel(ctx, tt.labelSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, persistentVolumes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, persistentVolumes, tt.expectedCount)
+
+				foundNames := make([]string, 0, len(persistentVolumes))
+				for _, item := range persistentVolumes {
+					foundNames = append(foundNames, item.Name)
+				}
+				assert.ElementsMatch(t, tt.expectedNames, foundNames)
+			}
+		})
+	}
+}
+
+func TestStorageAPI_ListPersistentVolumesByField(t *testing.T) {
+	// Setup test persistentvolumes
+	testPersistentVolumes := []*corev1.PersistentVolume{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-persistentvolume-1",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "production",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-persistentvolume-2",
+				Labels: map[string]string{
+					"app":         "test-app",
+					"environment": "staging",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other-persistentvolume",
+				Labels: map[string]string{
+					"app":         "other-app",
+					"environment": "production",
+				},
+			},
+		},
+	}
+
+	// Create fake clientset
+	fakeClient := fake.NewClientset()
+	for _, obj := range testPersistentVolumes {
+		require.NoError(t, fakeClient.Tracker().Add(obj))
+	}
+
+	// Initialize storage API
+	pvAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors, so every persistentvolume
+	// in the requested scope is returned
+	tests := []struct {
+		name          string
+		fieldSelector string
+		expectedCount int
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List persistentvolumes by field",
+			fieldSelector: "metadata.name=test-persistentvolume-1",
+			expectedCount: 3,
+			wantErr:       false,
+		},
+		{
+			name:          "Empty field selector",
+			fieldSelector: "",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+		{
+			name:          "Invalid field selector format",
+			fieldSelector: "invalid@field",
+			wantErr:       true,
+			errorContains: "failed to validate field selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			persistentVolumes, err := pvAPI.ListPersistentVolumesByField(ctx, tt.fieldSelector)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, persistentVolumes)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, persistentVolumes, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestStorageAPI_ListPersistentVolumesByQuery(t *testing.T) {
+	// Setup persistentvolumes with different labels
+	fakeClient := fake.NewClientset(
+		&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Labels: map[string]string{"app": "web"}}},
+		&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "db", Labels: map[string]string{"app": "db"}}},
+	)
+	pvAPI := NewStorageAPI(fakeClient, podapi.NewPodAPI(fakeClient))
+
+	// The fake clientset does not evaluate field selectors and limits, so only the
+	// label selector narrows the result; the request is checked to carry the whole query
+	tests := []struct {
+		name          string
+		query         api.ListQuery
+		expectedNames []string
+		wantErr       bool
+		errorContains string
+	}{
+		{
+			name:          "List by label selector",
+			query:         api.ListQuery{LabelSelector: "app=web"},
+			expectedNames: []string{"web-1", "web-2"},
+		},
+		{
+			name: "List by label and field selectors with limit and resource version",
+			query: api.ListQuery{
+				LabelSelector:   "app=web,tier=frontend",
+				FieldSelector:   "metadata.name=web-2",
+				Limit:           10,
+				ResourceVersion: "0",
+			},
+			expectedNames: []string{"web-2"},
+		},
+		{
+			name:          "List with empty query",
+			query:         api.ListQuery{},
+			expectedNames: []string{"web-1", "web-2", "db"},
+		},
+		{
+			name:          "Invalid label selector format",
+			query:         api.ListQuery{LabelSelector: "invalid@label"},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Negative limit",
+			query:         api.ListQuery{Limit: -1},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+		{
+			name:          "Resource version match without resource version",
+			query:         api.ListQuery{ResourceVersionMatch: metav1.ResourceVersionMatchExact},
+			wantErr:       true,
+			errorContains: "list query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient.ClearActions()
+
+			items, err := pvAPI.ListPersistentVolumesByQuery(context.Background(), tt.query)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, items)
+				assert.Empty(t, fakeClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+
+			require.Len(t, fakeClient.Actions(), 1)
+			listAction, ok := fakeClient.Actions()[0].(k8stesting.ListActionImpl)
+			require.True(t, ok)
+			assert.Equal(t, tt.query.ListOptions(), listAction.GetListOptions())
+		})
+	}
+}
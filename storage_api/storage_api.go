@@ -0,0 +1,55 @@
+package storageapi
+
+import (
+	"log/slog"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kaudit/api"
+)
+
+// StorageAPI provides high-level methods for retrieving PersistentVolumes,
+// PersistentVolumeClaims, StorageClasses and VolumeAttachments, and for resolving
+// the volume bound to each claim and the pods mounting it.
+type StorageAPI struct {
+	client  kubernetes.Interface
+	pods    api.PodAPI
+	limiter *api.RateLimiter
+	logger  *slog.Logger
+}
+
+// Option configures a StorageAPI.
+type Option func(*StorageAPI)
+
+// WithRateLimiter sends every request through limiter, so that it counts against a
+// limit shared with the other APIs using it. By default requests are not limited.
+func WithRateLimiter(limiter *api.RateLimiter) Option {
+	return func(s *StorageAPI) {
+		s.limiter = limiter
+	}
+}
+
+// WithLogger logs every request at debug level, with its verb, resource, namespace,
+// selector, duration and item count, and failed requests at warn level with their
+// error. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *StorageAPI) {
+		s.logger = logger
+	}
+}
+
+// NewStorageAPI creates a new StorageAPI instance using the provided client.
+//
+// The pods API is used to resolve the pods mounting a claim, see ListPodsForClaim.
+//
+// Options such as WithRateLimiter and WithLogger customize the instance.
+func NewStorageAPI(client kubernetes.Interface, pods api.PodAPI, opts ...Option) *StorageAPI {
+	s := &StorageAPI{
+		client: client,
+		pods:   pods,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,18 @@
+package storageapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kaudit/api/pod_api"
+)
+
+func TestNewStorageAPI(t *testing.T) {
+	client := fake.NewClientset()
+	storageAPI := NewStorageAPI(client, podapi.NewPodAPI(client))
+
+	assert.NotNil(t, storageAPI)
+	assert.Equal(t, client, storageAPI.client)
+}
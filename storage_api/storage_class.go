@@ -0,0 +1,151 @@
+package storageapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kaudit/val"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kaudit/api"
+	"github.com/kaudit/api/internal/reqlog"
+	"github.com/kaudit/api/internal/throttle"
+)
+
+// GetStorageClassByName retrieves a single StorageClass object by its name.
+//
+// The name parameter is validated to ensure it is not empty.
+// If the validation fails or if the retrieval from the Kubernetes API fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - name: The name of the cluster-scoped storageclass to retrieve.
+//
+// Returns a pointer to a storagev1.StorageClass object or an error if the storageclass
+// is not found or if any other retrieval error occurs.
+func (s *StorageAPI) GetStorageClassByName(ctx context.Context, name string) (*storagev1.StorageClass, error) {
+	err := val.ValidateWithTag(name, "required")
+	if err != nil {
+		return nil, api.NewValidationError("StorageClass", "", name, "name", "failed to validate storageclass name", err)
+	}
+
+	req := reqlog.Request{Verb: "get", Resource: "storageclasses", Name: name}
+	start := time.Now()
+	sc, err := throttle.Do(ctx, s.limiter, func() (*storagev1.StorageClass, error) {
+		return s.client.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+	})
+	if err != nil {
+		err = api.NewResourceError("StorageClass", "", name, fmt.Sprintf("failed to get storageclass %q", name), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, 1)
+	return sc, nil
+}
+
+// ListStorageClassesByLabel retrieves a list of StorageClass objects filtered by a label selector.
+//
+// The labelSelector parameter is validated to ensure it uses a valid Kubernetes
+// label selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - labelSelector: The Kubernetes-compliant label selector string.
+//
+// Returns a slice of storagev1.StorageClass objects matching the label selector, or
+// an error if the operation fails.
+func (s *StorageAPI) ListStorageClassesByLabel(ctx context.Context, labelSelector string) ([]storagev1.StorageClass, error) {
+	err := val.ValidateWithTag(labelSelector, "required,k8s_label_selector")
+	if err != nil {
+		return nil, api.NewValidationError("StorageClass", "", "", "labelSelector", "failed to validate label selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "storageclasses", LabelSelector: labelSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*storagev1.StorageClassList, error) {
+		return s.client.StorageV1().StorageClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StorageClass", "", "", fmt.Sprintf("failed to list storageclasses by label %q", labelSelector), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListStorageClassesByField retrieves a list of StorageClass objects filtered by a field selector.
+//
+// The fieldSelector parameter is validated to ensure it uses a valid Kubernetes
+// field selector syntax.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - fieldSelector: The Kubernetes-compliant field selector string.
+//
+// Returns a slice of storagev1.StorageClass objects matching the field selector, or
+// an error if the operation fails.
+func (s *StorageAPI) ListStorageClassesByField(ctx context.Context, fieldSelector string) ([]storagev1.StorageClass, error) {
+	err := val.ValidateWithTag(fieldSelector, "required,k8s_field_selector")
+	if err != nil {
+		return nil, api.NewValidationError("StorageClass", "", "", "fieldSelector", "failed to validate field selector", err)
+	}
+
+	opts := metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	}
+
+	req := reqlog.Request{Verb: "list", Resource: "storageclasses", FieldSelector: fieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*storagev1.StorageClassList, error) {
+		return s.client.StorageV1().StorageClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StorageClass", "", "", fmt.Sprintf("failed to list storageclasses by field %q", fieldSelector), err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
+
+// ListStorageClassesByQuery retrieves a list of StorageClass objects filtered by a query.
+//
+// The query is validated to ensure its selectors use a valid Kubernetes selector
+// syntax and its limit and resource version are consistent, see api.ListQuery.
+// If the validation fails or if the Kubernetes API call fails, an error is returned.
+//
+//   - ctx: The context to use for cancellation.
+//   - query: The label and field selectors, limit and resource version.
+//
+// Returns a slice of storagev1.StorageClass objects matching the query, at most query.Limit
+// when set, or an error if the operation fails.
+func (s *StorageAPI) ListStorageClassesByQuery(ctx context.Context, query api.ListQuery) ([]storagev1.StorageClass, error) {
+	err := val.ValidateStruct(query)
+	if err != nil {
+		return nil, api.NewValidationError("StorageClass", "", "", "query", "failed to validate list query", err)
+	}
+
+	opts := query.ListOptions()
+
+	req := reqlog.Request{Verb: "list", Resource: "storageclasses", LabelSelector: query.LabelSelector, FieldSelector: query.FieldSelector}
+	start := time.Now()
+	list, err := throttle.Do(ctx, s.limiter, func() (*storagev1.StorageClassList, error) {
+		return s.client.StorageV1().StorageClasses().List(ctx, opts)
+	})
+	if err != nil {
+		err = api.NewResourceError("StorageClass", "", "", "failed to list storageclasses by query", err)
+		reqlog.Failed(ctx, s.logger, req, start, err)
+		return nil, err
+	}
+	reqlog.Succeeded(ctx, s.logger, req, start, len(list.Items))
+
+	return list.Items, nil
+}
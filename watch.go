@@ -0,0 +1,23 @@
+package api
+
+// EventType identifies the kind of change carried by a WatchEvent.
+type EventType string
+
+const (
+	// EventAdded is emitted when an object is created, or when it is first observed
+	// after a watch had to be restarted from scratch.
+	EventAdded EventType = "ADDED"
+	// EventModified is emitted when an existing object is updated.
+	EventModified EventType = "MODIFIED"
+	// EventDeleted is emitted when an object is removed; it carries the last known state.
+	EventDeleted EventType = "DELETED"
+	// EventBookmark is emitted when the apiserver reports progress without a change.
+	// Only the resourceVersion of the carried object is meaningful.
+	EventBookmark EventType = "BOOKMARK"
+)
+
+// WatchEvent is a typed change notification delivered by the Watch* methods.
+type WatchEvent[T any] struct {
+	Type   EventType
+	Object T
+}